@@ -0,0 +1,200 @@
+package iosec
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/hyp3rd/hyperlogger"
+)
+
+const (
+	casLockSuffix   = ".lock"
+	casLockFileMode = 0o600
+)
+
+// SecureCompareAndSwap atomically replaces path with newData only when the SHA-256 digest of the
+// current contents equals expectedSHA256. A nil expectedSHA256 means the file must not exist yet.
+// It returns false without an error when the precondition does not hold.
+// The check and the replace run under an advisory lock on path+".lock", so concurrent callers are
+// serialized; writers that bypass SecureCompareAndSwap are not.
+func SecureCompareAndSwap(
+	path string,
+	expectedSHA256 []byte,
+	newData []byte,
+	opts WriteOptions,
+	log hyperlogger.Logger,
+) (bool, error) {
+	if expectedSHA256 != nil && len(expectedSHA256) != sha256.Size {
+		return false, ErrInvalidChecksum.WithMetadata(pathLabel, path)
+	}
+
+	normalized, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// The swap must never expose a partially written file to concurrent readers.
+	normalized.DisableAtomic = false
+	normalized.CreateExclusive = false
+
+	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	if err != nil {
+		return false, err
+	}
+
+	unlock, err := acquireCASLock(resolved, normalized.AllowSymlinks, path)
+	if err != nil {
+		return false, err
+	}
+
+	defer unlock()
+
+	if expectedSHA256 == nil {
+		return createIfAbsent(path, resolved, newData, normalized, log)
+	}
+
+	current, err := checksumFile(path, readOptsFromWriteOptions(normalized, normalized.MaxSizeBytes), log)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if subtle.ConstantTimeCompare(current, expectedSHA256) != 1 {
+		return false, nil
+	}
+
+	err = SecureWriteFile(path, newData, normalized, log)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createIfAbsent writes data to a temp file next to path and publishes it with a hard link,
+// which fails instead of replacing a file that appeared in the meantime.
+func createIfAbsent(path string, resolved resolvedPath, data []byte, opts WriteOptions, log hyperlogger.Logger) (bool, error) {
+	tempName, err := newTempName()
+	if err != nil {
+		return false, err
+	}
+
+	tempPath := filepath.Join(filepath.Dir(path), tempName)
+
+	tempResolved, err := resolvePath(tempPath, opts.BaseDir, opts.AllowedRoots, opts.AllowAbsolute)
+	if err != nil {
+		return false, err
+	}
+
+	if tempResolved.rootPath != resolved.rootPath {
+		return false, ErrInvalidPath.WithMetadata(pathLabel, path)
+	}
+
+	tempOpts := opts
+	tempOpts.CreateExclusive = true
+	tempOpts.BackupSuffix = ""
+	tempOpts.SyncDir = false
+
+	err = SecureWriteFile(tempPath, data, tempOpts, log)
+	if err != nil {
+		return false, err
+	}
+
+	err = publishNoReplace(tempResolved, resolved, opts.AllowSymlinks)
+	if errors.Is(err, fs.ErrExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, ewrap.Wrap(err, "failed to publish file").WithMetadata(pathLabel, path)
+	}
+
+	if opts.SyncDir && !opts.DisableSync {
+		err = syncDirOnDisk(filepath.Dir(resolved.fullPath), path)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// publishNoReplace links temp to target and removes temp, leaving an existing target untouched.
+func publishNoReplace(temp, target resolvedPath, allowSymlinks bool) error {
+	if allowSymlinks {
+		// #nosec G703 -- paths are derived from previously validated/contained write targets.
+		err := os.Link(temp.fullPath, target.fullPath)
+		_ = os.Remove(temp.fullPath)
+
+		return err
+	}
+
+	root, err := os.OpenRoot(target.rootPath)
+	if err != nil {
+		_ = os.Remove(temp.fullPath)
+
+		return err
+	}
+
+	defer func() { _ = root.Close() }()
+
+	err = root.Link(temp.relPath, target.relPath)
+	_ = root.Remove(temp.relPath)
+
+	return err
+}
+
+// acquireCASLock opens (creating if needed) the sibling lock file and takes an exclusive lock on it.
+// The lock file is left in place, since removing it would let a waiting caller lock a stale inode.
+func acquireCASLock(resolved resolvedPath, allowSymlinks bool, originalPath string) (func(), error) {
+	var (
+		file *os.File
+		err  error
+	)
+
+	if allowSymlinks {
+		// #nosec G304 -- path is validated against allowed roots and symlink policy.
+		file, err = os.OpenFile(resolved.fullPath+casLockSuffix, os.O_RDWR|os.O_CREATE, casLockFileMode)
+	} else {
+		var root *os.Root
+
+		root, err = os.OpenRoot(resolved.rootPath)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "failed to open root").WithMetadata(pathLabel, originalPath)
+		}
+
+		file, err = root.OpenFile(resolved.relPath+casLockSuffix, os.O_RDWR|os.O_CREATE, casLockFileMode)
+		_ = root.Close()
+	}
+
+	if err != nil {
+		return nil, ewrap.Wrap(err, "failed to open lock file").WithMetadata(pathLabel, originalPath)
+	}
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		_ = file.Close()
+
+		return nil, ErrNonRegularFile.WithMetadata(pathLabel, originalPath+casLockSuffix)
+	}
+
+	err = lockFile(file, resolved.fullPath)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, ewrap.Wrap(err, "failed to lock file").WithMetadata(pathLabel, originalPath)
+	}
+
+	return func() {
+		unlockFile(file, resolved.fullPath)
+		_ = file.Close()
+	}, nil
+}
@@ -1,12 +1,16 @@
 package iosec
 
 const (
-	tempFilePrefix  = ".sectools-"
-	tempRandBytes   = 16
-	tempMaxAttempts = 10
-	fileModeMask    = 0o777
-	rootDirRel      = "."
-	osWindows       = "windows"
+	tempFilePrefix   = ".sectools-"
+	tempRandBytes    = 16
+	tempMaxAttempts  = 10
+	fileModeMask     = 0o777
+	rootDirRel       = "."
+	osWindows        = "windows"
+	maxWipePasses    = 35
+	readDirBatchSize = 256
+	// readInitialBufferSize is the minimum starting capacity for streaming reads, as in os.ReadFile.
+	readInitialBufferSize = 512
 )
 
 const (
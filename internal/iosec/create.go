@@ -0,0 +1,400 @@
+package iosec
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/hyp3rd/hyperlogger"
+)
+
+// SecureCreateFile opens path for streaming writes with the same root, symlink, mode, and ownership
+// policy as SecureWriteFile. In atomic mode data goes to a temp file that Close renames over the
+// target; with DisableAtomic or CreateExclusive the target is written directly.
+// MaxSizeBytes is enforced on every Write. Once a Write fails, Close discards the data written so far
+// (the temp file, or a file created by this call) and returns the write error.
+func SecureCreateFile(path string, opts WriteOptions, log hyperlogger.Logger) (io.WriteCloser, error) {
+	normalized, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enforceSymlinkPolicy(resolved.fullPath, resolved.rootPath, resolved.relPath, normalized.AllowSymlinks, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateParentDir(resolved.fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	targetInfo, err := validateWriteTarget(resolved.fullPath, normalized, path)
+	if err != nil {
+		return nil, err
+	}
+
+	targetExists := targetInfo != nil
+	normalized = resolveWriteFileMode(targetInfo, normalized)
+
+	if normalized.AllowSymlinks {
+		if normalized.CreateExclusive || normalized.DisableAtomic {
+			return createDirectOnDisk(resolved.fullPath, normalized, log, path, targetExists)
+		}
+
+		return createAtomicOnDisk(resolved.fullPath, normalized, log, path)
+	}
+
+	root, err := os.OpenRoot(resolved.rootPath)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "failed to open root").WithMetadata(pathLabel, path)
+	}
+
+	var writer *secureFileWriter
+
+	if normalized.CreateExclusive || normalized.DisableAtomic {
+		writer, err = createDirectInRoot(root, resolved.relPath, normalized, log, path, targetExists)
+	} else {
+		writer, err = createAtomicInRoot(root, resolved.relPath, normalized, log, path)
+	}
+
+	if err != nil {
+		closeRoot(root, path, log)
+
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// secureFileWriter streams into an open file and finalizes the write on Close.
+// root is nil when the file was opened on disk because symlinks are allowed.
+type secureFileWriter struct {
+	mu sync.Mutex
+
+	file    *os.File
+	limited *limitedWriter
+	root    *os.Root
+	opts    WriteOptions
+	log     hyperlogger.Logger
+
+	originalPath string
+	// targetRel is root-relative, or a full path on disk.
+	targetRel string
+	// tempRel is set in atomic mode and follows the same convention as targetRel.
+	tempRel string
+	cleanup func(*bool)
+	created bool
+
+	err    error
+	closed bool
+}
+
+func createAtomicInRoot(
+	root *os.Root,
+	relPath string,
+	opts WriteOptions,
+	log hyperlogger.Logger,
+	originalPath string,
+) (*secureFileWriter, error) {
+	tempFile, tempRel, cleanup, err := prepareTempFileInRoot(root, filepath.Dir(relPath), originalPath, log)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyTempPermissions(tempFile, opts.FileMode, opts.OwnerUID, opts.OwnerGID, originalPath)
+	if err != nil {
+		closeFile(tempFile, originalPath, log)
+		cleanup(nil)
+
+		return nil, err
+	}
+
+	return newSecureFileWriter(tempFile, root, opts, log, originalPath, relPath, tempRel, cleanup, false), nil
+}
+
+func createAtomicOnDisk(path string, opts WriteOptions, log hyperlogger.Logger, originalPath string) (*secureFileWriter, error) {
+	tempFile, cleanup, err := prepareTempFile(filepath.Dir(path), originalPath, log)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyTempPermissions(tempFile, opts.FileMode, opts.OwnerUID, opts.OwnerGID, originalPath)
+	if err != nil {
+		closeFile(tempFile, originalPath, log)
+		cleanup(nil)
+
+		return nil, err
+	}
+
+	return newSecureFileWriter(tempFile, nil, opts, log, originalPath, path, tempFile.Name(), cleanup, false), nil
+}
+
+func createDirectInRoot(
+	root *os.Root,
+	relPath string,
+	opts WriteOptions,
+	log hyperlogger.Logger,
+	originalPath string,
+	targetExists bool,
+) (*secureFileWriter, error) {
+	perm, needsChmod := createPerm(opts.FileMode)
+
+	var (
+		file    *os.File
+		created = true
+		err     error
+	)
+
+	if opts.CreateExclusive {
+		file, err = openExclusiveFile(root, relPath, perm, originalPath)
+	} else {
+		file, created, err = openDirectFile(root, relPath, perm, targetExists)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyFileMode(file, opts.FileMode, needsChmod, created, opts.EnforceFileMode, originalPath)
+	if err == nil {
+		err = validateFileOwnership(file, opts.OwnerUID, opts.OwnerGID, originalPath)
+	}
+
+	if err != nil {
+		closeFile(file, originalPath, log)
+
+		if created {
+			removeFileInRoot(root, relPath, originalPath, log)
+		}
+
+		return nil, err
+	}
+
+	return newSecureFileWriter(file, root, opts, log, originalPath, relPath, "", nil, created), nil
+}
+
+func createDirectOnDisk(
+	path string,
+	opts WriteOptions,
+	log hyperlogger.Logger,
+	originalPath string,
+	targetExists bool,
+) (*secureFileWriter, error) {
+	perm, needsChmod := createPerm(opts.FileMode)
+
+	var (
+		file    *os.File
+		created = true
+		err     error
+	)
+
+	if opts.CreateExclusive {
+		// #nosec G304 -- path is validated against allowed roots and symlink policy.
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) {
+			return nil, ErrFileExists.WithMetadata(pathLabel, originalPath)
+		}
+
+		if err != nil {
+			return nil, ewrap.Wrap(err, "failed to create file").WithMetadata(pathLabel, originalPath)
+		}
+	} else {
+		file, created, err = openDirectFileOnDisk(path, perm, targetExists, originalPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = applyFileMode(file, opts.FileMode, needsChmod, created, opts.EnforceFileMode, originalPath)
+	if err == nil {
+		err = validateFileOwnership(file, opts.OwnerUID, opts.OwnerGID, originalPath)
+	}
+
+	if err != nil {
+		closeFile(file, originalPath, log)
+
+		if created {
+			removeFileOnDisk(path, originalPath, log)
+		}
+
+		return nil, err
+	}
+
+	return newSecureFileWriter(file, nil, opts, log, originalPath, path, "", nil, created), nil
+}
+
+//nolint:revive
+func newSecureFileWriter(
+	file *os.File,
+	root *os.Root,
+	opts WriteOptions,
+	log hyperlogger.Logger,
+	originalPath string,
+	targetRel string,
+	tempRel string,
+	cleanup func(*bool),
+	created bool,
+) *secureFileWriter {
+	return &secureFileWriter{
+		file:         file,
+		limited:      &limitedWriter{writer: file, max: opts.MaxSizeBytes},
+		root:         root,
+		opts:         opts,
+		log:          log,
+		originalPath: originalPath,
+		targetRel:    targetRel,
+		tempRel:      tempRel,
+		cleanup:      cleanup,
+		created:      created,
+	}
+}
+
+// Write appends data to the file, failing with ErrFileTooLarge once MaxSizeBytes would be exceeded.
+func (w *secureFileWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrWriterClosed.WithMetadata(pathLabel, w.originalPath)
+	}
+
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	n, err := w.limited.Write(data)
+	if err != nil {
+		if errors.Is(err, ErrFileTooLarge) {
+			w.err = ErrFileTooLarge.WithMetadata(pathLabel, w.originalPath)
+		} else {
+			w.err = ewrap.Wrap(err, "failed to write file").WithMetadata(pathLabel, w.originalPath)
+		}
+
+		return n, w.err
+	}
+
+	return n, nil
+}
+
+// Close syncs the file and, in atomic mode, renames the temp file over the target.
+// Calling Close more than once returns nil.
+func (w *secureFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	defer closeRoot(w.root, w.originalPath, w.log)
+
+	if w.err != nil {
+		w.abort()
+
+		return w.err
+	}
+
+	if w.tempRel != "" {
+		return w.commitAtomic()
+	}
+
+	return w.commitDirect()
+}
+
+// abort closes the file and removes whatever this writer created.
+func (w *secureFileWriter) abort() {
+	closeFile(w.file, w.originalPath, w.log)
+
+	if w.cleanup != nil {
+		w.cleanup(nil)
+
+		return
+	}
+
+	if !w.created {
+		return
+	}
+
+	if w.root != nil {
+		removeFileInRoot(w.root, w.targetRel, w.originalPath, w.log)
+
+		return
+	}
+
+	removeFileOnDisk(w.targetRel, w.originalPath, w.log)
+}
+
+func (w *secureFileWriter) commitAtomic() error {
+	success := false
+	defer w.cleanup(&success)
+
+	err := syncTempFile(w.file, w.opts.DisableSync, w.originalPath)
+	if err != nil {
+		closeFile(w.file, w.originalPath, w.log)
+
+		return err
+	}
+
+	err = closeTempFile(w.file, w.log, w.originalPath)
+	if err != nil {
+		return err
+	}
+
+	if w.root != nil {
+		err = replaceTargetFile(w.root, w.tempRel, w.targetRel, w.opts, w.originalPath)
+	} else {
+		err = replaceTargetFileOnDisk(w.tempRel, w.targetRel, w.opts, w.originalPath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	success = true
+
+	if w.opts.SyncDir && !w.opts.DisableSync {
+		return w.syncDir()
+	}
+
+	return nil
+}
+
+func (w *secureFileWriter) commitDirect() error {
+	if !w.opts.DisableSync {
+		err := w.file.Sync()
+		if err != nil {
+			closeFile(w.file, w.originalPath, w.log)
+
+			return ewrap.Wrap(err, "failed to sync file").WithMetadata(pathLabel, w.originalPath)
+		}
+	}
+
+	err := w.file.Close()
+	if err != nil {
+		return ewrap.Wrap(err, "failed to close file").WithMetadata(pathLabel, w.originalPath)
+	}
+
+	if w.opts.SyncDir && w.created && !w.opts.DisableSync {
+		return w.syncDir()
+	}
+
+	return nil
+}
+
+func (w *secureFileWriter) syncDir() error {
+	if w.root != nil {
+		return syncDirInRoot(w.root, filepath.Dir(w.targetRel), w.originalPath)
+	}
+
+	return syncDirOnDisk(filepath.Dir(w.targetRel), w.originalPath)
+}
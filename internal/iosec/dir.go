@@ -1,6 +1,8 @@
 package iosec
 
 import (
+	"errors"
+	"io"
 	"os"
 
 	"github.com/hyp3rd/ewrap"
@@ -13,53 +15,139 @@ func SecureReadDir(path string, log hyperlogger.Logger) ([]os.DirEntry, error) {
 }
 
 // SecureReadDirWithOptions reads a directory securely with configurable options.
+// When MaxEntries is set, entries are read in batches and ErrTooManyEntries is returned
+// as soon as the directory holds more than MaxEntries entries.
 func SecureReadDirWithOptions(path string, opts ReadOptions, log hyperlogger.Logger) ([]os.DirEntry, error) {
 	normalized, err := normalizeReadOptions(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	dir, err := openSecureDir(path, normalized, log)
 	if err != nil {
 		return nil, err
 	}
 
-	err = enforceSymlinkPolicy(resolved.fullPath, resolved.rootPath, resolved.relPath, normalized.AllowSymlinks, false)
+	defer closeFile(dir, path, log)
+
+	var entries []os.DirEntry
+
+	if normalized.MaxEntries == 0 {
+		entries, err = dir.ReadDir(-1)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "failed to read directory").WithMetadata(pathLabel, path)
+		}
+
+		return entries, nil
+	}
+
+	err = readDirBatches(dir, path, normalized.MaxEntries, func(entry os.DirEntry) error {
+		entries = append(entries, entry)
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	dir, err := openFileHandle(resolved, normalized.AllowSymlinks, log, path)
+	return entries, nil
+}
+
+// SecureReadDirIter streams directory entries to fn in batches without loading the whole directory.
+// Iteration stops at the first error returned by fn, which is returned unchanged.
+// When MaxEntries is set, ErrTooManyEntries is returned once more than MaxEntries entries were seen.
+func SecureReadDirIter(path string, opts ReadOptions, fn func(os.DirEntry) error, log hyperlogger.Logger) error {
+	if fn == nil {
+		return ErrNilCallback
+	}
+
+	normalized, err := normalizeReadOptions(opts)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	dir, err := openSecureDir(path, normalized, log)
+	if err != nil {
+		return err
 	}
 
 	defer closeFile(dir, path, log)
 
-	info, err := dir.Stat()
+	return readDirBatches(dir, path, normalized.MaxEntries, fn)
+}
+
+// openSecureDir opens path and applies the directory, permission, and ownership checks.
+func openSecureDir(path string, opts ReadOptions, log hyperlogger.Logger) (*os.File, error) {
+	resolved, err := resolvePath(path, opts.BaseDir, opts.AllowedRoots, opts.AllowAbsolute)
 	if err != nil {
-		return nil, ewrap.Wrap(err, "failed to stat directory").WithMetadata(pathLabel, path)
+		return nil, err
 	}
 
-	if !info.IsDir() {
-		return nil, ErrNotDirectory.WithMetadata(pathLabel, path)
+	err = enforceSymlinkPolicy(resolved.fullPath, resolved.rootPath, resolved.relPath, opts.AllowSymlinks, false)
+	if err != nil {
+		return nil, err
 	}
 
-	if normalized.DisallowPerms != 0 && info.Mode().Perm()&normalized.DisallowPerms != 0 {
-		return nil, ErrPermissionsNotAllowed.WithMetadata(pathLabel, path)
+	dir, err := openFileHandle(resolved, opts.AllowSymlinks, log, path)
+	if err != nil {
+		return nil, err
 	}
 
-	err = validateOwnership(info, normalized.OwnerUID, normalized.OwnerGID, path)
+	err = validateOpenDir(dir, opts, path)
 	if err != nil {
+		closeFile(dir, path, log)
+
 		return nil, err
 	}
 
-	entries, err := dir.ReadDir(-1)
+	return dir, nil
+}
+
+func validateOpenDir(dir *os.File, opts ReadOptions, path string) error {
+	info, err := dir.Stat()
 	if err != nil {
-		return nil, ewrap.Wrap(err, "failed to read directory").WithMetadata(pathLabel, path)
+		return ewrap.Wrap(err, "failed to stat directory").WithMetadata(pathLabel, path)
 	}
 
-	return entries, nil
+	if !info.IsDir() {
+		return ErrNotDirectory.WithMetadata(pathLabel, path)
+	}
+
+	if opts.DisallowPerms != 0 && info.Mode().Perm()&opts.DisallowPerms != 0 {
+		return ErrPermissionsNotAllowed.WithMetadata(pathLabel, path)
+	}
+
+	return validateOwnership(info, opts.OwnerUID, opts.OwnerGID, path)
+}
+
+// readDirBatches reads dir in batches of readDirBatchSize and passes each entry to fn.
+// A maxEntries of zero means no limit.
+func readDirBatches(dir *os.File, path string, maxEntries int, fn func(os.DirEntry) error) error {
+	seen := 0
+
+	for {
+		batch, err := dir.ReadDir(readDirBatchSize)
+
+		for _, entry := range batch {
+			seen++
+			if maxEntries > 0 && seen > maxEntries {
+				return ErrTooManyEntries.WithMetadata(pathLabel, path)
+			}
+
+			cbErr := fn(entry)
+			if cbErr != nil {
+				return cbErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return ewrap.Wrap(err, "failed to read directory").WithMetadata(pathLabel, path)
+		}
+	}
 }
 
 // SecureMkdirAll creates a directory securely with configurable options.
@@ -43,6 +43,22 @@ var (
 	ErrOwnershipUnsupported = ewrap.New("ownership checks are not supported")
 	// ErrInvalidTempPrefix indicates a temp prefix was invalid.
 	ErrInvalidTempPrefix = ewrap.New("invalid temp prefix")
+	// ErrInvalidBackupSuffix indicates a backup suffix was invalid.
+	ErrInvalidBackupSuffix = ewrap.New("invalid backup suffix")
+	// ErrInvalidWipePasses indicates a wipe pass count was invalid.
+	ErrInvalidWipePasses = ewrap.New("invalid wipe passes")
+	// ErrBackupFailed indicates the existing target could not be backed up before replacement.
+	ErrBackupFailed = ewrap.New("failed to back up target file")
+	// ErrMaxEntriesInvalid indicates the configured max directory entries is invalid.
+	ErrMaxEntriesInvalid = ewrap.New("max entries cannot be negative")
+	// ErrTooManyEntries indicates a directory holds more entries than the configured maximum.
+	ErrTooManyEntries = ewrap.New("directory exceeds maximum entries")
+	// ErrNilCallback indicates a nil callback was provided.
+	ErrNilCallback = ewrap.New("callback cannot be nil")
+	// ErrInvalidChecksum indicates a provided checksum has the wrong length.
+	ErrInvalidChecksum = ewrap.New("invalid checksum")
 	// ErrChecksumMismatch indicates a checksum verification failure.
 	ErrChecksumMismatch = ewrap.New("checksum mismatch")
+	// ErrWriterClosed indicates a write to a file writer that was already closed.
+	ErrWriterClosed = ewrap.New("file writer is closed")
 )
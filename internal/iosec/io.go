@@ -2,6 +2,7 @@
 package iosec
 
 import (
+	"errors"
 	"io"
 	"os"
 
@@ -60,6 +61,8 @@ func SecureReadFileWithOptions(path string, opts ReadOptions, log hyperlogger.Lo
 		}
 	}()
 
+	// openFileWithOptions has already rejected negative sizes and sizes above MaxSizeBytes,
+	// so the buffer below is never allocated for a file the caller asked to limit.
 	maxInt := int64(^uint(0) >> 1)
 	if info.Size() > maxInt {
 		return nil, ErrFileTooLarge.WithMetadata(pathLabel, path)
@@ -89,14 +92,85 @@ func SecureOpenFile(path string, opts ReadOptions, log hyperlogger.Logger) (*os.
 	return file, nil
 }
 
+// SecureOpenFileLimited opens a file for streaming reads and enforces MaxSizeBytes while reading.
+// Reads past the limit return ErrFileTooLarge, which also covers files that grow after opening.
+// Closing the returned reader closes the underlying file.
+func SecureOpenFileLimited(path string, opts ReadOptions, log hyperlogger.Logger) (io.ReadCloser, error) {
+	file, _, err := openFileWithOptions(path, opts, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MaxSizeBytes <= 0 {
+		return file, nil
+	}
+
+	return &limitedReadCloser{
+		file: file,
+		max:  opts.MaxSizeBytes,
+		path: path,
+	}, nil
+}
+
+type limitedReadCloser struct {
+	file     *os.File
+	max      int64
+	read     int64
+	exceeded bool
+	path     string
+}
+
+func (lr *limitedReadCloser) Read(data []byte) (int, error) {
+	if lr.exceeded {
+		return 0, ErrFileTooLarge.WithMetadata(pathLabel, lr.path)
+	}
+
+	remaining := lr.max - lr.read
+	if int64(len(data)) > remaining+1 {
+		// Read one byte past the limit so an oversized stream is detected.
+		data = data[:remaining+1]
+	}
+
+	bytesRead, err := lr.file.Read(data)
+	if int64(bytesRead) > remaining {
+		lr.read = lr.max
+		lr.exceeded = true
+
+		return int(remaining), ErrFileTooLarge.WithMetadata(pathLabel, lr.path)
+	}
+
+	lr.read += int64(bytesRead)
+
+	//nolint:wrapcheck // io.EOF must be returned unwrapped to readers.
+	return bytesRead, err
+}
+
+func (lr *limitedReadCloser) Close() error {
+	err := lr.file.Close()
+	if err != nil {
+		return ewrap.Wrap(err, "failed to close file").WithMetadata(pathLabel, lr.path)
+	}
+
+	return nil
+}
+
 // SecureReadFileWithSecureBuffer reads a file securely and returns its contents in a SecureBuffer.
 func SecureReadFileWithSecureBuffer(path string, log hyperlogger.Logger) (*memory.SecureBuffer, error) {
 	return SecureReadFileWithSecureBufferOptions(path, ReadOptions{}, log)
 }
 
 // SecureReadFileWithSecureBufferOptions reads a file securely with options and returns its contents in a SecureBuffer.
+// MaxSizeBytes is enforced while reading, not only against the size reported when the file is opened,
+// so files that grow afterwards and non-regular files allowed by AllowNonRegular are also bounded.
+// Intermediate buffers are zeroed on success and on error.
 func SecureReadFileWithSecureBufferOptions(path string, opts ReadOptions, log hyperlogger.Logger) (*memory.SecureBuffer, error) {
-	data, err := SecureReadFileWithOptions(path, opts, log)
+	file, info, err := openFileWithOptions(path, opts, log)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(file, path, log)
+
+	data, err := readAllZeroing(file, info.Size(), opts.MaxSizeBytes, path)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +181,57 @@ func SecureReadFileWithSecureBufferOptions(path string, opts ReadOptions, log hy
 	return secureBuffer, nil
 }
 
+// readAllZeroing reads reader to EOF, starting from sizeHint bytes of capacity and returning
+// ErrFileTooLarge once more than maxSize bytes are read (maxSize <= 0 means no limit).
+// Unlike io.ReadAll, every buffer it outgrows is zeroed, and so is the result on error.
+func readAllZeroing(reader io.Reader, sizeHint, maxSize int64, path string) ([]byte, error) {
+	maxInt := int64(^uint(0) >> 1)
+	if sizeHint < 0 || sizeHint >= maxInt || maxSize >= maxInt {
+		return nil, ErrFileTooLarge.WithMetadata(pathLabel, path)
+	}
+
+	// One spare byte lets a file of exactly sizeHint bytes reach EOF without growing.
+	capacity := max(sizeHint+1, readInitialBufferSize)
+	if maxSize > 0 {
+		capacity = min(capacity, maxSize+1)
+	}
+
+	buf := make([]byte, 0, int(capacity))
+
+	for {
+		if len(buf) == cap(buf) {
+			newCap := int64(cap(buf)) * 2
+			if maxSize > 0 {
+				newCap = min(newCap, maxSize+1)
+			}
+
+			grown := make([]byte, len(buf), int(newCap))
+			copy(grown, buf)
+			memory.ZeroBytes(buf)
+			buf = grown
+		}
+
+		bytesRead, err := reader.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+bytesRead]
+
+		if maxSize > 0 && int64(len(buf)) > maxSize {
+			memory.ZeroBytes(buf)
+
+			return nil, ErrFileTooLarge.WithMetadata(pathLabel, path)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return buf, nil
+		}
+
+		if err != nil {
+			memory.ZeroBytes(buf)
+
+			return nil, ewrap.Wrap(err, "failed to read file").WithMetadata(pathLabel, path)
+		}
+	}
+}
+
 func openFileWithOptions(path string, opts ReadOptions, log hyperlogger.Logger) (*os.File, os.FileInfo, error) {
 	normalized, err := normalizeReadOptions(opts)
 	if err != nil {
@@ -1,6 +1,7 @@
 package iosec
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -107,3 +108,21 @@ func TestSecurePathSymlink(t *testing.T) {
 		})
 	}
 }
+
+func TestReadAllZeroingEnforcesMaxSize(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("k"), 2*readInitialBufferSize)
+
+	// A small size hint stands in for a file that grew after it was opened.
+	got, err := readAllZeroing(bytes.NewReader(data), 4, 0, "grown")
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	got, err = readAllZeroing(bytes.NewReader(data), 4, int64(len(data)), "exact")
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	_, err = readAllZeroing(bytes.NewReader(data), 4, int64(len(data)-1), "oversized")
+	require.ErrorIs(t, err, ErrFileTooLarge)
+}
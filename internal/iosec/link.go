@@ -0,0 +1,86 @@
+package iosec
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/hyp3rd/hyperlogger"
+)
+
+// SecureReadlink returns the target of the symlink at path without following it.
+// The link is read through os.Root, so it must live inside the allowed roots; parent
+// directories must not be symlinks unless AllowSymlinks is set. The target is returned
+// as stored and is not validated; use SecureRealpath to check where it leads.
+func SecureReadlink(path string, opts ReadOptions, log hyperlogger.Logger) (string, error) {
+	normalized, err := normalizeReadOptions(opts)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	if err != nil {
+		return "", err
+	}
+
+	parent := filepath.Dir(resolved.relPath)
+	if parent != "." && !normalized.AllowSymlinks {
+		err = rejectSymlinkComponents(resolved.rootPath, parent, false)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	root, err := os.OpenRoot(resolved.rootPath)
+	if err != nil {
+		return "", ewrap.Wrap(err, "failed to open root").WithMetadata(pathLabel, path)
+	}
+
+	defer closeRoot(root, path, log)
+
+	target, err := root.Readlink(resolved.relPath)
+	if err != nil {
+		return "", ewrap.Wrap(err, "failed to read symlink").WithMetadata(pathLabel, path)
+	}
+
+	return target, nil
+}
+
+// SecureRealpath returns the absolute path with every symlink resolved, without opening it.
+// The result must lie within one of the allowed roots, or ErrPathEscapesRoot is returned;
+// the check follows symlinks regardless of AllowSymlinks, since resolving them is its purpose.
+// The answer can change if the tree is modified afterwards.
+func SecureRealpath(path string, opts ReadOptions, _ hyperlogger.Logger) (string, error) {
+	normalized, err := normalizeReadOptions(opts)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	if err != nil {
+		return "", err
+	}
+
+	realPath, err := filepath.EvalSymlinks(resolved.fullPath)
+	if err != nil {
+		return "", ewrap.Wrap(err, "failed to resolve symlink").WithMetadata(pathLabel, path)
+	}
+
+	for _, root := range normalized.AllowedRoots {
+		resolvedRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			resolvedRoot = root
+		}
+
+		ok, err := isWithinRoot(realPath, resolvedRoot)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return realPath, nil
+		}
+	}
+
+	return "", ErrPathEscapesRoot.WithMetadata(pathLabel, path)
+}
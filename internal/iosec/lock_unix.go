@@ -0,0 +1,24 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package iosec
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive flock on file. flock locks belong to the open file description, so
+// separate opens in the same process exclude each other as well as other processes.
+func lockFile(file *os.File, _ string) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX) //nolint:gosec // fds fit in int.
+		if !errors.Is(err, syscall.EINTR) {
+			return err
+		}
+	}
+}
+
+func unlockFile(file *os.File, _ string) {
+	_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN) //nolint:gosec // fds fit in int.
+}
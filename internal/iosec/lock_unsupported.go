@@ -0,0 +1,32 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package iosec
+
+import (
+	"hash/fnv"
+	"os"
+	"sync"
+)
+
+const lockStripes = 64
+
+// Without flock only callers in this process are serialized, using a fixed set of
+// mutexes selected by path so the lock table never grows.
+var lockStripeMu [lockStripes]sync.Mutex
+
+func lockFile(_ *os.File, path string) error {
+	lockStripe(path).Lock()
+
+	return nil
+}
+
+func unlockFile(_ *os.File, path string) {
+	lockStripe(path).Unlock()
+}
+
+func lockStripe(path string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(path))
+
+	return &lockStripeMu[h.Sum32()%lockStripes]
+}
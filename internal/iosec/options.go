@@ -7,6 +7,7 @@ type ReadOptions struct {
 	BaseDir         string
 	AllowedRoots    []string
 	MaxSizeBytes    int64
+	MaxEntries      int
 	AllowAbsolute   bool
 	AllowSymlinks   bool
 	AllowNonRegular bool
@@ -28,6 +29,8 @@ type WriteOptions struct {
 	AllowAbsolute   bool
 	AllowSymlinks   bool
 	EnforceFileMode bool
+	PreserveMode    bool
+	BackupSuffix    string
 	OwnerUID        *int
 	OwnerGID        *int
 }
@@ -64,6 +67,8 @@ type RemoveOptions struct {
 	AllowAbsolute bool
 	AllowSymlinks bool
 	Wipe          bool
+	WipePasses    int
+	WipeRandom    bool
 	OwnerUID      *int
 	OwnerGID      *int
 }
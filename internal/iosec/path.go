@@ -29,6 +29,10 @@ func normalizeReadOptions(opts ReadOptions) (ReadOptions, error) {
 		return opts, ErrMaxSizeInvalid
 	}
 
+	if opts.MaxEntries < 0 {
+		return opts, ErrMaxEntriesInvalid
+	}
+
 	if opts.DisallowPerms&^fileModeMask != 0 {
 		return opts, ErrInvalidPermissions
 	}
@@ -115,6 +119,11 @@ func normalizeWriteOptions(opts WriteOptions) (WriteOptions, error) {
 		return opts, ErrMaxSizeInvalid
 	}
 
+	err = validateBackupSuffix(opts.BackupSuffix, opts.DisableAtomic)
+	if err != nil {
+		return opts, err
+	}
+
 	if opts.FileMode == 0 {
 		opts.FileMode = 0o600
 	}
@@ -189,6 +198,14 @@ func normalizeRemoveOptions(opts RemoveOptions) (RemoveOptions, error) {
 		return opts, err
 	}
 
+	if opts.WipePasses < 0 || opts.WipePasses > maxWipePasses {
+		return opts, ErrInvalidWipePasses
+	}
+
+	if opts.WipePasses == 0 {
+		opts.WipePasses = 1
+	}
+
 	if opts.BaseDir == "" {
 		if len(opts.AllowedRoots) > 0 {
 			opts.BaseDir = opts.AllowedRoots[0]
@@ -1,6 +1,7 @@
 package iosec
 
 import (
+	"crypto/rand"
 	"io"
 	"os"
 
@@ -65,7 +66,7 @@ func removeOnDisk(
 	}
 
 	if opts.Wipe {
-		wipeFileOnDisk(fullPath, originalPath, log)
+		wipeFileOnDisk(fullPath, originalPath, opts, log)
 	}
 
 	// #nosec G304 -- path is validated against allowed roots and symlink policy.
@@ -107,7 +108,7 @@ func removeInRoot(
 	}
 
 	if opts.Wipe {
-		wipeFileInRoot(root, resolved.relPath, originalPath, log)
+		wipeFileInRoot(root, resolved.relPath, originalPath, opts, log)
 	}
 
 	err = root.Remove(resolved.relPath)
@@ -171,7 +172,7 @@ func validateRemoveOwnershipInRoot(
 	return validateOwnership(info, opts.OwnerUID, opts.OwnerGID, originalPath)
 }
 
-func wipeFileOnDisk(path, originalPath string, log hyperlogger.Logger) {
+func wipeFileOnDisk(path, originalPath string, opts RemoveOptions, log hyperlogger.Logger) {
 	// #nosec G304 -- path is validated against allowed roots and symlink policy.
 	file, err := os.OpenFile(path, os.O_WRONLY, 0)
 	if err != nil {
@@ -196,13 +197,13 @@ func wipeFileOnDisk(path, originalPath string, log hyperlogger.Logger) {
 		return
 	}
 
-	err = wipeFileContents(file, info.Size())
+	err = wipeFilePasses(file, info.Size(), opts.WipePasses, opts.WipeRandom)
 	if err != nil && log != nil {
 		log.WithError(err).Errorf("failed to wipe file contents: %v", originalPath)
 	}
 }
 
-func wipeFileInRoot(root *os.Root, relPath, originalPath string, log hyperlogger.Logger) {
+func wipeFileInRoot(root *os.Root, relPath, originalPath string, opts RemoveOptions, log hyperlogger.Logger) {
 	file, err := root.OpenFile(relPath, os.O_WRONLY, 0)
 	if err != nil {
 		if log != nil {
@@ -226,13 +227,30 @@ func wipeFileInRoot(root *os.Root, relPath, originalPath string, log hyperlogger
 		return
 	}
 
-	err = wipeFileContents(file, info.Size())
+	err = wipeFilePasses(file, info.Size(), opts.WipePasses, opts.WipeRandom)
 	if err != nil && log != nil {
 		log.WithError(err).Errorf("failed to wipe file contents: %v", originalPath)
 	}
 }
 
+// wipeFilePasses overwrites the file the requested number of times. The first pass writes zeros;
+// later passes write random data when random is set and zeros otherwise. Each pass is synced.
+func wipeFilePasses(file *os.File, size int64, passes int, random bool) error {
+	for pass := range max(passes, 1) {
+		err := overwriteFileContents(file, size, random && pass > 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func wipeFileContents(file *os.File, size int64) error {
+	return overwriteFileContents(file, size, false)
+}
+
+func overwriteFileContents(file *os.File, size int64, random bool) error {
 	if size <= 0 {
 		return nil
 	}
@@ -246,6 +264,13 @@ func wipeFileContents(file *os.File, size int64) error {
 	for size > 0 {
 		toWrite := min(size, int64(len(buf)))
 
+		if random {
+			_, err := rand.Read(buf[:toWrite])
+			if err != nil {
+				return ewrap.Wrap(err, "failed to generate random data")
+			}
+		}
+
 		bytes, err := file.Write(buf[:toWrite])
 		if err != nil {
 			return ewrap.Wrap(err, "failed to write file", ewrap.WithRetry(maxRetryAttempts, retryDelay))
@@ -0,0 +1,119 @@
+package iosec
+
+import (
+	"os"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/hyp3rd/hyperlogger"
+)
+
+// SecureTruncateFile overwrites an existing regular file with zeros and truncates it to zero length.
+// The file keeps its inode, permissions, and ownership.
+func SecureTruncateFile(path string, opts WriteOptions, log hyperlogger.Logger) error {
+	normalized, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolvePath(path, normalized.BaseDir, normalized.AllowedRoots, normalized.AllowAbsolute)
+	if err != nil {
+		return err
+	}
+
+	err = enforceSymlinkPolicy(resolved.fullPath, resolved.rootPath, resolved.relPath, normalized.AllowSymlinks, false)
+	if err != nil {
+		return err
+	}
+
+	if normalized.AllowSymlinks {
+		return truncateOnDisk(resolved.fullPath, path, normalized, log)
+	}
+
+	return truncateInRoot(resolved, path, normalized, log)
+}
+
+func truncateOnDisk(fullPath, originalPath string, opts WriteOptions, log hyperlogger.Logger) error {
+	// #nosec G304 -- path is validated against allowed roots and symlink policy.
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to stat file").WithMetadata(pathLabel, originalPath)
+	}
+
+	// Reject non-regular files before opening so FIFOs and devices are never opened for writing.
+	if !info.Mode().IsRegular() {
+		return ErrNonRegularFile.WithMetadata(pathLabel, originalPath)
+	}
+
+	// #nosec G304 -- path is validated against allowed roots and symlink policy.
+	file, err := os.OpenFile(fullPath, os.O_WRONLY, 0)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to open file").WithMetadata(pathLabel, originalPath)
+	}
+	defer closeFile(file, originalPath, log)
+
+	return truncateOpenFile(file, info, originalPath, opts)
+}
+
+func truncateInRoot(resolved resolvedPath, originalPath string, opts WriteOptions, log hyperlogger.Logger) error {
+	root, err := os.OpenRoot(resolved.rootPath)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to open root").WithMetadata(pathLabel, originalPath)
+	}
+	defer closeRoot(root, originalPath, log)
+
+	info, err := root.Lstat(resolved.relPath)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to stat file").WithMetadata(pathLabel, originalPath)
+	}
+
+	if !info.Mode().IsRegular() {
+		return ErrNonRegularFile.WithMetadata(pathLabel, originalPath)
+	}
+
+	file, err := root.OpenFile(resolved.relPath, os.O_WRONLY, 0)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to open file").WithMetadata(pathLabel, originalPath)
+	}
+	defer closeFile(file, originalPath, log)
+
+	return truncateOpenFile(file, info, originalPath, opts)
+}
+
+// truncateOpenFile wipes and truncates file after confirming it is still the regular file
+// that was checked before opening.
+func truncateOpenFile(file *os.File, checked os.FileInfo, originalPath string, opts WriteOptions) error {
+	info, err := file.Stat()
+	if err != nil {
+		return ewrap.Wrap(err, "failed to stat file").WithMetadata(pathLabel, originalPath)
+	}
+
+	if !info.Mode().IsRegular() || !os.SameFile(checked, info) {
+		return ErrNonRegularFile.WithMetadata(pathLabel, originalPath)
+	}
+
+	err = validateOwnership(info, opts.OwnerUID, opts.OwnerGID, originalPath)
+	if err != nil {
+		return err
+	}
+
+	err = wipeFileContents(file, info.Size())
+	if err != nil {
+		return ewrap.Wrap(err, "failed to wipe file contents").WithMetadata(pathLabel, originalPath)
+	}
+
+	err = file.Truncate(0)
+	if err != nil {
+		return ewrap.Wrap(err, "failed to truncate file").WithMetadata(pathLabel, originalPath)
+	}
+
+	if opts.DisableSync {
+		return nil
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return ewrap.Wrap(err, "failed to sync file").WithMetadata(pathLabel, originalPath)
+	}
+
+	return nil
+}
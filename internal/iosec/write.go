@@ -41,11 +41,14 @@ func SecureWriteFile(path string, data []byte, opts WriteOptions, log hyperlogge
 		return err
 	}
 
-	targetExists, err := validateWriteTarget(resolved.fullPath, normalized, path)
+	targetInfo, err := validateWriteTarget(resolved.fullPath, normalized, path)
 	if err != nil {
 		return err
 	}
 
+	targetExists := targetInfo != nil
+	normalized = resolveWriteFileMode(targetInfo, normalized)
+
 	if normalized.AllowSymlinks {
 		if normalized.CreateExclusive {
 			return writeExclusiveAllowSymlinks(resolved.fullPath, data, normalized, log, path)
@@ -93,23 +96,36 @@ func validateParentDir(targetPath string) error {
 	return nil
 }
 
-func validateWriteTarget(targetPath string, opts WriteOptions, originalPath string) (bool, error) {
+// validateWriteTarget checks an existing target and returns its Lstat info, or nil when it does not exist.
+func validateWriteTarget(targetPath string, opts WriteOptions, originalPath string) (os.FileInfo, error) {
 	info, err := os.Lstat(targetPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return false, nil
+			return nil, nil //nolint:nilnil // a missing target is not an error.
 		}
 
-		return false, ewrap.Wrap(err, "failed to stat target").
+		return nil, ewrap.Wrap(err, "failed to stat target").
 			WithMetadata(pathLabel, originalPath)
 	}
 
 	err = validateExistingTarget(info, opts, targetPath, originalPath)
 	if err != nil {
-		return true, err
+		return nil, err
 	}
 
-	return true, nil
+	return info, nil
+}
+
+// resolveWriteFileMode swaps FileMode for the mode of an existing target when PreserveMode is set.
+// It reuses the info validated by validateWriteTarget instead of stating the path again.
+func resolveWriteFileMode(targetInfo os.FileInfo, opts WriteOptions) WriteOptions {
+	if !opts.PreserveMode || opts.EnforceFileMode || targetInfo == nil {
+		return opts
+	}
+
+	opts.FileMode = targetInfo.Mode().Perm()
+
+	return opts
 }
 
 func validateExistingTarget(info os.FileInfo, opts WriteOptions, targetPath, originalPath string) error {
@@ -638,7 +654,7 @@ func performAtomicWrite(
 		return true, err
 	}
 
-	err = renameTempFile(root, tempRel, targetRel, originalPath)
+	err = replaceTargetFile(root, tempRel, targetRel, opts, originalPath)
 	if err != nil {
 		return true, err
 	}
@@ -681,7 +697,7 @@ func performAtomicWriteOnDisk(
 		return true, err
 	}
 
-	err = renameTempFileOnDisk(file.Name(), targetPath, originalPath)
+	err = replaceTargetFileOnDisk(file.Name(), targetPath, opts, originalPath)
 	if err != nil {
 		return true, err
 	}
@@ -26,7 +26,11 @@ func (lw *limitedWriter) Write(data []byte) (int, error) {
 		n, err := lw.writer.Write(data)
 		lw.written += int64(n)
 
-		return n, ewrap.Wrap(err, errMsgFailedToWrite)
+		if err != nil {
+			return n, ewrap.Wrap(err, errMsgFailedToWrite)
+		}
+
+		return n, nil
 	}
 
 	remaining := lw.max - lw.written
@@ -85,11 +89,14 @@ func SecureWriteFromReader(path string, reader io.Reader, opts WriteOptions, log
 		return err
 	}
 
-	targetExists, err := validateWriteTarget(resolved.fullPath, normalized, path)
+	targetInfo, err := validateWriteTarget(resolved.fullPath, normalized, path)
 	if err != nil {
 		return err
 	}
 
+	targetExists := targetInfo != nil
+	normalized = resolveWriteFileMode(targetInfo, normalized)
+
 	if normalized.AllowSymlinks {
 		if normalized.CreateExclusive {
 			return writeExclusiveFromReaderAllowSymlinks(resolved.fullPath, reader, normalized, log, path)
@@ -400,7 +407,7 @@ func performAtomicWriteFromReader(
 		return true, err
 	}
 
-	err = renameTempFile(root, tempRel, targetRel, originalPath)
+	err = replaceTargetFile(root, tempRel, targetRel, opts, originalPath)
 	if err != nil {
 		return true, err
 	}
@@ -443,7 +450,7 @@ func performAtomicWriteFromReaderOnDisk(
 		return true, err
 	}
 
-	err = renameTempFileOnDisk(file.Name(), targetPath, originalPath)
+	err = replaceTargetFileOnDisk(file.Name(), targetPath, opts, originalPath)
 	if err != nil {
 		return true, err
 	}
@@ -0,0 +1,94 @@
+package iosec
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureWriteSyncDirParity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts WriteOptions
+	}{
+		{name: "atomic", opts: WriteOptions{}},
+		{name: "direct", opts: WriteOptions{DisableAtomic: true}},
+		{name: "exclusive", opts: WriteOptions{CreateExclusive: true}},
+		{name: "atomic allow symlinks", opts: WriteOptions{AllowSymlinks: true}},
+		{name: "direct allow symlinks", opts: WriteOptions{DisableAtomic: true, AllowSymlinks: true}},
+		{name: "exclusive allow symlinks", opts: WriteOptions{CreateExclusive: true, AllowSymlinks: true}},
+	}
+
+	data := []byte("sync-dir-parity")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			baseDir := t.TempDir()
+			opts := tt.opts
+			opts.BaseDir = baseDir
+			opts.SyncDir = true
+
+			byteErr := SecureWriteFile("bytes.txt", data, opts, nil)
+			readerErr := SecureWriteFromReader("reader.txt", bytes.NewReader(data), opts, nil)
+
+			if errors.Is(byteErr, ErrSyncDirUnsupported) || errors.Is(readerErr, ErrSyncDirUnsupported) {
+				require.ErrorIs(t, byteErr, ErrSyncDirUnsupported)
+				require.ErrorIs(t, readerErr, ErrSyncDirUnsupported)
+
+				return
+			}
+
+			require.NoError(t, byteErr)
+			require.NoError(t, readerErr)
+
+			byteData, err := os.ReadFile(filepath.Join(baseDir, "bytes.txt"))
+			require.NoError(t, err)
+
+			readerData, err := os.ReadFile(filepath.Join(baseDir, "reader.txt"))
+			require.NoError(t, err)
+
+			assert.Equal(t, byteData, readerData)
+		})
+	}
+}
+
+func TestCopyBackupInRootFallback(t *testing.T) {
+	t.Parallel()
+
+	baseDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "target"), []byte("original"), 0o640))
+
+	root, err := os.OpenRoot(baseDir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		//nolint:errcheck
+		_ = root.Close()
+	})
+
+	info, err := root.Lstat("target")
+	require.NoError(t, err)
+
+	require.NoError(t, copyBackupInRoot(root, "target", "copy", info))
+
+	//nolint:gosec
+	data, err := os.ReadFile(filepath.Join(baseDir, "copy"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), data)
+
+	copyInfo, err := root.Lstat("copy")
+	require.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), copyInfo.Mode().Perm())
+	assert.False(t, os.SameFile(info, copyInfo))
+
+	require.Error(t, copyBackupInRoot(root, "target", "copy", info), "existing temp names must not be overwritten")
+}
@@ -0,0 +1,45 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/hyp3rd/sectools/pkg/errs"
+)
+
+// Apply runs every non-nil option against cfg, skipping nil options.
+// With no failures it returns nil. Otherwise, if collectAll reports true once all options have run,
+// it returns an *errs.MultiError holding each failure prefixed with the option's position;
+// if not, it returns the first failure unchanged.
+func Apply[T any, O ~func(*T) error](cfg *T, opts []O, collectAll func(*T) bool) error {
+	var (
+		first  error
+		failed []error
+	)
+
+	for i, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(cfg)
+		if err == nil {
+			continue
+		}
+
+		if first == nil {
+			first = err
+		}
+
+		failed = append(failed, fmt.Errorf("option %d: %w", i, err))
+	}
+
+	if first == nil {
+		return nil
+	}
+
+	if collectAll(cfg) {
+		return errs.Join(failed...)
+	}
+
+	return first
+}
@@ -0,0 +1,3 @@
+// Package options applies functional options for sectools constructors.
+// It is an implementation detail and not part of the public API contract.
+package options
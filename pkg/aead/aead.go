@@ -0,0 +1,87 @@
+package aead
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+	"github.com/hyp3rd/sectools/pkg/password"
+)
+
+// KeySize is the required key length in bytes.
+const KeySize = chacha20poly1305.KeySize
+
+// AEAD encrypts and decrypts data with XChaCha20-Poly1305 and random 24-byte nonces.
+// Ciphertexts are laid out as nonce || sealed data.
+// Instances of AEAD contain only immutable state and can be safely
+// used concurrently by multiple goroutines.
+type AEAD struct {
+	aead cipher.AEAD
+}
+
+// NewAEAD constructs an AEAD from a 32-byte key.
+func NewAEAD(key []byte) (*AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return &AEAD{aead: aead}, nil
+}
+
+// NewAEADFromPassphrase derives a key from passphrase and salt with argon2id and constructs an AEAD.
+// The KeyLength in params is overridden to KeySize; the derived key is zeroed after use.
+func NewAEADFromPassphrase(passphrase, salt []byte, params password.Argon2idParams) (*AEAD, error) {
+	params.KeyLength = KeySize
+
+	key, err := params.DeriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	defer memory.ZeroBytes(key)
+
+	return NewAEAD(key)
+}
+
+// Seal encrypts and authenticates plaintext and aad, returning nonce || ciphertext.
+func (a *AEAD) Seal(plaintext, aad []byte) ([]byte, error) {
+	nonceSize := a.aead.NonceSize()
+
+	out := make([]byte, nonceSize, nonceSize+len(plaintext)+a.aead.Overhead())
+
+	_, err := rand.Read(out)
+	if err != nil {
+		return nil, fmt.Errorf("read nonce: %w", err)
+	}
+
+	return a.aead.Seal(out, out[:nonceSize], plaintext, aad), nil
+}
+
+// Open authenticates and decrypts a ciphertext produced by Seal with the same aad.
+func (a *AEAD) Open(ciphertext, aad []byte) ([]byte, error) {
+	nonceSize := a.aead.NonceSize()
+	if len(ciphertext) < nonceSize+a.aead.Overhead() {
+		return nil, ErrCiphertextInvalid
+	}
+
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	buf := make([]byte, 0, len(sealed)-a.aead.Overhead())
+
+	plaintext, err := a.aead.Open(buf, nonce, sealed, aad)
+	if err != nil {
+		memory.ZeroBytes(buf[:cap(buf)])
+
+		return nil, ErrDecryptFailed
+	}
+
+	return plaintext, nil
+}
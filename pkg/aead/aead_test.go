@@ -0,0 +1,98 @@
+package aead
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/hyp3rd/sectools/pkg/password"
+)
+
+const errMsgAEAD = "expected aead, got %v"
+
+func TestAEADSealOpen(t *testing.T) {
+	t.Parallel()
+
+	cipher, err := NewAEAD(bytes.Repeat([]byte{0x11}, KeySize))
+	if err != nil {
+		t.Fatalf(errMsgAEAD, err)
+	}
+
+	sealed, err := cipher.Seal([]byte("api-key"), []byte("user:42"))
+	if err != nil {
+		t.Fatalf("expected ciphertext, got %v", err)
+	}
+
+	again, err := cipher.Seal([]byte("api-key"), []byte("user:42"))
+	if err != nil {
+		t.Fatalf("expected ciphertext, got %v", err)
+	}
+
+	if bytes.Equal(sealed, again) {
+		t.Fatal("expected random nonces to produce distinct ciphertexts")
+	}
+
+	plaintext, err := cipher.Open(sealed, []byte("user:42"))
+	if err != nil {
+		t.Fatalf("expected plaintext, got %v", err)
+	}
+
+	if string(plaintext) != "api-key" {
+		t.Fatalf("expected plaintext, got %q", plaintext)
+	}
+
+	_, err = cipher.Open(sealed, []byte("user:43"))
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed for mismatched aad, got %v", err)
+	}
+
+	sealed[len(sealed)-1] ^= 0xff
+
+	_, err = cipher.Open(sealed, []byte("user:42"))
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed for tampered ciphertext, got %v", err)
+	}
+
+	_, err = cipher.Open(sealed[:10], nil)
+	if !errors.Is(err, ErrCiphertextInvalid) {
+		t.Fatalf("expected ErrCiphertextInvalid, got %v", err)
+	}
+}
+
+func TestAEADFromPassphrase(t *testing.T) {
+	t.Parallel()
+
+	params := password.Argon2idParams{
+		Memory:     8 * 1024,
+		Time:       1,
+		Threads:    1,
+		SaltLength: 16,
+		KeyLength:  16,
+	}
+	salt := []byte("0123456789abcdef")
+
+	sealer, err := NewAEADFromPassphrase([]byte("correct horse"), salt, params)
+	if err != nil {
+		t.Fatalf(errMsgAEAD, err)
+	}
+
+	opener, err := NewAEADFromPassphrase([]byte("correct horse"), salt, params)
+	if err != nil {
+		t.Fatalf(errMsgAEAD, err)
+	}
+
+	sealed, err := sealer.Seal([]byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("expected ciphertext, got %v", err)
+	}
+
+	plaintext, err := opener.Open(sealed, nil)
+	if err != nil || string(plaintext) != "secret" {
+		t.Fatalf("expected plaintext, got %q, %v", plaintext, err)
+	}
+
+	_, err = NewAEAD([]byte("short"))
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
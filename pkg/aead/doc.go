@@ -0,0 +1,2 @@
+// Package aead provides authenticated encryption helpers for small secrets at rest.
+package aead
@@ -0,0 +1,164 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const (
+	envelopeVersion1 byte = 1
+	envelopeLenBytes      = 2
+)
+
+var (
+	envelopeMagic = []byte("SENV")
+	// wrapKeyAAD domain-separates wrapped keys from ordinary Seal output.
+	wrapKeyAAD = []byte("sectools/aead wrap-key v1")
+)
+
+// WrapKey encrypts dek under the key-encryption key kek.
+func WrapKey(kek, dek []byte) ([]byte, error) {
+	if len(dek) == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	wrapper, err := NewAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Seal(dek, wrapKeyAAD)
+}
+
+// UnwrapKey decrypts a key produced by WrapKey with the same kek.
+func UnwrapKey(kek, wrapped []byte) ([]byte, error) {
+	wrapper, err := NewAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Open(wrapped, wrapKeyAAD)
+}
+
+// SealWithDEK encrypts plaintext under a fresh random data key and wraps that key with kek.
+// The blob layout is magic "SENV" || version || uint16 wrapped-key length || wrapped key || payload.
+// The header, but not the wrapped key, is authenticated with the payload so RewrapEnvelope can rotate kek.
+func SealWithDEK(kek, plaintext, aad []byte) ([]byte, error) {
+	dek := make([]byte, KeySize)
+	defer memory.ZeroBytes(dek)
+
+	_, err := rand.Read(dek)
+	if err != nil {
+		return nil, fmt.Errorf("read data key: %w", err)
+	}
+
+	wrapped, err := WrapKey(kek, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	sealer, err := NewAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	header := envelopeHeader(envelopeVersion1)
+
+	payload, err := sealer.Seal(plaintext, envelopeAAD(header, aad))
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEnvelope(header, wrapped, payload), nil
+}
+
+// OpenWithWrappedDEK unwraps the data key in blob with kek and decrypts the payload.
+func OpenWithWrappedDEK(kek, blob, aad []byte) ([]byte, error) {
+	header, wrapped, payload, err := parseEnvelope(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := UnwrapKey(kek, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	defer memory.ZeroBytes(dek)
+
+	opener, err := NewAEAD(dek)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEnvelopeInvalid, err)
+	}
+
+	return opener.Open(payload, envelopeAAD(header, aad))
+}
+
+// RewrapEnvelope re-wraps the data key in blob from oldKEK to newKEK without re-encrypting the payload.
+func RewrapEnvelope(oldKEK, newKEK, blob []byte) ([]byte, error) {
+	header, wrapped, payload, err := parseEnvelope(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := UnwrapKey(oldKEK, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	defer memory.ZeroBytes(dek)
+
+	rewrapped, err := WrapKey(newKEK, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEnvelope(header, rewrapped, payload), nil
+}
+
+func envelopeHeader(version byte) []byte {
+	header := make([]byte, 0, len(envelopeMagic)+1)
+	header = append(header, envelopeMagic...)
+
+	return append(header, version)
+}
+
+func envelopeAAD(header, aad []byte) []byte {
+	bound := make([]byte, 0, len(header)+len(aad))
+	bound = append(bound, header...)
+
+	return append(bound, aad...)
+}
+
+func buildEnvelope(header, wrapped, payload []byte) []byte {
+	blob := make([]byte, 0, len(header)+envelopeLenBytes+len(wrapped)+len(payload))
+	blob = append(blob, header...)
+	blob = binary.BigEndian.AppendUint16(blob, uint16(len(wrapped))) //nolint:gosec // wrapped keys are far below 64 KiB.
+	blob = append(blob, wrapped...)
+
+	return append(blob, payload...)
+}
+
+func parseEnvelope(blob []byte) ([]byte, []byte, []byte, error) {
+	headerLen := len(envelopeMagic) + 1
+	if len(blob) < headerLen+envelopeLenBytes || !bytes.HasPrefix(blob, envelopeMagic) {
+		return nil, nil, nil, ErrEnvelopeInvalid
+	}
+
+	if blob[len(envelopeMagic)] != envelopeVersion1 {
+		return nil, nil, nil, fmt.Errorf("%w: unsupported version %d", ErrEnvelopeInvalid, blob[len(envelopeMagic)])
+	}
+
+	wrappedLen := int(binary.BigEndian.Uint16(blob[headerLen:]))
+	start := headerLen + envelopeLenBytes
+
+	if wrappedLen == 0 || len(blob) < start+wrappedLen {
+		return nil, nil, nil, ErrEnvelopeInvalid
+	}
+
+	return blob[:headerLen], blob[start : start+wrappedLen], blob[start+wrappedLen:], nil
+}
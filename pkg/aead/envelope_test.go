@@ -0,0 +1,80 @@
+package aead
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEnvelopeSealOpenAndRewrap(t *testing.T) {
+	t.Parallel()
+
+	oldKEK := bytes.Repeat([]byte{0x01}, KeySize)
+	newKEK := bytes.Repeat([]byte{0x02}, KeySize)
+
+	blob, err := SealWithDEK(oldKEK, []byte("card-number"), []byte("tenant:7"))
+	if err != nil {
+		t.Fatalf("expected envelope, got %v", err)
+	}
+
+	plaintext, err := OpenWithWrappedDEK(oldKEK, blob, []byte("tenant:7"))
+	if err != nil || string(plaintext) != "card-number" {
+		t.Fatalf("expected plaintext, got %q, %v", plaintext, err)
+	}
+
+	rotated, err := RewrapEnvelope(oldKEK, newKEK, blob)
+	if err != nil {
+		t.Fatalf("expected rewrapped envelope, got %v", err)
+	}
+
+	_, _, original, err := parseEnvelope(blob)
+	if err != nil {
+		t.Fatalf("expected parsed envelope, got %v", err)
+	}
+
+	_, _, payload, err := parseEnvelope(rotated)
+	if err != nil || !bytes.Equal(payload, original) {
+		t.Fatalf("expected payload ciphertext to be unchanged by rewrap, got %v", err)
+	}
+
+	plaintext, err = OpenWithWrappedDEK(newKEK, rotated, []byte("tenant:7"))
+	if err != nil || string(plaintext) != "card-number" {
+		t.Fatalf("expected plaintext after rotation, got %q, %v", plaintext, err)
+	}
+
+	_, err = OpenWithWrappedDEK(oldKEK, rotated, []byte("tenant:7"))
+	if !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed with the retired kek, got %v", err)
+	}
+}
+
+func TestEnvelopeRejectsMalformedBlobs(t *testing.T) {
+	t.Parallel()
+
+	kek := bytes.Repeat([]byte{0x03}, KeySize)
+
+	blob, err := SealWithDEK(kek, []byte("value"), nil)
+	if err != nil {
+		t.Fatalf("expected envelope, got %v", err)
+	}
+
+	badVersion := bytes.Clone(blob)
+	badVersion[len(envelopeMagic)] = 9
+
+	for _, candidate := range [][]byte{nil, []byte("SENV"), blob[:10], badVersion} {
+		_, err = OpenWithWrappedDEK(kek, candidate, nil)
+		if !errors.Is(err, ErrEnvelopeInvalid) {
+			t.Fatalf("expected ErrEnvelopeInvalid, got %v", err)
+		}
+	}
+
+	wrapped, err := WrapKey(kek, []byte("data-key"))
+	if err != nil {
+		t.Fatalf("expected wrapped key, got %v", err)
+	}
+
+	dek, err := UnwrapKey(kek, wrapped)
+	if err != nil || string(dek) != "data-key" {
+		t.Fatalf("expected unwrapped key, got %q, %v", dek, err)
+	}
+}
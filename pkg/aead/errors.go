@@ -0,0 +1,14 @@
+package aead
+
+import "github.com/hyp3rd/ewrap"
+
+var (
+	// ErrInvalidKey indicates the encryption key has the wrong length.
+	ErrInvalidKey = ewrap.New("invalid aead key")
+	// ErrCiphertextInvalid indicates the ciphertext is malformed or too short.
+	ErrCiphertextInvalid = ewrap.New("aead ciphertext is invalid")
+	// ErrDecryptFailed indicates the ciphertext or associated data failed authentication.
+	ErrDecryptFailed = ewrap.New("aead decryption failed")
+	// ErrEnvelopeInvalid indicates an envelope blob is malformed or uses an unsupported version.
+	ErrEnvelopeInvalid = ewrap.New("aead envelope is invalid")
+)
@@ -26,6 +26,19 @@ var (
 	// ErrJWTConflictingOptions indicates that the JWT options are conflicting.
 	ErrJWTConflictingOptions = ewrap.New("jwt options are conflicting")
 
+	// JWE Errors.
+
+	// ErrJWEInvalidConfig indicates that the JWE configuration is invalid.
+	ErrJWEInvalidConfig = ewrap.New("invalid jwe config")
+	// ErrJWEMissingKey indicates that the JWE key is missing.
+	ErrJWEMissingKey = ewrap.New("jwe key is required")
+	// ErrJWEMissingAlg indicates that the JWE key management algorithm is missing.
+	ErrJWEMissingAlg = ewrap.New("jwe key management algorithm is required")
+	// ErrJWEMissingAllowedAlgs indicates that the JWE allowed algorithms are missing.
+	ErrJWEMissingAllowedAlgs = ewrap.New("jwe allowed algorithms are required")
+	// ErrJWEInvalidToken indicates that the JWE token is invalid.
+	ErrJWEInvalidToken = ewrap.New("jwe token is invalid")
+
 	// Paseto Errors.
 
 	// ErrPasetoInvalidConfig indicates that the Paseto configuration is invalid.
@@ -42,4 +55,13 @@ var (
 	ErrPasetoInvalidToken = ewrap.New("paseto token is invalid")
 	// ErrPasetoConflictingOpts indicates that the Paseto options are conflicting.
 	ErrPasetoConflictingOpts = ewrap.New("paseto options are conflicting")
+
+	// Key Errors.
+
+	// ErrKeyInvalidPEM indicates that the PEM key data is invalid.
+	ErrKeyInvalidPEM = ewrap.New("pem key is invalid")
+	// ErrKeyEncrypted indicates that the PEM key is encrypted and cannot be parsed without a passphrase.
+	ErrKeyEncrypted = ewrap.New("pem key is encrypted and requires a passphrase")
+	// ErrKeyUnsupported indicates that the key type is not supported.
+	ErrKeyUnsupported = ewrap.New("key type is not supported")
 )
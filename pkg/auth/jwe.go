@@ -0,0 +1,664 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// JWEAlgDirect uses the shared symmetric key directly as the content encryption key.
+	JWEAlgDirect = "dir"
+	// JWEAlgRSAOAEP256 wraps a random content encryption key with RSA-OAEP using SHA-256.
+	JWEAlgRSAOAEP256 = "RSA-OAEP-256"
+	// JWEEncA256GCM is the only supported content encryption algorithm.
+	JWEEncA256GCM = "A256GCM"
+
+	jweTypJWT       = "JWT"
+	jweKeySize      = 32
+	jweNonceSize    = 12
+	jweTagSize      = 16
+	jweCompactParts = 5
+)
+
+type jweHeader struct {
+	Alg  string   `json:"alg"`
+	Enc  string   `json:"enc"`
+	Typ  string   `json:"typ,omitempty"`
+	Kid  string   `json:"kid,omitempty"`
+	Zip  string   `json:"zip,omitempty"`
+	Crit []string `json:"crit,omitempty"`
+}
+
+// JWEEncrypter encrypts JWT claims into compact JWE tokens.
+type JWEEncrypter struct {
+	alg               string
+	key               any
+	keyID             string
+	requireExpiration bool
+}
+
+// JWEEncrypterOption configures JWE encryption behavior.
+type JWEEncrypterOption func(*jweEncrypterConfig) error
+
+type jweEncrypterConfig struct {
+	alg               string
+	key               any
+	keyID             string
+	requireExpiration bool
+	minRSABits        int
+}
+
+// NewJWEEncrypter constructs a JWE encrypter with strict defaults.
+func NewJWEEncrypter(opts ...JWEEncrypterOption) (*JWEEncrypter, error) {
+	cfg := jweEncrypterConfig{
+		requireExpiration: true,
+		minRSABits:        defaultMinRSAKeyBits,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.alg == "" {
+		return nil, ErrJWEMissingAlg
+	}
+
+	if cfg.key == nil {
+		return nil, ErrJWEMissingKey
+	}
+
+	err := validateJWEEncryptionKey(cfg.alg, cfg.key, cfg.minRSABits)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret, ok := cfg.key.([]byte); ok {
+		cfg.key = slices.Clone(secret)
+	}
+
+	return &JWEEncrypter{
+		alg:               cfg.alg,
+		key:               cfg.key,
+		keyID:             cfg.keyID,
+		requireExpiration: cfg.requireExpiration,
+	}, nil
+}
+
+// WithJWEKeyAlgorithm configures the key management algorithm (dir or RSA-OAEP-256).
+func WithJWEKeyAlgorithm(alg string) JWEEncrypterOption {
+	return func(cfg *jweEncrypterConfig) error {
+		trimmed := strings.TrimSpace(alg)
+		if trimmed == "" {
+			return ErrJWEMissingAlg
+		}
+
+		if !isSupportedJWEAlg(trimmed) {
+			return ErrJWEInvalidConfig
+		}
+
+		cfg.alg = trimmed
+
+		return nil
+	}
+}
+
+// WithJWEEncryptionKey sets the encryption key.
+// Use a 32-byte []byte for dir and an *rsa.PublicKey for RSA-OAEP-256.
+func WithJWEEncryptionKey(key any) JWEEncrypterOption {
+	return func(cfg *jweEncrypterConfig) error {
+		if key == nil {
+			return ErrJWEMissingKey
+		}
+
+		cfg.key = key
+
+		return nil
+	}
+}
+
+// WithJWEKeyID sets the kid header on encrypted tokens.
+func WithJWEKeyID(keyID string) JWEEncrypterOption {
+	return func(cfg *jweEncrypterConfig) error {
+		cfg.keyID = strings.TrimSpace(keyID)
+
+		return nil
+	}
+}
+
+// WithJWEEncrypterAllowMissingExpiration disables the default requirement for exp.
+func WithJWEEncrypterAllowMissingExpiration() JWEEncrypterOption {
+	return func(cfg *jweEncrypterConfig) error {
+		cfg.requireExpiration = false
+
+		return nil
+	}
+}
+
+// WithJWEEncrypterMinRSAKeySize sets the smallest accepted RSA-OAEP-256 public key in bits (default 2048).
+// Values below 2048 return ErrJWEInvalidConfig.
+func WithJWEEncrypterMinRSAKeySize(bits int) JWEEncrypterOption {
+	return func(cfg *jweEncrypterConfig) error {
+		if bits < defaultMinRSAKeyBits {
+			return ErrJWEInvalidConfig
+		}
+
+		cfg.minRSABits = bits
+
+		return nil
+	}
+}
+
+// Encrypt encrypts claims into a compact JWE string.
+func (e *JWEEncrypter) Encrypt(claims jwt.Claims) (string, error) {
+	if claims == nil {
+		return "", ErrJWTMissingClaims
+	}
+
+	if e.requireExpiration {
+		exp, err := claims.GetExpirationTime()
+		if err != nil || exp == nil {
+			return "", ErrJWTMissingExpiration
+		}
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encode jwe claims: %w", err)
+	}
+
+	headerJSON, err := json.Marshal(jweHeader{
+		Alg: e.alg,
+		Enc: JWEEncA256GCM,
+		Typ: jweTypJWT,
+		Kid: e.keyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode jwe header: %w", err)
+	}
+
+	cek, encryptedKey, err := e.contentKey()
+	if err != nil {
+		return "", err
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(headerJSON)
+
+	aead, err := newJWEAEAD(cek)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, jweNonceSize)
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("generate jwe nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nil, nonce, payload, []byte(protected))
+	ciphertext := sealed[:len(sealed)-jweTagSize]
+	tag := sealed[len(sealed)-jweTagSize:]
+
+	return strings.Join([]string{
+		protected,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(nonce),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, "."), nil
+}
+
+func (e *JWEEncrypter) contentKey() ([]byte, []byte, error) {
+	if e.alg == JWEAlgDirect {
+		key, ok := e.key.([]byte)
+		if !ok {
+			return nil, nil, ErrJWEInvalidConfig
+		}
+
+		return key, nil, nil
+	}
+
+	publicKey, ok := e.key.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, ErrJWEInvalidConfig
+	}
+
+	cek := make([]byte, jweKeySize)
+
+	_, err := rand.Read(cek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate jwe content key: %w", err)
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, cek, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wrap jwe content key: %w", err)
+	}
+
+	return cek, encryptedKey, nil
+}
+
+// JWEDecrypter decrypts compact JWE tokens and validates their claims.
+type JWEDecrypter struct {
+	allowedAlgs []string
+	key         any
+	rules       jwtClaimRules
+}
+
+// JWEDecrypterOption configures JWE decryption behavior.
+type JWEDecrypterOption func(*jweDecrypterConfig) error
+
+type jweDecrypterConfig struct {
+	allowedAlgs       []string
+	key               any
+	issuer            string
+	audiences         []string
+	subject           string
+	leeway            time.Duration
+	now               func() time.Time
+	requireExpiration bool
+	minRSABits        int
+}
+
+// NewJWEDecrypter constructs a JWE decrypter with strict defaults.
+func NewJWEDecrypter(opts ...JWEDecrypterOption) (*JWEDecrypter, error) {
+	cfg := jweDecrypterConfig{
+		requireExpiration: true,
+		now:               time.Now,
+		minRSABits:        defaultMinRSAKeyBits,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := validateJWEDecrypterConfig(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret, ok := cfg.key.([]byte); ok {
+		cfg.key = slices.Clone(secret)
+	}
+
+	return &JWEDecrypter{
+		allowedAlgs: cfg.allowedAlgs,
+		key:         cfg.key,
+		rules: jwtClaimRules{
+			issuer:            cfg.issuer,
+			audiences:         cfg.audiences,
+			subject:           cfg.subject,
+			leeway:            cfg.leeway,
+			now:               cfg.now,
+			requireExpiration: cfg.requireExpiration,
+		},
+	}, nil
+}
+
+func validateJWEDecrypterConfig(cfg *jweDecrypterConfig) error {
+	if len(cfg.allowedAlgs) == 0 {
+		return ErrJWEMissingAllowedAlgs
+	}
+
+	if cfg.key == nil {
+		return ErrJWEMissingKey
+	}
+
+	for _, alg := range cfg.allowedAlgs {
+		err := validateJWEDecryptionKey(alg, cfg.key, cfg.minRSABits)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := validateJWTIssuerAudiences(cfg.issuer, cfg.audiences)
+	if err != nil {
+		return ErrJWEInvalidConfig
+	}
+
+	err = validateJWTClock(cfg.now, cfg.leeway)
+	if err != nil {
+		return ErrJWEInvalidConfig
+	}
+
+	return nil
+}
+
+// WithJWEAllowedAlgorithms configures allowed key management algorithms.
+func WithJWEAllowedAlgorithms(algs ...string) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cleaned := make([]string, 0, len(algs))
+		for _, alg := range algs {
+			trimmed := strings.TrimSpace(alg)
+			if trimmed == "" {
+				continue
+			}
+
+			if !isSupportedJWEAlg(trimmed) {
+				return ErrJWEInvalidConfig
+			}
+
+			cleaned = append(cleaned, trimmed)
+		}
+
+		if len(cleaned) == 0 {
+			return ErrJWEMissingAllowedAlgs
+		}
+
+		cfg.allowedAlgs = cleaned
+
+		return nil
+	}
+}
+
+// WithJWEDecryptionKey sets the decryption key.
+// Use a 32-byte []byte for dir and an *rsa.PrivateKey for RSA-OAEP-256.
+func WithJWEDecryptionKey(key any) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		if key == nil {
+			return ErrJWEMissingKey
+		}
+
+		cfg.key = key
+
+		return nil
+	}
+}
+
+// WithJWEIssuer configures the required issuer.
+func WithJWEIssuer(issuer string) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cfg.issuer = strings.TrimSpace(issuer)
+
+		return nil
+	}
+}
+
+// WithJWEAudience configures the required audience list.
+func WithJWEAudience(audiences ...string) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cleaned := make([]string, 0, len(audiences))
+		for _, audience := range audiences {
+			trimmed := strings.TrimSpace(audience)
+			if trimmed == "" {
+				continue
+			}
+
+			cleaned = append(cleaned, trimmed)
+		}
+
+		cfg.audiences = cleaned
+
+		return nil
+	}
+}
+
+// WithJWESubject configures the required subject.
+func WithJWESubject(subject string) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cfg.subject = strings.TrimSpace(subject)
+
+		return nil
+	}
+}
+
+// WithJWELeeway configures allowable clock skew.
+func WithJWELeeway(leeway time.Duration) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cfg.leeway = leeway
+
+		return nil
+	}
+}
+
+// WithJWEClock overrides the clock used for validation.
+func WithJWEClock(now func() time.Time) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		if now == nil {
+			return ErrJWEInvalidConfig
+		}
+
+		cfg.now = now
+
+		return nil
+	}
+}
+
+// WithJWEDecrypterAllowMissingExpiration disables the default requirement for exp.
+func WithJWEDecrypterAllowMissingExpiration() JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		cfg.requireExpiration = false
+
+		return nil
+	}
+}
+
+// WithJWEMinRSAKeySize sets the smallest accepted RSA-OAEP-256 private key in bits (default 2048).
+// Values below 2048 return ErrJWEInvalidConfig.
+func WithJWEMinRSAKeySize(bits int) JWEDecrypterOption {
+	return func(cfg *jweDecrypterConfig) error {
+		if bits < defaultMinRSAKeyBits {
+			return ErrJWEInvalidConfig
+		}
+
+		cfg.minRSABits = bits
+
+		return nil
+	}
+}
+
+// Decrypt decrypts a compact JWE token and validates its claims.
+func (d *JWEDecrypter) Decrypt(token string) (jwt.MapClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != jweCompactParts {
+		return nil, ErrJWEInvalidToken
+	}
+
+	header, err := decodeJWEHeader(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsString(d.allowedAlgs, header.Alg) {
+		return nil, ErrJWEInvalidToken
+	}
+
+	segments, err := decodeJWESegments(parts[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ciphertext, tag := segments[1], segments[2], segments[3]
+	if len(nonce) != jweNonceSize || len(tag) != jweTagSize {
+		return nil, ErrJWEInvalidToken
+	}
+
+	cek, err := d.contentKey(header.Alg, segments[0])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newJWEAEAD(cek)
+	if err != nil {
+		return nil, ErrJWEInvalidToken
+	}
+
+	sealed := make([]byte, 0, len(ciphertext)+len(tag))
+	sealed = append(sealed, ciphertext...)
+	sealed = append(sealed, tag...)
+
+	payload, err := aead.Open(nil, nonce, sealed, []byte(parts[0]))
+	if err != nil {
+		return nil, ErrJWEInvalidToken
+	}
+
+	claims := jwt.MapClaims{}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWEInvalidToken, err)
+	}
+
+	err = d.rules.validate(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (d *JWEDecrypter) contentKey(alg string, encryptedKey []byte) ([]byte, error) {
+	if alg == JWEAlgDirect {
+		if len(encryptedKey) != 0 {
+			return nil, ErrJWEInvalidToken
+		}
+
+		key, ok := d.key.([]byte)
+		if !ok {
+			return nil, ErrJWEInvalidToken
+		}
+
+		return key, nil
+	}
+
+	privateKey, ok := d.key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrJWEInvalidToken
+	}
+
+	// RFC 7516 section 11.5: a failed unwrap must not be distinguishable from a failed
+	// decryption, so a random CEK is substituted and the AEAD open fails instead.
+	fallback := make([]byte, jweKeySize)
+
+	_, err := rand.Read(fallback)
+	if err != nil {
+		return nil, ErrJWEInvalidToken
+	}
+
+	cek, err := rsa.DecryptOAEP(sha256.New(), nil, privateKey, encryptedKey, nil)
+	if err != nil || len(cek) != jweKeySize {
+		return fallback, nil
+	}
+
+	return cek, nil
+}
+
+func decodeJWEHeader(segment string) (jweHeader, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return jweHeader{}, ErrJWEInvalidToken
+	}
+
+	var header jweHeader
+
+	err = json.Unmarshal(raw, &header)
+	if err != nil {
+		return jweHeader{}, ErrJWEInvalidToken
+	}
+
+	if !isSupportedJWEAlg(header.Alg) || header.Enc != JWEEncA256GCM {
+		return jweHeader{}, ErrJWEInvalidToken
+	}
+
+	if header.Zip != "" || len(header.Crit) > 0 {
+		return jweHeader{}, ErrJWEInvalidToken
+	}
+
+	return header, nil
+}
+
+func decodeJWESegments(segments []string) ([][]byte, error) {
+	decoded := make([][]byte, 0, len(segments))
+	for _, segment := range segments {
+		value, err := base64.RawURLEncoding.DecodeString(segment)
+		if err != nil {
+			return nil, ErrJWEInvalidToken
+		}
+
+		decoded = append(decoded, value)
+	}
+
+	return decoded, nil
+}
+
+func newJWEAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != jweKeySize {
+		return nil, ErrJWEInvalidConfig
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWEInvalidConfig, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWEInvalidConfig, err)
+	}
+
+	return aead, nil
+}
+
+func isSupportedJWEAlg(alg string) bool {
+	return alg == JWEAlgDirect || alg == JWEAlgRSAOAEP256
+}
+
+func validateJWEEncryptionKey(alg string, key any, minRSABits int) error {
+	if alg == JWEAlgDirect {
+		return validateJWEDirectKey(key)
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok || rsaKeyTooSmall(publicKey, minRSABits) {
+		return ErrJWEInvalidConfig
+	}
+
+	return nil
+}
+
+func validateJWEDecryptionKey(alg string, key any, minRSABits int) error {
+	if alg == JWEAlgDirect {
+		return validateJWEDirectKey(key)
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok || rsaKeyTooSmall(privateKey, minRSABits) {
+		return ErrJWEInvalidConfig
+	}
+
+	return nil
+}
+
+func validateJWEDirectKey(key any) error {
+	secret, ok := key.([]byte)
+	if !ok || len(secret) != jweKeySize {
+		return ErrJWEInvalidConfig
+	}
+
+	return nil
+}
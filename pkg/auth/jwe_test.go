@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWEDirectRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	key := make([]byte, 32)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	encrypter, err := NewJWEEncrypter(
+		WithJWEKeyAlgorithm(JWEAlgDirect),
+		WithJWEEncryptionKey(key),
+		WithJWEKeyID("kid-1"),
+	)
+	if err != nil {
+		t.Fatalf("expected encrypter, got error: %v", err)
+	}
+
+	decrypter, err := NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgDirect),
+		WithJWEDecryptionKey(key),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+		WithJWEClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected decrypter, got error: %v", err)
+	}
+
+	token, err := encrypter.Encrypt(jwt.MapClaims{
+		"iss":  issuer,
+		"aud":  "apps",
+		"exp":  float64(now.Add(time.Hour).Unix()),
+		"role": "admin",
+	})
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	if strings.Count(token, ".") != 4 {
+		t.Fatalf("expected compact jwe, got %q", token)
+	}
+
+	claims, err := decrypter.Decrypt(token)
+	if err != nil {
+		t.Fatalf("expected decrypt success, got error: %v", err)
+	}
+
+	if claims["role"] != "admin" {
+		t.Fatalf("expected role claim, got %v", claims["role"])
+	}
+
+	expired, err := NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgDirect),
+		WithJWEDecryptionKey(key),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+		WithJWEClock(func() time.Time { return now.Add(2 * time.Hour) }),
+	)
+	if err != nil {
+		t.Fatalf("expected decrypter, got error: %v", err)
+	}
+
+	_, err = expired.Decrypt(token)
+	if !errors.Is(err, ErrJWTInvalidToken) {
+		t.Fatalf("expected ErrJWTInvalidToken, got %v", err)
+	}
+}
+
+func TestJWERSAOAEPRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	encrypter, err := NewJWEEncrypter(
+		WithJWEKeyAlgorithm(JWEAlgRSAOAEP256),
+		WithJWEEncryptionKey(&privateKey.PublicKey),
+	)
+	if err != nil {
+		t.Fatalf("expected encrypter, got error: %v", err)
+	}
+
+	decrypter, err := NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgRSAOAEP256),
+		WithJWEDecryptionKey(privateKey),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+	)
+	if err != nil {
+		t.Fatalf("expected decrypter, got error: %v", err)
+	}
+
+	token, err := encrypter.Encrypt(jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	_, err = decrypter.Decrypt(token)
+	if err != nil {
+		t.Fatalf("expected decrypt success, got error: %v", err)
+	}
+}
+
+func TestJWERSAOAEPUnwrapFailureIsUniform(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	encrypter, err := NewJWEEncrypter(
+		WithJWEKeyAlgorithm(JWEAlgRSAOAEP256),
+		WithJWEEncryptionKey(&privateKey.PublicKey),
+	)
+	if err != nil {
+		t.Fatalf("expected encrypter, got error: %v", err)
+	}
+
+	decrypter, err := NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgRSAOAEP256),
+		WithJWEDecryptionKey(privateKey),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+	)
+	if err != nil {
+		t.Fatalf("expected decrypter, got error: %v", err)
+	}
+
+	token, err := encrypter.Encrypt(jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	shortKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &privateKey.PublicKey, make([]byte, 16), nil)
+	if err != nil {
+		t.Fatalf("expected wrapped key, got %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	encoded := base64.RawURLEncoding.EncodeToString
+
+	tampered := map[string]string{
+		"garbage key": strings.Join([]string{parts[0], encoded([]byte("garbage")), parts[2], parts[3], parts[4]}, "."),
+		"short cek":   strings.Join([]string{parts[0], encoded(shortKey), parts[2], parts[3], parts[4]}, "."),
+		"bad tag":     strings.Join([]string{parts[0], parts[1], parts[2], parts[3], encoded(make([]byte, 16))}, "."),
+	}
+
+	for name, candidate := range tampered {
+		_, err = decrypter.Decrypt(candidate)
+		if !errors.Is(err, ErrJWEInvalidToken) {
+			t.Fatalf("%s: expected ErrJWEInvalidToken, got %v", name, err)
+		}
+	}
+
+	cek, err := decrypter.contentKey(JWEAlgRSAOAEP256, []byte("garbage"))
+	if err != nil || len(cek) != 32 {
+		t.Fatalf("expected substituted CEK, got %d bytes, %v", len(cek), err)
+	}
+}
+
+func TestJWEMinRSAKeySize(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, defaultMinRSAKeyBits)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	_, err = NewJWEEncrypter(
+		WithJWEKeyAlgorithm(JWEAlgRSAOAEP256),
+		WithJWEEncryptionKey(&privateKey.PublicKey),
+		WithJWEEncrypterMinRSAKeySize(3072),
+	)
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig below raised minimum, got %v", err)
+	}
+
+	_, err = NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgRSAOAEP256),
+		WithJWEDecryptionKey(privateKey),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+		WithJWEMinRSAKeySize(3072),
+	)
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig below raised minimum, got %v", err)
+	}
+
+	_, err = NewJWEEncrypter(WithJWEEncrypterMinRSAKeySize(1024))
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig for lowered minimum, got %v", err)
+	}
+
+	_, err = NewJWEDecrypter(WithJWEMinRSAKeySize(1024))
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig for lowered minimum, got %v", err)
+	}
+}
+
+func TestJWEDecryptRejectsUnexpectedAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+
+	decrypter, err := NewJWEDecrypter(
+		WithJWEAllowedAlgorithms(JWEAlgDirect),
+		WithJWEDecryptionKey(key),
+		WithJWEIssuer(issuer),
+		WithJWEAudience("apps"),
+	)
+	if err != nil {
+		t.Fatalf("expected decrypter, got error: %v", err)
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","enc":"A256GCM"}`))
+
+	_, err = decrypter.Decrypt(header + "....")
+	if !errors.Is(err, ErrJWEInvalidToken) {
+		t.Fatalf("expected ErrJWEInvalidToken, got %v", err)
+	}
+}
+
+func TestJWEConfigRejectsUnsupportedAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewJWEEncrypter(
+		WithJWEKeyAlgorithm("A128KW"),
+		WithJWEEncryptionKey(make([]byte, 32)),
+	)
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig, got %v", err)
+	}
+
+	_, err = NewJWEEncrypter(
+		WithJWEKeyAlgorithm(JWEAlgDirect),
+		WithJWEEncryptionKey([]byte("short")),
+	)
+	if !errors.Is(err, ErrJWEInvalidConfig) {
+		t.Fatalf("expected ErrJWEInvalidConfig, got %v", err)
+	}
+}
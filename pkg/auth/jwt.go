@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 	"maps"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -10,7 +11,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-const jwtHeaderKeyID = "kid"
+const (
+	jwtHeaderKeyID     = "kid"
+	jwtHeaderType      = "typ"
+	jwtMediaTypePrefix = "application/"
+)
 
 // JWTSigner signs JWTs with required claims and strict algorithm selection.
 type JWTSigner struct {
@@ -18,6 +23,8 @@ type JWTSigner struct {
 	key               any
 	keyID             string
 	requireExpiration bool
+	setIssuedAt       bool
+	now               func() time.Time
 }
 
 // JWTSignerOption configures JWT signing behavior.
@@ -28,12 +35,17 @@ type jwtSignerConfig struct {
 	key               any
 	keyID             string
 	requireExpiration bool
+	setIssuedAt       bool
+	now               func() time.Time
+	minRSABits        int
 }
 
 // NewJWTSigner constructs a JWT signer with strict defaults.
 func NewJWTSigner(opts ...JWTSignerOption) (*JWTSigner, error) {
 	cfg := jwtSignerConfig{
 		requireExpiration: true,
+		now:               time.Now,
+		minRSABits:        defaultMinRSAKeyBits,
 	}
 
 	for _, opt := range opts {
@@ -55,11 +67,21 @@ func NewJWTSigner(opts ...JWTSignerOption) (*JWTSigner, error) {
 		return nil, ErrJWTMissingKey
 	}
 
+	if cfg.now == nil {
+		return nil, ErrJWTInvalidConfig
+	}
+
+	if rsaKeyTooSmall(cfg.key, cfg.minRSABits) {
+		return nil, ErrJWTInvalidConfig
+	}
+
 	return &JWTSigner{
 		method:            cfg.method,
 		key:               cfg.key,
 		keyID:             cfg.keyID,
 		requireExpiration: cfg.requireExpiration,
+		setIssuedAt:       cfg.setIssuedAt,
+		now:               cfg.now,
 	}, nil
 }
 
@@ -89,7 +111,7 @@ func WithJWTSigningAlgorithm(alg string) JWTSignerOption {
 // WithJWTSigningKey sets the signing key.
 func WithJWTSigningKey(key any) JWTSignerOption {
 	return func(cfg *jwtSignerConfig) error {
-		if key == nil {
+		if isNilKey(key) {
 			return ErrJWTMissingKey
 		}
 
@@ -99,6 +121,20 @@ func WithJWTSigningKey(key any) JWTSignerOption {
 	}
 }
 
+// WithJWTSignerMinRSAKeySize sets the smallest accepted RSA signing key in bits (default 2048).
+// Values below 2048 return ErrJWTInvalidConfig.
+func WithJWTSignerMinRSAKeySize(bits int) JWTSignerOption {
+	return func(cfg *jwtSignerConfig) error {
+		if bits < defaultMinRSAKeyBits {
+			return ErrJWTInvalidConfig
+		}
+
+		cfg.minRSABits = bits
+
+		return nil
+	}
+}
+
 // WithJWTSigningKeyID sets the kid header on signed tokens.
 func WithJWTSigningKeyID(keyID string) JWTSignerOption {
 	return func(cfg *jwtSignerConfig) error {
@@ -117,12 +153,40 @@ func WithJWTSignerAllowMissingExpiration() JWTSignerOption {
 	}
 }
 
+// WithJWTSignerClock overrides the clock used for signing-time claims.
+func WithJWTSignerClock(now func() time.Time) JWTSignerOption {
+	return func(cfg *jwtSignerConfig) error {
+		if now == nil {
+			return ErrJWTInvalidConfig
+		}
+
+		cfg.now = now
+
+		return nil
+	}
+}
+
+// WithJWTSignerIssuedAt stamps iat from the signer clock when the claims do not set it.
+// Only jwt.MapClaims and jwt.RegisteredClaims (value or pointer) are populated;
+// the caller's claims are copied, never mutated.
+func WithJWTSignerIssuedAt() JWTSignerOption {
+	return func(cfg *jwtSignerConfig) error {
+		cfg.setIssuedAt = true
+
+		return nil
+	}
+}
+
 // Sign signs claims into a JWT string.
 func (s *JWTSigner) Sign(claims jwt.Claims) (string, error) {
 	if claims == nil {
 		return "", ErrJWTMissingClaims
 	}
 
+	if s.setIssuedAt {
+		claims = withIssuedAt(claims, s.now())
+	}
+
 	if s.requireExpiration {
 		exp, err := claims.GetExpirationTime()
 		if err != nil || exp == nil {
@@ -143,19 +207,44 @@ func (s *JWTSigner) Sign(claims jwt.Claims) (string, error) {
 	return signed, nil
 }
 
+func withIssuedAt(claims jwt.Claims, now time.Time) jwt.Claims {
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat != nil {
+		return claims
+	}
+
+	issuedAt := jwt.NewNumericDate(now)
+
+	switch typed := claims.(type) {
+	case jwt.MapClaims:
+		clone := maps.Clone(typed)
+		clone["iat"] = issuedAt
+
+		return clone
+	case jwt.RegisteredClaims:
+		typed.IssuedAt = issuedAt
+
+		return typed
+	case *jwt.RegisteredClaims:
+		clone := *typed
+		clone.IssuedAt = issuedAt
+
+		return &clone
+	default:
+		return claims
+	}
+}
+
 // JWTVerifier verifies JWT signatures and claims with strict validation.
 type JWTVerifier struct {
-	allowedAlgs       []string
-	key               any
-	keys              map[string]any
-	keyFunc           jwt.Keyfunc
-	requireKeyID      bool
-	issuer            string
-	audiences         []string
-	subject           string
-	leeway            time.Duration
-	now               func() time.Time
-	requireExpiration bool
+	allowedAlgs  []string
+	key          any
+	keys         map[string][]any
+	keyFunc      jwt.Keyfunc
+	requireKeyID bool
+	requiredType string
+	minRSABits   int
+	rules        jwtClaimRules
 }
 
 // JWTVerifierOption configures JWT verification behavior.
@@ -164,15 +253,18 @@ type JWTVerifierOption func(*jwtVerifierConfig) error
 type jwtVerifierConfig struct {
 	allowedAlgs       []string
 	key               any
-	keys              map[string]any
+	keys              map[string][]any
 	keyFunc           jwt.Keyfunc
 	requireKeyID      bool
+	requiredType      string
 	issuer            string
 	audiences         []string
 	subject           string
 	leeway            time.Duration
 	now               func() time.Time
 	requireExpiration bool
+	maxAge            time.Duration
+	minRSABits        int
 }
 
 // NewJWTVerifier constructs a JWT verifier with strict defaults.
@@ -180,6 +272,7 @@ func NewJWTVerifier(opts ...JWTVerifierOption) (*JWTVerifier, error) {
 	cfg := jwtVerifierConfig{
 		requireExpiration: true,
 		now:               time.Now,
+		minRSABits:        defaultMinRSAKeyBits,
 	}
 
 	for _, opt := range opts {
@@ -199,17 +292,22 @@ func NewJWTVerifier(opts ...JWTVerifierOption) (*JWTVerifier, error) {
 	}
 
 	return &JWTVerifier{
-		allowedAlgs:       cfg.allowedAlgs,
-		key:               cfg.key,
-		keys:              cfg.keys,
-		keyFunc:           cfg.keyFunc,
-		requireKeyID:      cfg.requireKeyID,
-		issuer:            cfg.issuer,
-		audiences:         cfg.audiences,
-		subject:           cfg.subject,
-		leeway:            cfg.leeway,
-		now:               cfg.now,
-		requireExpiration: cfg.requireExpiration,
+		allowedAlgs:  cfg.allowedAlgs,
+		key:          cfg.key,
+		keys:         cfg.keys,
+		keyFunc:      cfg.keyFunc,
+		requireKeyID: cfg.requireKeyID,
+		requiredType: cfg.requiredType,
+		minRSABits:   cfg.minRSABits,
+		rules: jwtClaimRules{
+			issuer:            cfg.issuer,
+			audiences:         cfg.audiences,
+			subject:           cfg.subject,
+			leeway:            cfg.leeway,
+			now:               cfg.now,
+			requireExpiration: cfg.requireExpiration,
+			maxAge:            cfg.maxAge,
+		},
 	}, nil
 }
 
@@ -258,6 +356,18 @@ func validateJWTKeySources(cfg *jwtVerifierConfig) error {
 		return ErrJWTConflictingOptions
 	}
 
+	if rsaKeyTooSmall(cfg.key, cfg.minRSABits) {
+		return ErrJWTInvalidConfig
+	}
+
+	for _, candidates := range cfg.keys {
+		for _, candidate := range candidates {
+			if rsaKeyTooSmall(candidate, cfg.minRSABits) {
+				return ErrJWTInvalidConfig
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -321,7 +431,7 @@ func WithJWTAllowedAlgorithms(algs ...string) JWTVerifierOption {
 // WithJWTVerificationKey configures a single verification key.
 func WithJWTVerificationKey(key any) JWTVerifierOption {
 	return func(cfg *jwtVerifierConfig) error {
-		if key == nil {
+		if isNilKey(key) {
 			return ErrJWTMissingKey
 		}
 
@@ -338,8 +448,37 @@ func WithJWTVerificationKeys(keys map[string]any) JWTVerifierOption {
 			return ErrJWTMissingKey
 		}
 
-		cfg.keys = make(map[string]any, len(keys))
-		maps.Copy(cfg.keys, keys)
+		cfg.keys = make(map[string][]any, len(keys))
+		for kid, key := range keys {
+			if isNilKey(key) {
+				return ErrJWTMissingKey
+			}
+
+			cfg.keys[kid] = []any{key}
+		}
+
+		cfg.requireKeyID = true
+
+		return nil
+	}
+}
+
+// WithJWTVerificationKeySets configures several candidate keys per kid, for rotations where
+// keys briefly share a kid. Each candidate is tried until one verifies the signature.
+func WithJWTVerificationKeySets(keys map[string][]any) JWTVerifierOption {
+	return func(cfg *jwtVerifierConfig) error {
+		if len(keys) == 0 {
+			return ErrJWTMissingKey
+		}
+
+		cfg.keys = make(map[string][]any, len(keys))
+		for kid, candidates := range keys {
+			if len(candidates) == 0 || slices.ContainsFunc(candidates, isNilKey) {
+				return ErrJWTMissingKey
+			}
+
+			cfg.keys[kid] = slices.Clone(candidates)
+		}
 
 		cfg.requireKeyID = true
 
@@ -360,6 +499,21 @@ func WithJWTVerificationKeyFunc(keyFunc jwt.Keyfunc) JWTVerifierOption {
 	}
 }
 
+// WithJWTMinRSAKeySize sets the smallest accepted RSA verification key in bits (default 2048).
+// Static keys are checked by NewJWTVerifier; keys from WithJWTVerificationKeyFunc are checked on
+// every Verify. Values below 2048 return ErrJWTInvalidConfig.
+func WithJWTMinRSAKeySize(bits int) JWTVerifierOption {
+	return func(cfg *jwtVerifierConfig) error {
+		if bits < defaultMinRSAKeyBits {
+			return ErrJWTInvalidConfig
+		}
+
+		cfg.minRSABits = bits
+
+		return nil
+	}
+}
+
 // WithJWTRequireKeyID requires a kid header even with a single key.
 func WithJWTRequireKeyID() JWTVerifierOption {
 	return func(cfg *jwtVerifierConfig) error {
@@ -369,6 +523,21 @@ func WithJWTRequireKeyID() JWTVerifierOption {
 	}
 }
 
+// WithJWTRequireType requires the typ header to match typ, such as "at+jwt" (RFC 9068).
+// The comparison is case-insensitive and ignores an "application/" prefix on either side.
+func WithJWTRequireType(typ string) JWTVerifierOption {
+	return func(cfg *jwtVerifierConfig) error {
+		normalized := normalizeJWTType(typ)
+		if normalized == "" {
+			return ErrJWTInvalidConfig
+		}
+
+		cfg.requiredType = normalized
+
+		return nil
+	}
+}
+
 // WithJWTIssuer configures the required issuer.
 func WithJWTIssuer(issuer string) JWTVerifierOption {
 	return func(cfg *jwtVerifierConfig) error {
@@ -428,6 +597,20 @@ func WithJWTClock(now func() time.Time) JWTVerifierOption {
 	}
 }
 
+// WithJWTMaxAge rejects tokens whose iat is older than maxAge (plus leeway), even if exp has not
+// passed. Tokens without iat are rejected while it is set.
+func WithJWTMaxAge(maxAge time.Duration) JWTVerifierOption {
+	return func(cfg *jwtVerifierConfig) error {
+		if maxAge <= 0 {
+			return ErrJWTInvalidConfig
+		}
+
+		cfg.maxAge = maxAge
+
+		return nil
+	}
+}
+
 // WithJWTVerifierAllowMissingExpiration disables the default requirement for exp.
 func WithJWTVerifierAllowMissingExpiration() JWTVerifierOption {
 	return func(cfg *jwtVerifierConfig) error {
@@ -470,7 +653,12 @@ func (v *JWTVerifier) Verify(tokenString string, claims jwt.Claims) error {
 		return ErrJWTInvalidToken
 	}
 
-	return v.validateClaims(claims)
+	err = v.validateType(token)
+	if err != nil {
+		return err
+	}
+
+	return v.rules.validate(claims)
 }
 
 // VerifyMap parses and validates a JWT into a map of claims.
@@ -485,6 +673,54 @@ func (v *JWTVerifier) VerifyMap(tokenString string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 
+// VerifyJWT verifies token into claims allocated by newClaims and returns them.
+// newClaims must return a usable value, typically a pointer to a fresh claims struct.
+func VerifyJWT[T jwt.Claims](v *JWTVerifier, token string, newClaims func() T) (T, error) {
+	var zero T
+
+	if v == nil {
+		return zero, ErrJWTInvalidConfig
+	}
+
+	if newClaims == nil {
+		return zero, ErrJWTMissingClaims
+	}
+
+	claims := newClaims()
+
+	value := reflect.ValueOf(claims)
+	if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return zero, ErrJWTMissingClaims
+	}
+
+	err := v.Verify(token, claims)
+	if err != nil {
+		return zero, err
+	}
+
+	return claims, nil
+}
+
+func (v *JWTVerifier) validateType(token *jwt.Token) error {
+	if v.requiredType == "" {
+		return nil
+	}
+
+	typ, ok := token.Header[jwtHeaderType].(string)
+	if !ok || normalizeJWTType(typ) != v.requiredType {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+// normalizeJWTType lowercases a typ value and strips the optional media type prefix (RFC 7515 section 4.1.9).
+func normalizeJWTType(typ string) string {
+	normalized := strings.ToLower(strings.TrimSpace(typ))
+
+	return strings.TrimPrefix(normalized, jwtMediaTypePrefix)
+}
+
 func (v *JWTVerifier) resolveKeyFunc() jwt.Keyfunc {
 	if v.keyFunc != nil {
 		return v.wrapKeyFunc(v.keyFunc)
@@ -504,8 +740,38 @@ func (v *JWTVerifier) wrapKeyFunc(keyFunc jwt.Keyfunc) jwt.Keyfunc {
 			return nil, err
 		}
 
-		return keyFunc(token)
+		key, err := keyFunc(token)
+		if err != nil {
+			return nil, err
+		}
+
+		err = v.checkKeyFuncKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	}
+}
+
+// checkKeyFuncKey applies the RSA size policy to a key, or key set, returned by a custom key function.
+func (v *JWTVerifier) checkKeyFuncKey(key any) error {
+	set, ok := key.(jwt.VerificationKeySet)
+	if !ok {
+		if rsaKeyTooSmall(key, v.minRSABits) {
+			return ErrJWTInvalidConfig
+		}
+
+		return nil
+	}
+
+	for _, candidate := range set.Keys {
+		if rsaKeyTooSmall(candidate, v.minRSABits) {
+			return ErrJWTInvalidConfig
+		}
 	}
+
+	return nil
 }
 
 func (v *JWTVerifier) keyMapFunc() jwt.Keyfunc {
@@ -515,12 +781,22 @@ func (v *JWTVerifier) keyMapFunc() jwt.Keyfunc {
 			return nil, err
 		}
 
-		key, ok := v.keys[kid]
-		if !ok || key == nil {
+		candidates, ok := v.keys[kid]
+		if !ok || len(candidates) == 0 || candidates[0] == nil {
 			return nil, ErrJWTMissingKey
 		}
 
-		return key, nil
+		if len(candidates) == 1 {
+			return candidates[0], nil
+		}
+
+		// The parser tries each key in the set and fails only if none verifies the signature.
+		set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, 0, len(candidates))}
+		for _, candidate := range candidates {
+			set.Keys = append(set.Keys, candidate)
+		}
+
+		return set, nil
 	}
 }
 
@@ -557,172 +833,6 @@ func (*JWTVerifier) tokenKeyID(token *jwt.Token, required bool) (string, error)
 	return kid, nil
 }
 
-func (v *JWTVerifier) validateClaims(claims jwt.Claims) error {
-	now := v.now()
-
-	err := v.validateExpiration(claims, now)
-	if err != nil {
-		return err
-	}
-
-	err = v.validateNotBefore(claims, now)
-	if err != nil {
-		return err
-	}
-
-	err = v.validateIssuedAt(claims, now)
-	if err != nil {
-		return err
-	}
-
-	err = v.validateIssuer(claims)
-	if err != nil {
-		return err
-	}
-
-	err = v.validateSubject(claims)
-	if err != nil {
-		return err
-	}
-
-	return v.validateAudience(claims)
-}
-
-func (v *JWTVerifier) validateExpiration(claims jwt.Claims, now time.Time) error {
-	if !v.requireExpiration {
-		return nil
-	}
-
-	exp, err := claims.GetExpirationTime()
-	if err != nil || exp == nil {
-		return ErrJWTMissingExpiration
-	}
-
-	if now.After(exp.Add(v.leeway)) {
-		return ErrJWTInvalidToken
-	}
-
-	return nil
-}
-
-func (v *JWTVerifier) validateNotBefore(claims jwt.Claims, now time.Time) error {
-	nbf, err := claims.GetNotBefore()
-	if err != nil {
-		return ErrJWTInvalidToken
-	}
-
-	if nbf == nil {
-		return nil
-	}
-
-	if now.Add(v.leeway).Before(nbf.Time) {
-		return ErrJWTInvalidToken
-	}
-
-	return nil
-}
-
-func (v *JWTVerifier) validateIssuedAt(claims jwt.Claims, now time.Time) error {
-	iat, err := claims.GetIssuedAt()
-	if err != nil {
-		return ErrJWTInvalidToken
-	}
-
-	if iat == nil {
-		return nil
-	}
-
-	if now.Add(v.leeway).Before(iat.Time) {
-		return ErrJWTInvalidToken
-	}
-
-	return nil
-}
-
-func (v *JWTVerifier) validateIssuer(claims jwt.Claims) error {
-	if v.issuer == "" {
-		return nil
-	}
-
-	iss, err := claims.GetIssuer()
-	if err != nil {
-		return ErrJWTInvalidToken
-	}
-
-	iss = strings.TrimSpace(iss)
-	if iss == "" {
-		return ErrJWTMissingClaims
-	}
-
-	if iss != v.issuer {
-		return ErrJWTInvalidToken
-	}
-
-	return nil
-}
-
-func (v *JWTVerifier) validateSubject(claims jwt.Claims) error {
-	if v.subject == "" {
-		return nil
-	}
-
-	sub, err := claims.GetSubject()
-	if err != nil {
-		return ErrJWTInvalidToken
-	}
-
-	sub = strings.TrimSpace(sub)
-	if sub == "" {
-		return ErrJWTMissingClaims
-	}
-
-	if sub != v.subject {
-		return ErrJWTInvalidToken
-	}
-
-	return nil
-}
-
-func (v *JWTVerifier) validateAudience(claims jwt.Claims) error {
-	if len(v.audiences) == 0 {
-		return nil
-	}
-
-	aud, err := claims.GetAudience()
-	if err != nil {
-		return ErrJWTInvalidToken
-	}
-
-	if len(aud) == 0 {
-		return ErrJWTMissingClaims
-	}
-
-	if !audienceMatches(v.audiences, aud) {
-		return ErrJWTInvalidAudience
-	}
-
-	return nil
-}
-
-func audienceMatches(expected []string, actual jwt.ClaimStrings) bool {
-	if len(actual) == 0 || len(expected) == 0 {
-		return false
-	}
-
-	set := make(map[string]struct{}, len(actual))
-	for _, audience := range actual {
-		set[audience] = struct{}{}
-	}
-
-	for _, audience := range expected {
-		if _, ok := set[audience]; ok {
-			return true
-		}
-	}
-
-	return false
-}
-
 func containsString(values []string, target string) bool {
 	return slices.Contains(values, target)
 }
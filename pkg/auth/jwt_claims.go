@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtClaimRules holds the standard-claim checks shared by JWT and JWE verification.
+type jwtClaimRules struct {
+	issuer            string
+	audiences         []string
+	subject           string
+	leeway            time.Duration
+	now               func() time.Time
+	requireExpiration bool
+	maxAge            time.Duration
+}
+
+func (r jwtClaimRules) validate(claims jwt.Claims) error {
+	now := r.now()
+
+	err := r.validateExpiration(claims, now)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateNotBefore(claims, now)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateIssuedAt(claims, now)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateIssuer(claims)
+	if err != nil {
+		return err
+	}
+
+	err = r.validateSubject(claims)
+	if err != nil {
+		return err
+	}
+
+	return r.validateAudience(claims)
+}
+
+func (r jwtClaimRules) validateExpiration(claims jwt.Claims, now time.Time) error {
+	if !r.requireExpiration {
+		return nil
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return ErrJWTMissingExpiration
+	}
+
+	if now.After(exp.Add(r.leeway)) {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+func (r jwtClaimRules) validateNotBefore(claims jwt.Claims, now time.Time) error {
+	nbf, err := claims.GetNotBefore()
+	if err != nil {
+		return ErrJWTInvalidToken
+	}
+
+	if nbf == nil {
+		return nil
+	}
+
+	if now.Add(r.leeway).Before(nbf.Time) {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+func (r jwtClaimRules) validateIssuedAt(claims jwt.Claims, now time.Time) error {
+	iat, err := claims.GetIssuedAt()
+	if err != nil {
+		return ErrJWTInvalidToken
+	}
+
+	if iat == nil {
+		if r.maxAge > 0 {
+			return ErrJWTMissingClaims
+		}
+
+		return nil
+	}
+
+	if now.Add(r.leeway).Before(iat.Time) {
+		return ErrJWTInvalidToken
+	}
+
+	if r.maxAge > 0 && iat.Before(now.Add(-r.maxAge-r.leeway)) {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+func (r jwtClaimRules) validateIssuer(claims jwt.Claims) error {
+	if r.issuer == "" {
+		return nil
+	}
+
+	iss, err := claims.GetIssuer()
+	if err != nil {
+		return ErrJWTInvalidToken
+	}
+
+	iss = strings.TrimSpace(iss)
+	if iss == "" {
+		return ErrJWTMissingClaims
+	}
+
+	if iss != r.issuer {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+func (r jwtClaimRules) validateSubject(claims jwt.Claims) error {
+	if r.subject == "" {
+		return nil
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil {
+		return ErrJWTInvalidToken
+	}
+
+	sub = strings.TrimSpace(sub)
+	if sub == "" {
+		return ErrJWTMissingClaims
+	}
+
+	if sub != r.subject {
+		return ErrJWTInvalidToken
+	}
+
+	return nil
+}
+
+func (r jwtClaimRules) validateAudience(claims jwt.Claims) error {
+	if len(r.audiences) == 0 {
+		return nil
+	}
+
+	aud, err := claims.GetAudience()
+	if err != nil {
+		return ErrJWTInvalidToken
+	}
+
+	if len(aud) == 0 {
+		return ErrJWTMissingClaims
+	}
+
+	if !audienceMatches(r.audiences, aud) {
+		return ErrJWTInvalidAudience
+	}
+
+	return nil
+}
+
+func audienceMatches(expected []string, actual jwt.ClaimStrings) bool {
+	if len(actual) == 0 || len(expected) == 0 {
+		return false
+	}
+
+	set := make(map[string]struct{}, len(actual))
+	for _, audience := range actual {
+		set[audience] = struct{}{}
+	}
+
+	for _, audience := range expected {
+		if _, ok := set[audience]; ok {
+			return true
+		}
+	}
+
+	return false
+}
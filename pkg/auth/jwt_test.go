@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
 	"testing"
 	"time"
@@ -186,3 +190,379 @@ func TestJWTVerifierKeySetRequiresKid(t *testing.T) {
 		t.Fatalf("expected ErrJWTMissingKeyID, got %v", err)
 	}
 }
+
+func TestJWTSignerClockStampsIssuedAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	secret := []byte("supersecret")
+
+	signer, err := NewJWTSigner(
+		WithJWTSigningAlgorithm("HS256"),
+		WithJWTSigningKey(secret),
+		WithJWTSignerClock(func() time.Time { return now }),
+		WithJWTSignerIssuedAt(),
+	)
+	if err != nil {
+		t.Fatalf(errMsgExpectedSigner, err)
+	}
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKey(secret),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	claims := &jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	if claims.IssuedAt != nil {
+		t.Fatal("expected caller claims to be left untouched")
+	}
+
+	parsed := &jwt.RegisteredClaims{}
+
+	err = verifier.Verify(token, parsed)
+	if err != nil {
+		t.Fatalf("expected verify success, got error: %v", err)
+	}
+
+	if parsed.IssuedAt == nil || !parsed.IssuedAt.Equal(now) {
+		t.Fatalf("expected iat %v, got %v", now, parsed.IssuedAt)
+	}
+}
+
+func TestJWTVerifierRequireType(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	secret := []byte("supersecret")
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	accessToken.Header["typ"] = "application/AT+JWT"
+
+	access, err := accessToken.SignedString(secret)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	plain, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	untypedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	delete(untypedToken.Header, "typ")
+
+	untyped, err := untypedToken.SignedString(secret)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKey(secret),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+		WithJWTRequireType("at+jwt"),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	err = verifier.Verify(access, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("expected typed token to verify, got %v", err)
+	}
+
+	for _, token := range []string{plain, untyped} {
+		err = verifier.Verify(token, &jwt.RegisteredClaims{})
+		if !errors.Is(err, ErrJWTInvalidToken) {
+			t.Fatalf("expected ErrJWTInvalidToken, got %v", err)
+		}
+	}
+
+	_, err = NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKey(secret),
+		WithJWTRequireType(" "),
+	)
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig, got %v", err)
+	}
+}
+
+func TestVerifyJWTGeneric(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	secret := []byte("supersecret")
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   "user-1",
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}).SignedString(secret)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKey(secret),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	claims, err := VerifyJWT(verifier, token, func() *jwt.RegisteredClaims { return &jwt.RegisteredClaims{} })
+	if err != nil {
+		t.Fatalf("expected claims, got %v", err)
+	}
+
+	if claims.Subject != "user-1" {
+		t.Fatalf("expected subject user-1, got %q", claims.Subject)
+	}
+
+	_, err = VerifyJWT(verifier, token, func() *jwt.RegisteredClaims { return nil })
+	if !errors.Is(err, ErrJWTMissingClaims) {
+		t.Fatalf("expected ErrJWTMissingClaims, got %v", err)
+	}
+
+	_, err = VerifyJWT[*jwt.RegisteredClaims](verifier, token, nil)
+	if !errors.Is(err, ErrJWTMissingClaims) {
+		t.Fatalf("expected ErrJWTMissingClaims, got %v", err)
+	}
+
+	mapClaims, err := VerifyJWT(verifier, token+"x", func() jwt.MapClaims { return jwt.MapClaims{} })
+	if !errors.Is(err, ErrJWTInvalidToken) || mapClaims != nil {
+		t.Fatalf("expected ErrJWTInvalidToken and nil claims, got %v %v", mapClaims, err)
+	}
+}
+
+func TestJWTVerifierMaxAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	secret := []byte("supersecret")
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKey(secret),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+		WithJWTMaxAge(10*time.Minute),
+		WithJWTLeeway(time.Minute),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	sign := func(issuedAt *jwt.NumericDate) string {
+		token, signErr := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{"apps"},
+			IssuedAt:  issuedAt,
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}).SignedString(secret)
+		if signErr != nil {
+			t.Fatalf(errMsgExpectedToken, signErr)
+		}
+
+		return token
+	}
+
+	err = verifier.Verify(sign(jwt.NewNumericDate(now.Add(-10*time.Minute))), &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("expected token within max age, got %v", err)
+	}
+
+	err = verifier.Verify(sign(jwt.NewNumericDate(now.Add(-12*time.Minute))), &jwt.RegisteredClaims{})
+	if !errors.Is(err, ErrJWTInvalidToken) {
+		t.Fatalf("expected ErrJWTInvalidToken for old token, got %v", err)
+	}
+
+	err = verifier.Verify(sign(nil), &jwt.RegisteredClaims{})
+	if !errors.Is(err, ErrJWTMissingClaims) {
+		t.Fatalf("expected ErrJWTMissingClaims without iat, got %v", err)
+	}
+
+	_, err = NewJWTVerifier(WithJWTMaxAge(0))
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig, got %v", err)
+	}
+}
+
+func TestJWTVerifierKeySetsSharedKid(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC) //nolint:revive
+	oldKey := []byte("old-rotation-secret")
+	newKey := []byte("new-rotation-secret")
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKeySets(map[string][]any{"kid-1": {oldKey, newKey}}),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	for _, key := range [][]byte{oldKey, newKey, []byte("unknown-secret")} {
+		signer, err := NewJWTSigner(
+			WithJWTSigningAlgorithm("HS256"),
+			WithJWTSigningKey(key),
+			WithJWTSigningKeyID("kid-1"),
+		)
+		if err != nil {
+			t.Fatalf(errMsgExpectedSigner, err)
+		}
+
+		token, err := signer.Sign(claims)
+		if err != nil {
+			t.Fatalf(errMsgExpectedToken, err)
+		}
+
+		err = verifier.Verify(token, &jwt.RegisteredClaims{})
+		if string(key) == "unknown-secret" {
+			if !errors.Is(err, ErrJWTInvalidToken) {
+				t.Fatalf("expected ErrJWTInvalidToken after all candidates fail, got %v", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("expected candidate key to verify, got %v", err)
+		}
+	}
+
+	_, err = NewJWTVerifier(
+		WithJWTAllowedAlgorithms("HS256"),
+		WithJWTVerificationKeySets(map[string][]any{"kid-1": {}}),
+	)
+	if !errors.Is(err, ErrJWTMissingKey) {
+		t.Fatalf("expected ErrJWTMissingKey, got %v", err)
+	}
+
+	for _, typedNil := range []any{(*rsa.PublicKey)(nil), (*ecdsa.PublicKey)(nil), ed25519.PublicKey(nil)} {
+		_, err = NewJWTVerifier(
+			WithJWTAllowedAlgorithms("RS256"),
+			WithJWTVerificationKeySets(map[string][]any{"kid-1": {newKey, typedNil}}),
+		)
+		if !errors.Is(err, ErrJWTMissingKey) {
+			t.Fatalf("expected ErrJWTMissingKey for %T, got %v", typedNil, err)
+		}
+	}
+
+	_, err = NewJWTVerifier(WithJWTVerificationKey((*rsa.PublicKey)(nil)))
+	if !errors.Is(err, ErrJWTMissingKey) {
+		t.Fatalf("expected ErrJWTMissingKey for typed nil key, got %v", err)
+	}
+}
+
+func TestJWTMinRSAKeySize(t *testing.T) {
+	t.Parallel()
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("expected rsa key, got %v", err)
+	}
+
+	strong, err := rsa.GenerateKey(rand.Reader, defaultMinRSAKeyBits)
+	if err != nil {
+		t.Fatalf("expected rsa key, got %v", err)
+	}
+
+	_, err = NewJWTSigner(WithJWTSigningAlgorithm("RS256"), WithJWTSigningKey(weak))
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig for weak signing key, got %v", err)
+	}
+
+	_, err = NewJWTSigner(WithJWTSigningAlgorithm("RS256"), WithJWTSigningKey(strong))
+	if err != nil {
+		t.Fatalf(errMsgExpectedSigner, err)
+	}
+
+	_, err = NewJWTSigner(WithJWTSigningAlgorithm("RS256"), WithJWTSigningKey(strong), WithJWTSignerMinRSAKeySize(3072))
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig below raised minimum, got %v", err)
+	}
+
+	_, err = NewJWTSigner(WithJWTSignerMinRSAKeySize(1024))
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig for lowered minimum, got %v", err)
+	}
+
+	verifierOpts := []JWTVerifierOption{
+		WithJWTAllowedAlgorithms("RS256"),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("api"),
+	}
+
+	_, err = NewJWTVerifier(append(verifierOpts, WithJWTVerificationKey(&weak.PublicKey))...)
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig for weak verification key, got %v", err)
+	}
+
+	_, err = NewJWTVerifier(append(verifierOpts, WithJWTVerificationKeys(map[string]any{"old": &weak.PublicKey}))...)
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig for weak key in key map, got %v", err)
+	}
+
+	verifier, err := NewJWTVerifier(append(verifierOpts, WithJWTVerificationKeyFunc(func(*jwt.Token) (any, error) {
+		return &weak.PublicKey, nil
+	}))...)
+	if err != nil {
+		t.Fatalf("expected verifier, got %v", err)
+	}
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"api"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(weak)
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	_, err = verifier.VerifyMap(token)
+	if !errors.Is(err, ErrJWTInvalidConfig) {
+		t.Fatalf("expected ErrJWTInvalidConfig for weak key from key func, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+const (
+	pemTypePrivateKey          = "PRIVATE KEY"
+	pemTypeRSAPrivateKey       = "RSA PRIVATE KEY"
+	pemTypeECPrivateKey        = "EC PRIVATE KEY"
+	pemTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	pemTypePublicKey           = "PUBLIC KEY"
+	pemTypeRSAPublicKey        = "RSA PUBLIC KEY"
+	pemTypeCertificate         = "CERTIFICATE"
+
+	pemHeaderProcType = "Proc-Type"
+	pemProcEncrypted  = "ENCRYPTED"
+
+	algEdDSA = "EdDSA"
+	algRS256 = "RS256"
+	algES256 = "ES256"
+	algES384 = "ES384"
+	algES512 = "ES512"
+)
+
+// ParsePrivateKeyPEM parses a PEM-encoded private key and suggests a JWT algorithm.
+// Supported encodings are PKCS#8, PKCS#1 (RSA), and SEC 1 (EC). RSA keys suggest RS256;
+// they are equally usable with the PS256/PS384/PS512 (RSA-PSS) algorithms.
+// Encrypted keys are rejected with ErrKeyEncrypted.
+func ParsePrivateKeyPEM(data []byte) (any, string, error) {
+	block, err := decodeKeyPEM(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var key any
+
+	switch block.Type {
+	case pemTypePrivateKey:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case pemTypeRSAPrivateKey:
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case pemTypeECPrivateKey:
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, "", ErrKeyUnsupported
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrKeyInvalidPEM, err)
+	}
+
+	alg, err := suggestedAlgorithm(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, alg, nil
+}
+
+// ParsePublicKeyPEM parses a PEM-encoded public key or certificate and suggests a JWT algorithm.
+// Supported encodings are PKIX, PKCS#1 (RSA), and X.509 certificates.
+func ParsePublicKeyPEM(data []byte) (any, string, error) {
+	block, err := decodeKeyPEM(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var key any
+
+	switch block.Type {
+	case pemTypePublicKey:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case pemTypeRSAPublicKey:
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case pemTypeCertificate:
+		var cert *x509.Certificate
+
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return nil, "", ErrKeyUnsupported
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrKeyInvalidPEM, err)
+	}
+
+	alg, err := suggestedAlgorithm(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, alg, nil
+}
+
+func decodeKeyPEM(data []byte) (*pem.Block, error) {
+	if len(data) == 0 {
+		return nil, ErrKeyInvalidPEM
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrKeyInvalidPEM
+	}
+
+	if block.Type == pemTypeEncryptedPrivateKey {
+		return nil, ErrKeyEncrypted
+	}
+
+	if strings.Contains(block.Headers[pemHeaderProcType], pemProcEncrypted) {
+		return nil, ErrKeyEncrypted
+	}
+
+	return block, nil
+}
+
+func suggestedAlgorithm(key any) (string, error) {
+	switch typed := key.(type) {
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return algEdDSA, nil
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return algRS256, nil
+	case *ecdsa.PrivateKey:
+		return ecdsaAlgorithm(typed.Curve)
+	case *ecdsa.PublicKey:
+		return ecdsaAlgorithm(typed.Curve)
+	default:
+		return "", ErrKeyUnsupported
+	}
+}
+
+// isNilKey reports whether key is nil, including typed nil pointers and empty key slices
+// that an `any` nil comparison misses.
+func isNilKey(key any) bool {
+	switch typed := key.(type) {
+	case nil:
+		return true
+	case *rsa.PublicKey:
+		return typed == nil
+	case *rsa.PrivateKey:
+		return typed == nil
+	case *ecdsa.PublicKey:
+		return typed == nil
+	case *ecdsa.PrivateKey:
+		return typed == nil
+	case ed25519.PublicKey:
+		return len(typed) == 0
+	case ed25519.PrivateKey:
+		return len(typed) == 0
+	case []byte:
+		return len(typed) == 0
+	default:
+		return false
+	}
+}
+
+// defaultMinRSAKeyBits is the smallest RSA key accepted by JWT and JWE unless raised by an option.
+const defaultMinRSAKeyBits = 2048
+
+// rsaKeyTooSmall reports whether key is an RSA key with a modulus shorter than minBits.
+// Non-RSA keys are never too small.
+func rsaKeyTooSmall(key any, minBits int) bool {
+	switch typed := key.(type) {
+	case *rsa.PublicKey:
+		return typed.N == nil || typed.N.BitLen() < minBits
+	case *rsa.PrivateKey:
+		return typed.N == nil || typed.N.BitLen() < minBits
+	default:
+		return false
+	}
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return algES256, nil
+	case elliptic.P384():
+		return algES384, nil
+	case elliptic.P521():
+		return algES512, nil
+	default:
+		return "", ErrKeyUnsupported
+	}
+}
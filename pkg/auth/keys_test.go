@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseKeyPEMEd25519RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("expected private der, got %v", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("expected public der, got %v", err)
+	}
+
+	signingKey, alg, err := ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	if err != nil {
+		t.Fatalf("expected private key, got %v", err)
+	}
+
+	if alg != "EdDSA" {
+		t.Fatalf("expected EdDSA, got %s", alg)
+	}
+
+	verificationKey, publicAlg, err := ParsePublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatalf("expected public key, got %v", err)
+	}
+
+	if publicAlg != alg {
+		t.Fatalf("expected matching algorithms, got %s and %s", alg, publicAlg)
+	}
+
+	signer, err := NewJWTSigner(WithJWTSigningAlgorithm(alg), WithJWTSigningKey(signingKey))
+	if err != nil {
+		t.Fatalf(errMsgExpectedSigner, err)
+	}
+
+	verifier, err := NewJWTVerifier(
+		WithJWTAllowedAlgorithms(alg),
+		WithJWTVerificationKey(verificationKey),
+		WithJWTIssuer(issuer),
+		WithJWTAudience("apps"),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	token, err := signer.Sign(jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{"apps"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf(errMsgExpectedToken, err)
+	}
+
+	_, err = verifier.VerifyMap(token)
+	if err != nil {
+		t.Fatalf("expected verify success, got error: %v", err)
+	}
+}
+
+func TestParsePrivateKeyPEMECDSA(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("expected der, got %v", err)
+	}
+
+	_, alg, err := ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("expected private key, got %v", err)
+	}
+
+	if alg != "ES384" {
+		t.Fatalf("expected ES384, got %s", alg)
+	}
+}
+
+func TestParsePrivateKeyPEMRejectsEncrypted(t *testing.T) {
+	t.Parallel()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{0x30, 0x00}})
+
+	_, _, err := ParsePrivateKeyPEM(data)
+	if !errors.Is(err, ErrKeyEncrypted) {
+		t.Fatalf("expected ErrKeyEncrypted, got %v", err)
+	}
+}
+
+func TestParsePublicKeyPEMInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := ParsePublicKeyPEM([]byte("not a pem"))
+	if !errors.Is(err, ErrKeyInvalidPEM) {
+		t.Fatalf("expected ErrKeyInvalidPEM, got %v", err)
+	}
+}
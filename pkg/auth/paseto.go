@@ -14,6 +14,7 @@ const pasetoWrapFormat = "%w: %w"
 type PasetoLocal struct {
 	key               paseto.V4SymmetricKey
 	requireExpiration bool
+	setIssuedAt       bool
 	issuer            string
 	audience          string
 	subject           string
@@ -27,6 +28,7 @@ type pasetoLocalConfig struct {
 	key               paseto.V4SymmetricKey
 	hasKey            bool
 	requireExpiration bool
+	setIssuedAt       bool
 	issuer            string
 	audience          string
 	subject           string
@@ -62,6 +64,7 @@ func NewPasetoLocal(opts ...PasetoLocalOption) (*PasetoLocal, error) {
 	return &PasetoLocal{
 		key:               cfg.key,
 		requireExpiration: cfg.requireExpiration,
+		setIssuedAt:       cfg.setIssuedAt,
 		issuer:            cfg.issuer,
 		audience:          cfg.audience,
 		subject:           cfg.subject,
@@ -157,7 +160,7 @@ func WithPasetoLocalSubject(subject string) PasetoLocalOption {
 	}
 }
 
-// WithPasetoLocalClock overrides the clock used for validation.
+// WithPasetoLocalClock overrides the clock used for validation and signing-time claims.
 func WithPasetoLocalClock(clock func() time.Time) PasetoLocalOption {
 	return func(cfg *pasetoLocalConfig) error {
 		if clock == nil {
@@ -179,12 +182,30 @@ func WithPasetoLocalAllowMissingExpiration() PasetoLocalOption {
 	}
 }
 
+// WithPasetoLocalIssuedAt stamps iat from the clock when the token does not set it.
+func WithPasetoLocalIssuedAt() PasetoLocalOption {
+	return func(cfg *pasetoLocalConfig) error {
+		cfg.setIssuedAt = true
+
+		return nil
+	}
+}
+
 // Encrypt encrypts a token using v4 local.
 func (p *PasetoLocal) Encrypt(token *paseto.Token) (string, error) {
 	if token == nil {
 		return "", ErrPasetoMissingToken
 	}
 
+	if p.setIssuedAt {
+		var err error
+
+		token, err = pasetoWithIssuedAt(token, p.clock())
+		if err != nil {
+			return "", err
+		}
+	}
+
 	if p.requireExpiration && !pasetoTokenHasExpiration(token) {
 		return "", ErrPasetoMissingExpiry
 	}
@@ -193,11 +214,17 @@ func (p *PasetoLocal) Encrypt(token *paseto.Token) (string, error) {
 }
 
 // Decrypt decrypts and validates a v4 local token.
+// Tokens with any other version or purpose are rejected with ErrPasetoInvalidToken.
 func (p *PasetoLocal) Decrypt(tokenString string) (*paseto.Token, error) {
 	if strings.TrimSpace(tokenString) == "" {
 		return nil, ErrPasetoMissingToken
 	}
 
+	err := checkPasetoHeader(tokenString, paseto.V4Local)
+	if err != nil {
+		return nil, err
+	}
+
 	parser := newPasetoParser(p.requireExpiration, p.issuer, p.audience, p.subject, p.clock())
 
 	token, err := parser.ParseV4Local(p.key, tokenString, nil)
@@ -208,10 +235,35 @@ func (p *PasetoLocal) Decrypt(tokenString string) (*paseto.Token, error) {
 	return token, nil
 }
 
+// DecryptUnchecked decrypts and authenticates a v4 local token without applying the
+// issuer, audience, subject, or expiry rules. Expired tokens are returned as valid.
+// Use it only to read claims needed to choose a policy, then enforce that policy yourself.
+func (p *PasetoLocal) DecryptUnchecked(tokenString string) (*paseto.Token, error) {
+	if strings.TrimSpace(tokenString) == "" {
+		return nil, ErrPasetoMissingToken
+	}
+
+	err := checkPasetoHeader(tokenString, paseto.V4Local)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := paseto.NewParserWithoutExpiryCheck()
+
+	token, err := parser.ParseV4Local(p.key, tokenString, nil)
+	if err != nil {
+		return nil, fmt.Errorf(pasetoWrapFormat, ErrPasetoInvalidToken, err)
+	}
+
+	return token, nil
+}
+
 // PasetoPublicSigner signs PASETO v4 public tokens.
 type PasetoPublicSigner struct {
 	key               paseto.V4AsymmetricSecretKey
 	requireExpiration bool
+	setIssuedAt       bool
+	clock             func() time.Time
 }
 
 // PasetoPublicSignerOption configures PASETO public signing behavior.
@@ -221,12 +273,15 @@ type pasetoPublicSignerConfig struct {
 	key               paseto.V4AsymmetricSecretKey
 	hasKey            bool
 	requireExpiration bool
+	setIssuedAt       bool
+	clock             func() time.Time
 }
 
 // NewPasetoPublicSigner constructs a PASETO v4 public signer.
 func NewPasetoPublicSigner(opts ...PasetoPublicSignerOption) (*PasetoPublicSigner, error) {
 	cfg := pasetoPublicSignerConfig{
 		requireExpiration: true,
+		clock:             time.Now,
 	}
 
 	for _, opt := range opts {
@@ -244,9 +299,15 @@ func NewPasetoPublicSigner(opts ...PasetoPublicSignerOption) (*PasetoPublicSigne
 		return nil, ErrPasetoMissingKey
 	}
 
+	if cfg.clock == nil {
+		return nil, ErrPasetoInvalidConfig
+	}
+
 	return &PasetoPublicSigner{
 		key:               cfg.key,
 		requireExpiration: cfg.requireExpiration,
+		setIssuedAt:       cfg.setIssuedAt,
+		clock:             cfg.clock,
 	}, nil
 }
 
@@ -320,12 +381,43 @@ func WithPasetoPublicSignerAllowMissingExpiration() PasetoPublicSignerOption {
 	}
 }
 
+// WithPasetoPublicSignerClock overrides the clock used for signing-time claims.
+func WithPasetoPublicSignerClock(clock func() time.Time) PasetoPublicSignerOption {
+	return func(cfg *pasetoPublicSignerConfig) error {
+		if clock == nil {
+			return ErrPasetoInvalidConfig
+		}
+
+		cfg.clock = clock
+
+		return nil
+	}
+}
+
+// WithPasetoPublicSignerIssuedAt stamps iat from the signer clock when the token does not set it.
+func WithPasetoPublicSignerIssuedAt() PasetoPublicSignerOption {
+	return func(cfg *pasetoPublicSignerConfig) error {
+		cfg.setIssuedAt = true
+
+		return nil
+	}
+}
+
 // Sign signs a token using v4 public.
 func (p *PasetoPublicSigner) Sign(token *paseto.Token) (string, error) {
 	if token == nil {
 		return "", ErrPasetoMissingToken
 	}
 
+	if p.setIssuedAt {
+		var err error
+
+		token, err = pasetoWithIssuedAt(token, p.clock())
+		if err != nil {
+			return "", err
+		}
+	}
+
 	if p.requireExpiration && !pasetoTokenHasExpiration(token) {
 		return "", ErrPasetoMissingExpiry
 	}
@@ -503,11 +595,17 @@ func WithPasetoPublicAllowMissingExpiration() PasetoPublicVerifierOption {
 }
 
 // Verify verifies and parses a v4 public token.
+// Tokens with any other version or purpose are rejected with ErrPasetoInvalidToken.
 func (p *PasetoPublicVerifier) Verify(tokenString string) (*paseto.Token, error) {
 	if strings.TrimSpace(tokenString) == "" {
 		return nil, ErrPasetoMissingToken
 	}
 
+	err := checkPasetoHeader(tokenString, paseto.V4Public)
+	if err != nil {
+		return nil, err
+	}
+
 	parser := newPasetoParser(p.requireExpiration, p.issuer, p.audience, p.subject, p.clock())
 
 	token, err := parser.ParseV4Public(p.key, tokenString, nil)
@@ -518,6 +616,56 @@ func (p *PasetoPublicVerifier) Verify(tokenString string) (*paseto.Token, error)
 	return token, nil
 }
 
+// Inspect verifies the signature of a v4 public token without applying the issuer, audience,
+// subject, or expiry rules. Expired tokens are returned as valid.
+// Use it only to read claims needed to choose a policy, then enforce that policy yourself.
+func (p *PasetoPublicVerifier) Inspect(tokenString string) (*paseto.Token, error) {
+	if strings.TrimSpace(tokenString) == "" {
+		return nil, ErrPasetoMissingToken
+	}
+
+	err := checkPasetoHeader(tokenString, paseto.V4Public)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := paseto.NewParserWithoutExpiryCheck()
+
+	token, err := parser.ParseV4Public(p.key, tokenString, nil)
+	if err != nil {
+		return nil, fmt.Errorf(pasetoWrapFormat, ErrPasetoInvalidToken, err)
+	}
+
+	return token, nil
+}
+
+// VerifyAndFooter verifies a v4 public token and also returns its footer bytes.
+// When verification fails but the token is well-formed, the footer is still returned alongside
+// the error; it is unauthenticated in that case and must only be used for key selection or logging.
+func (p *PasetoPublicVerifier) VerifyAndFooter(tokenString string) (*paseto.Token, []byte, error) {
+	token, err := p.Verify(tokenString)
+	if err == nil {
+		return token, token.Footer(), nil
+	}
+
+	footer, footerErr := paseto.NewParserWithoutExpiryCheck().UnsafeParseFooter(paseto.V4Public, tokenString)
+	if footerErr != nil {
+		return nil, nil, err
+	}
+
+	return nil, footer, err
+}
+
+// checkPasetoHeader rejects tokens whose version or purpose differs from protocol before any
+// parsing, so a v3 token or a local token passed to a public verifier fails fast.
+func checkPasetoHeader(tokenString string, protocol paseto.Protocol) error {
+	if !strings.HasPrefix(tokenString, protocol.Header()) {
+		return fmt.Errorf("%w: expected %s token", ErrPasetoInvalidToken, strings.TrimSuffix(protocol.Header(), "."))
+	}
+
+	return nil
+}
+
 func newPasetoParser(requireExpiration bool, issuer, audience, subject string, now time.Time) paseto.Parser {
 	parser := paseto.NewParserWithoutExpiryCheck()
 
@@ -563,3 +711,20 @@ func pasetoTokenHasExpiration(token *paseto.Token) bool {
 
 	return true
 }
+
+// pasetoWithIssuedAt returns a copy of token with iat set to now, or token itself when iat is already set.
+func pasetoWithIssuedAt(token *paseto.Token, now time.Time) (*paseto.Token, error) {
+	_, err := token.GetIssuedAt()
+	if err == nil {
+		return token, nil
+	}
+
+	clone, err := paseto.MakeToken(token.Claims(), token.Footer())
+	if err != nil {
+		return nil, fmt.Errorf("%w: set issued at: %w", ErrPasetoInvalidToken, err)
+	}
+
+	clone.SetIssuedAt(now)
+
+	return clone, nil
+}
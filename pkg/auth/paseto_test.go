@@ -121,3 +121,233 @@ func TestPasetoMissingExpiration(t *testing.T) {
 		t.Fatalf("expected ErrPasetoMissingExpiry, got %v", err)
 	}
 }
+
+func TestPasetoPublicSignerClockStampsIssuedAt(t *testing.T) {
+	t.Parallel()
+	//nolint:revive
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret := paseto.NewV4AsymmetricSecretKey()
+
+	signer, err := NewPasetoPublicSigner(
+		WithPasetoPublicSecretKey(secret),
+		WithPasetoPublicSignerClock(func() time.Time { return now }),
+		WithPasetoPublicSignerIssuedAt(),
+	)
+	if err != nil {
+		t.Fatalf("expected signer, got error: %v", err)
+	}
+
+	verifier, err := NewPasetoPublicVerifier(
+		WithPasetoPublicKey(secret.Public()),
+		WithPasetoPublicClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	token := paseto.NewToken()
+	token.SetExpiration(now.Add(time.Hour))
+
+	signed, err := signer.Sign(&token)
+	if err != nil {
+		t.Fatalf("expected signed token, got error: %v", err)
+	}
+
+	parsed, err := verifier.Verify(signed)
+	if err != nil {
+		t.Fatalf("expected verified token, got error: %v", err)
+	}
+
+	issuedAt, err := parsed.GetIssuedAt()
+	if err != nil || !issuedAt.Equal(now) {
+		t.Fatalf("expected iat %v, got %v (%v)", now, issuedAt, err)
+	}
+}
+
+func TestPasetoPublicVerifyAndFooter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret := paseto.NewV4AsymmetricSecretKey()
+
+	signer, err := NewPasetoPublicSigner(WithPasetoPublicSecretKey(secret))
+	if err != nil {
+		t.Fatalf(errMsgExpectedSigner, err)
+	}
+
+	verifier, err := NewPasetoPublicVerifier(
+		WithPasetoPublicKey(secret.Public()),
+		WithPasetoPublicClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	token := paseto.NewToken()
+	token.SetExpiration(now.Add(time.Hour))
+	token.SetFooter([]byte(`{"kid":"k1"}`))
+
+	signed, err := signer.Sign(&token)
+	if err != nil {
+		t.Fatalf("expected signed token, got error: %v", err)
+	}
+
+	parsed, footer, err := verifier.VerifyAndFooter(signed)
+	if err != nil || parsed == nil {
+		t.Fatalf("expected parsed token, got error: %v", err)
+	}
+
+	if string(footer) != `{"kid":"k1"}` {
+		t.Fatalf("expected footer, got %q", footer)
+	}
+
+	other, err := NewPasetoPublicVerifier(WithPasetoPublicKey(paseto.NewV4AsymmetricSecretKey().Public()))
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	parsed, footer, err = other.VerifyAndFooter(signed)
+	if !errors.Is(err, ErrPasetoInvalidToken) || parsed != nil {
+		t.Fatalf("expected ErrPasetoInvalidToken, got %v", err)
+	}
+
+	if string(footer) != `{"kid":"k1"}` {
+		t.Fatalf("expected unauthenticated footer on failure, got %q", footer)
+	}
+
+	_, footer, err = verifier.VerifyAndFooter("not-a-token")
+	if err == nil || footer != nil {
+		t.Fatalf("expected error without footer, got %q, %v", footer, err)
+	}
+}
+
+func TestPasetoInspectSkipsRules(t *testing.T) {
+	t.Parallel()
+	//nolint:revive
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret := paseto.NewV4AsymmetricSecretKey()
+	symmetric := paseto.NewV4SymmetricKey()
+
+	token := paseto.NewToken()
+	token.SetExpiration(now.Add(-time.Hour))
+	token.SetIssuer("tenant-a")
+	token.SetString("tenant", "a")
+
+	signed := token.V4Sign(secret, nil)
+	encrypted := token.V4Encrypt(symmetric, nil)
+
+	verifier, err := NewPasetoPublicVerifier(
+		WithPasetoPublicKey(secret.Public()),
+		WithPasetoPublicIssuer("tenant-b"),
+		WithPasetoPublicClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	local, err := NewPasetoLocal(
+		WithPasetoLocalKey(symmetric),
+		WithPasetoLocalIssuer("tenant-b"),
+		WithPasetoLocalClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("expected local helper, got error: %v", err)
+	}
+
+	_, err = verifier.Verify(signed)
+	if !errors.Is(err, ErrPasetoInvalidToken) {
+		t.Fatalf("expected Verify to enforce rules, got %v", err)
+	}
+
+	inspected, err := verifier.Inspect(signed)
+	if err != nil {
+		t.Fatalf("expected inspected token, got %v", err)
+	}
+
+	decrypted, err := local.DecryptUnchecked(encrypted)
+	if err != nil {
+		t.Fatalf("expected decrypted token, got %v", err)
+	}
+
+	for _, parsed := range []*paseto.Token{inspected, decrypted} {
+		tenant, err := parsed.GetString("tenant")
+		if err != nil || tenant != "a" {
+			t.Fatalf("expected tenant claim, got %q %v", tenant, err)
+		}
+	}
+
+	other := paseto.NewV4AsymmetricSecretKey()
+
+	_, err = verifier.Inspect(token.V4Sign(other, nil))
+	if !errors.Is(err, ErrPasetoInvalidToken) {
+		t.Fatalf("expected forged signature to fail, got %v", err)
+	}
+
+	_, err = local.DecryptUnchecked(encrypted + "x")
+	if !errors.Is(err, ErrPasetoInvalidToken) {
+		t.Fatalf("expected tampered token to fail, got %v", err)
+	}
+}
+
+func TestPasetoRejectsMismatchedVersionAndPurpose(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	symmetric := paseto.NewV4SymmetricKey()
+	secret := paseto.NewV4AsymmetricSecretKey()
+
+	token := paseto.NewToken()
+	token.SetExpiration(now.Add(time.Hour))
+
+	v3Local := token.V3Encrypt(paseto.NewV3SymmetricKey(), nil)
+	v3Public := token.V3Sign(paseto.NewV3AsymmetricSecretKey(), nil)
+	v4Local := token.V4Encrypt(symmetric, nil)
+	v4Public := token.V4Sign(secret, nil)
+
+	local, err := NewPasetoLocal(WithPasetoLocalKey(symmetric))
+	if err != nil {
+		t.Fatalf("expected local helper, got error: %v", err)
+	}
+
+	verifier, err := NewPasetoPublicVerifier(WithPasetoPublicKey(secret.Public()))
+	if err != nil {
+		t.Fatalf("expected verifier, got error: %v", err)
+	}
+
+	reads := map[string]func(string) (*paseto.Token, error){
+		"Decrypt":          local.Decrypt,
+		"DecryptUnchecked": local.DecryptUnchecked,
+		"Verify":           verifier.Verify,
+		"Inspect":          verifier.Inspect,
+	}
+
+	accepted := map[string]string{
+		"Decrypt":          v4Local,
+		"DecryptUnchecked": v4Local,
+		"Verify":           v4Public,
+		"Inspect":          v4Public,
+	}
+
+	for name, read := range reads {
+		_, err = read(accepted[name])
+		if err != nil {
+			t.Fatalf("%s: expected matching token to parse, got %v", name, err)
+		}
+
+		for _, mismatched := range []string{v3Local, v3Public, v4Local, v4Public} {
+			if mismatched == accepted[name] {
+				continue
+			}
+
+			_, err = read(mismatched)
+			if !errors.Is(err, ErrPasetoInvalidToken) {
+				t.Fatalf("%s: expected ErrPasetoInvalidToken for %.10s, got %v", name, mismatched, err)
+			}
+		}
+	}
+
+	_, footer, err := verifier.VerifyAndFooter(v3Public)
+	if !errors.Is(err, ErrPasetoInvalidToken) || footer != nil {
+		t.Fatalf("expected v3 token to be rejected without footer, got %q %v", footer, err)
+	}
+}
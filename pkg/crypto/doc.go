@@ -0,0 +1,2 @@
+// Package crypto provides key-derivation helpers for splitting a master secret into subkeys.
+package crypto
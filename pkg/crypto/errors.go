@@ -0,0 +1,10 @@
+package crypto
+
+import "github.com/hyp3rd/ewrap"
+
+var (
+	// ErrInvalidKDFConfig indicates that the key-derivation parameters are invalid.
+	ErrInvalidKDFConfig = ewrap.New("invalid key derivation config")
+	// ErrInvalidKeyLength indicates that the requested key length exceeds what the hash can derive.
+	ErrInvalidKeyLength = ewrap.New("invalid derived key length")
+)
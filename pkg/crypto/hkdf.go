@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const (
+	hkdfMaxBlocks = 255
+	// DerivedKeySize is the length of each key returned by DeriveKeys.
+	DerivedKeySize = 32
+)
+
+// HKDF derives length bytes from secret using HKDF (RFC 5869) with the given hash, delegating to
+// crypto/hkdf. length must be between 1 and 255 times the hash output size.
+func HKDF(hash func() hash.Hash, secret, salt, info []byte, length int) ([]byte, error) {
+	if hash == nil || len(secret) == 0 {
+		return nil, ErrInvalidKDFConfig
+	}
+
+	if length <= 0 || length > hkdfMaxBlocks*hash().Size() {
+		return nil, ErrInvalidKeyLength
+	}
+
+	key, err := hkdf.Key(hash, secret, salt, string(info), length)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKDFConfig, err)
+	}
+
+	return key, nil
+}
+
+// DeriveKeys derives a distinct 32-byte key per label from master using HKDF-SHA256,
+// with each label as the HKDF info parameter. Labels must be non-empty and unique.
+func DeriveKeys(master []byte, labels ...string) (map[string][]byte, error) {
+	if len(master) == 0 || len(labels) == 0 {
+		return nil, ErrInvalidKDFConfig
+	}
+
+	for i, label := range labels {
+		if label == "" || slices.Contains(labels[:i], label) {
+			return nil, ErrInvalidKDFConfig
+		}
+	}
+
+	keys := make(map[string][]byte, len(labels))
+
+	for _, label := range labels {
+		key, err := HKDF(sha256.New, master, nil, []byte(label), DerivedKeySize)
+		if err != nil {
+			for _, derived := range keys {
+				memory.ZeroBytes(derived)
+			}
+
+			return nil, err
+		}
+
+		keys[label] = key
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,121 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestHKDFRFC5869Vectors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		secret string
+		salt   string
+		info   string
+		length int
+		okm    string
+	}{
+		{
+			name:   "basic",
+			secret: "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			salt:   "000102030405060708090a0b0c",
+			info:   "f0f1f2f3f4f5f6f7f8f9",
+			length: 42,
+			okm:    "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{
+			name:   "empty salt and info",
+			secret: "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			length: 42,
+			okm:    "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+	}
+
+	for _, tc := range cases {
+		okm, err := HKDF(sha256.New, mustHex(t, tc.secret), mustHex(t, tc.salt), mustHex(t, tc.info), tc.length)
+		if err != nil {
+			t.Fatalf("%s: expected derived key, got %v", tc.name, err)
+		}
+
+		if hex.EncodeToString(okm) != tc.okm {
+			t.Fatalf("%s: unexpected output %x", tc.name, okm)
+		}
+	}
+}
+
+func TestHKDFRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := HKDF(sha256.New, []byte("secret"), nil, nil, 255*sha256.Size+1)
+	if !errors.Is(err, ErrInvalidKeyLength) {
+		t.Fatalf("expected ErrInvalidKeyLength, got %v", err)
+	}
+
+	_, err = HKDF(sha256.New, []byte("secret"), nil, nil, 0)
+	if !errors.Is(err, ErrInvalidKeyLength) {
+		t.Fatalf("expected ErrInvalidKeyLength, got %v", err)
+	}
+
+	_, err = HKDF(sha256.New, nil, nil, nil, 32)
+	if !errors.Is(err, ErrInvalidKDFConfig) {
+		t.Fatalf("expected ErrInvalidKDFConfig, got %v", err)
+	}
+
+	_, err = HKDF(nil, []byte("secret"), nil, nil, 32)
+	if !errors.Is(err, ErrInvalidKDFConfig) {
+		t.Fatalf("expected ErrInvalidKDFConfig, got %v", err)
+	}
+}
+
+func TestDeriveKeys(t *testing.T) {
+	t.Parallel()
+
+	master := bytes.Repeat([]byte{0x42}, 32)
+
+	keys, err := DeriveKeys(master, "encryption", "mac")
+	if err != nil {
+		t.Fatalf("expected keys, got %v", err)
+	}
+
+	if len(keys["encryption"]) != DerivedKeySize || len(keys["mac"]) != DerivedKeySize {
+		t.Fatalf("expected %d-byte keys, got %d and %d", DerivedKeySize, len(keys["encryption"]), len(keys["mac"]))
+	}
+
+	if bytes.Equal(keys["encryption"], keys["mac"]) {
+		t.Fatal("expected distinct keys per label")
+	}
+
+	again, err := DeriveKeys(master, "mac")
+	if err != nil {
+		t.Fatalf("expected keys, got %v", err)
+	}
+
+	if !bytes.Equal(again["mac"], keys["mac"]) {
+		t.Fatal("expected derivation to be deterministic")
+	}
+
+	_, err = DeriveKeys(master, "mac", "mac")
+	if !errors.Is(err, ErrInvalidKDFConfig) {
+		t.Fatalf("expected ErrInvalidKDFConfig for duplicate labels, got %v", err)
+	}
+
+	_, err = DeriveKeys(master)
+	if !errors.Is(err, ErrInvalidKDFConfig) {
+		t.Fatalf("expected ErrInvalidKDFConfig without labels, got %v", err)
+	}
+}
+
+func mustHex(t *testing.T, value string) []byte {
+	t.Helper()
+
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatalf("expected hex, got %v", err)
+	}
+
+	return decoded
+}
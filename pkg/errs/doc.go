@@ -0,0 +1,2 @@
+// Package errs provides error types shared across sectools packages.
+package errs
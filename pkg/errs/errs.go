@@ -0,0 +1,64 @@
+package errs
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MultiError holds several errors reported together, such as every invalid option passed to a
+// constructor configured to collect all errors. errors.Is and errors.As match any held error.
+type MultiError struct {
+	Errors []error
+}
+
+// Join returns a *MultiError holding the non-nil errors, or nil when there are none.
+func Join(errs ...error) error {
+	held := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			held = append(held, err)
+		}
+	}
+
+	if len(held) == 0 {
+		return nil
+	}
+
+	return &MultiError{Errors: held}
+}
+
+// Error lists the held errors separated by semicolons.
+func (m *MultiError) Error() string {
+	if m == nil || len(m.Errors) == 0 {
+		return "no errors"
+	}
+
+	if len(m.Errors) == 1 {
+		return m.Errors[0].Error()
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(strconv.Itoa(len(m.Errors)))
+	builder.WriteString(" errors: ")
+
+	for i, err := range m.Errors {
+		if i > 0 {
+			builder.WriteString("; ")
+		}
+
+		builder.WriteString(err.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the held errors.
+func (m *MultiError) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+
+	return m.Errors
+}
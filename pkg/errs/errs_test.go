@@ -0,0 +1,38 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+var (
+	errFirst  = errors.New("first")
+	errSecond = errors.New("second")
+)
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	if Join() != nil || Join(nil, nil) != nil {
+		t.Fatal("expected nil for no errors")
+	}
+
+	err := Join(errFirst, nil, errSecond)
+
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("expected two held errors, got %v", err)
+	}
+
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Fatalf("expected held errors to match, got %v", err)
+	}
+
+	if err.Error() != "2 errors: first; second" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+
+	if Join(errFirst).Error() != "first" {
+		t.Fatalf("expected single error message unchanged, got %q", Join(errFirst).Error())
+	}
+}
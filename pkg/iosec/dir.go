@@ -15,6 +15,15 @@ func (c *Client) ReadDir(path string) ([]os.DirEntry, error) {
 	return internalio.SecureReadDirWithOptions(path, c.read, c.log)
 }
 
+// ReadDirIter streams directory entries to fn in batches, stopping at the first error fn returns.
+func (c *Client) ReadDirIter(path string, fn func(os.DirEntry) error) error {
+	if c.log != nil {
+		c.log.WithField("path", path).Debug("Iterating directory securely")
+	}
+
+	return internalio.SecureReadDirIter(path, c.read, fn, c.log)
+}
+
 // MkdirAll creates a directory securely.
 func (c *Client) MkdirAll(path string) error {
 	if c.log != nil {
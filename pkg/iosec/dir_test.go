@@ -1,6 +1,8 @@
 package iosec
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -40,6 +42,77 @@ func TestSecureReadDirNotDirectory(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotDirectory)
 }
 
+func TestSecureReadDirMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		err := os.WriteFile(filepath.Join(dirAbs, name), []byte("data"), 0o600)
+		require.NoError(t, err)
+	}
+
+	client, err := NewWithOptions(WithReadMaxEntries(2))
+	require.NoError(t, err)
+
+	_, err = client.ReadDir(dirRel)
+	require.ErrorIs(t, err, ErrTooManyEntries)
+
+	client, err = NewWithOptions(WithReadMaxEntries(3))
+	require.NoError(t, err)
+
+	entries, err := client.ReadDir(dirRel)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	_, err = NewWithOptions(WithReadMaxEntries(0))
+	require.ErrorIs(t, err, ErrMaxEntriesInvalid)
+}
+
+func TestSecureReadDirIter(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+
+	for i := range 300 {
+		err := os.WriteFile(filepath.Join(dirAbs, fmt.Sprintf("file-%03d.txt", i)), nil, 0o600)
+		require.NoError(t, err)
+	}
+
+	client := New()
+	seen := 0
+
+	err := client.ReadDirIter(dirRel, func(os.DirEntry) error {
+		seen++
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 300, seen)
+
+	errStop := errors.New("stop")
+	seen = 0
+
+	err = client.ReadDirIter(dirRel, func(os.DirEntry) error {
+		seen++
+		if seen == 10 {
+			return errStop
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, 10, seen)
+
+	_, fileRel := createTempFile(t, []byte("data"))
+
+	err = client.ReadDirIter(fileRel, func(os.DirEntry) error { return nil })
+	require.ErrorIs(t, err, ErrNotDirectory)
+
+	err = client.ReadDirIter(dirRel, nil)
+	require.ErrorIs(t, err, ErrNilCallback)
+}
+
 func TestSecureMkdirAllDefaultOptions(t *testing.T) {
 	t.Parallel()
 
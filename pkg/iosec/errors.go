@@ -43,6 +43,22 @@ var (
 	ErrOwnershipUnsupported = internalio.ErrOwnershipUnsupported
 	// ErrInvalidTempPrefix indicates a temp prefix was invalid.
 	ErrInvalidTempPrefix = internalio.ErrInvalidTempPrefix
+	// ErrInvalidBackupSuffix indicates a backup suffix was invalid.
+	ErrInvalidBackupSuffix = internalio.ErrInvalidBackupSuffix
+	// ErrInvalidWipePasses indicates a wipe pass count was invalid.
+	ErrInvalidWipePasses = internalio.ErrInvalidWipePasses
+	// ErrBackupFailed indicates the existing target could not be backed up before replacement.
+	ErrBackupFailed = internalio.ErrBackupFailed
+	// ErrMaxEntriesInvalid indicates the configured max directory entries is invalid.
+	ErrMaxEntriesInvalid = internalio.ErrMaxEntriesInvalid
+	// ErrTooManyEntries indicates a directory holds more entries than the configured maximum.
+	ErrTooManyEntries = internalio.ErrTooManyEntries
+	// ErrNilCallback indicates a nil callback was provided.
+	ErrNilCallback = internalio.ErrNilCallback
+	// ErrInvalidChecksum indicates a provided checksum has the wrong length.
+	ErrInvalidChecksum = internalio.ErrInvalidChecksum
 	// ErrChecksumMismatch indicates a checksum verification failure.
 	ErrChecksumMismatch = internalio.ErrChecksumMismatch
+	// ErrWriterClosed indicates a write to a file writer that was already closed.
+	ErrWriterClosed = internalio.ErrWriterClosed
 )
@@ -28,8 +28,18 @@ func (c *Client) OpenFile(file string) (*os.File, error) {
 	return internalio.SecureOpenFile(file, c.read, c.log)
 }
 
+// OpenFileLimited opens a file for streaming reads that enforce the configured read max size.
+func (c *Client) OpenFileLimited(file string) (io.ReadCloser, error) {
+	if c.log != nil {
+		c.log.WithField("file", file).Debug("Opening file securely with size limit")
+	}
+
+	return internalio.SecureOpenFileLimited(file, c.read, c.log)
+}
+
 // ReadFileWithSecureBuffer reads a file securely and returns the contents
-// in a SecureBuffer for better memory protection.
+// in a SecureBuffer for better memory protection. The read max size is enforced
+// while reading, and intermediate buffers are zeroed.
 func (c *Client) ReadFileWithSecureBuffer(filename string) (*memory.SecureBuffer, error) {
 	if c.log != nil {
 		c.log.WithField("file", filename).Debug("Reading file securely into secure buffer")
@@ -55,3 +65,32 @@ func (c *Client) WriteFromReader(file string, reader io.Reader) error {
 
 	return internalio.SecureWriteFromReader(file, reader, c.write, c.log)
 }
+
+// CompareAndSwap atomically replaces file with data only if its current SHA-256 digest equals
+// expectedSHA256, or, when expectedSHA256 is nil, only if the file does not exist.
+func (c *Client) CompareAndSwap(file string, expectedSHA256, data []byte) (bool, error) {
+	if c.log != nil {
+		c.log.WithField("file", file).Debug("Compare-and-swap writing file securely")
+	}
+
+	return internalio.SecureCompareAndSwap(file, expectedSHA256, data, c.write, c.log)
+}
+
+// CreateFile opens file for streaming writes under the client's write policy.
+// In atomic mode the data becomes visible only when the returned writer is closed.
+func (c *Client) CreateFile(file string) (io.WriteCloser, error) {
+	if c.log != nil {
+		c.log.WithField("file", file).Debug("Creating file securely for streaming writes")
+	}
+
+	return internalio.SecureCreateFile(file, c.write, c.log)
+}
+
+// TruncateFile overwrites an existing regular file with zeros and truncates it to zero length.
+func (c *Client) TruncateFile(file string) error {
+	if c.log != nil {
+		c.log.WithField("file", file).Debug("Truncating file securely")
+	}
+
+	return internalio.SecureTruncateFile(file, c.write, c.log)
+}
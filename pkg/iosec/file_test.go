@@ -1,12 +1,16 @@
 package iosec
 
 import (
+	"crypto/sha256"
 	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,6 +131,68 @@ func TestSecureReadFileWithMaxSizeTooLarge(t *testing.T) {
 	require.ErrorIs(t, err, ErrFileTooLarge)
 }
 
+func TestSecureReadFileWithMaxSizeSparseFile(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, nil)
+	require.NoError(t, os.Truncate(absPath, 1<<40))
+
+	client, err := NewWithOptions(WithReadMaxSize(readMaxSize))
+	require.NoError(t, err)
+
+	_, err = client.ReadFile(relPath)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+
+	_, err = client.ReadFileWithSecureBuffer(relPath)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+}
+
+func TestSecureOpenFileLimitedWithinLimit(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("secret")
+	_, relPath := createTempFile(t, data)
+
+	client, err := NewWithOptions(WithReadMaxSize(int64(len(data))))
+	require.NoError(t, err)
+
+	reader, err := client.OpenFileLimited(relPath)
+	require.NoError(t, err)
+
+	read, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	require.NoError(t, reader.Close())
+}
+
+func TestSecureOpenFileLimitedGrowsPastLimit(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("sec"))
+
+	client, err := NewWithOptions(WithReadMaxSize(readMaxSize))
+	require.NoError(t, err)
+
+	reader, err := client.OpenFileLimited(relPath)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, reader.Close())
+	}()
+
+	appendFile, err := os.OpenFile(absPath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+
+	_, err = appendFile.WriteString("ret")
+	require.NoError(t, err)
+	require.NoError(t, appendFile.Close())
+
+	read, err := io.ReadAll(reader)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, []byte("sec"), read)
+}
+
 func TestSecureReadFileWithMaxSizeInvalid(t *testing.T) {
 	t.Parallel()
 
@@ -306,6 +372,162 @@ func TestSecureWriteFileSyncDir(t *testing.T) {
 	assert.Equal(t, data, readData)
 }
 
+func TestSecureWriteFilePreserveMode(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not reliable on Windows")
+	}
+
+	absPath, relPath := createTempFile(t, []byte("existing"))
+	require.NoError(t, os.Chmod(absPath, 0o640))
+
+	client, err := NewWithOptions(WithWritePreserveMode(true))
+	require.NoError(t, err)
+
+	require.NoError(t, client.WriteFile(relPath, []byte("updated")))
+	require.NoError(t, client.WriteFromReader(relPath, strings.NewReader("streamed")))
+
+	info, err := os.Stat(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	enforced, err := NewWithOptions(
+		WithWritePreserveMode(true),
+		WithWriteEnforceFileMode(true),
+		WithWriteFileMode(0o600),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, enforced.WriteFile(relPath, []byte("enforced")))
+
+	info, err = os.Stat(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestSecureWriteFileBackupSuffix(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("original"))
+
+	t.Cleanup(func() {
+		//nolint:errcheck
+		_ = os.Remove(absPath + ".bak")
+	})
+
+	client, err := NewWithOptions(WithWriteBackupSuffix(".bak"))
+	require.NoError(t, err)
+
+	require.NoError(t, client.WriteFile(relPath, []byte("updated")))
+
+	//nolint:gosec
+	readData, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("updated"), readData)
+
+	//nolint:gosec
+	backupData, err := os.ReadFile(absPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), backupData)
+
+	require.NoError(t, client.WriteFromReader(relPath, strings.NewReader("streamed")))
+
+	//nolint:gosec
+	backupData, err = os.ReadFile(absPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("updated"), backupData)
+}
+
+func TestSecureWriteFileBackupKeepsTargetPresent(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("original"))
+
+	t.Cleanup(func() {
+		//nolint:errcheck
+		_ = os.Remove(absPath + ".bak")
+	})
+
+	client, err := NewWithOptions(WithWriteBackupSuffix(".bak"))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	missing := make(chan error, 1)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				close(missing)
+
+				return
+			default:
+			}
+
+			_, statErr := os.Stat(absPath)
+			if statErr != nil {
+				missing <- statErr
+				close(missing)
+
+				return
+			}
+		}
+	}()
+
+	for i := range 50 {
+		require.NoError(t, client.WriteFile(relPath, []byte("update-"+strconv.Itoa(i))))
+	}
+
+	close(done)
+
+	require.NoError(t, <-missing, "target must never be missing while a backup is taken")
+
+	//nolint:gosec
+	backupData, err := os.ReadFile(absPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("update-48"), backupData)
+}
+
+func TestSecureWriteFileBackupSuffixInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWithOptions(WithWriteBackupSuffix("/../escape"))
+	require.ErrorIs(t, err, ErrInvalidBackupSuffix)
+
+	_, err = NewWithOptions(WithWriteBackupSuffix(".bak"), WithWriteDisableAtomic(true))
+	require.ErrorIs(t, err, ErrInvalidBackupSuffix)
+}
+
+func TestSecureWriteFileBackupSymlinkRejected(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("original"))
+	outside := filepath.Join(t.TempDir(), "outside")
+	require.NoError(t, os.WriteFile(outside, []byte("outside"), 0o600))
+
+	err := os.Symlink(outside, absPath+".bak")
+	if err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck
+		_ = os.Remove(absPath + ".bak")
+	})
+
+	client, err := NewWithOptions(WithWriteBackupSuffix(".bak"))
+	require.NoError(t, err)
+
+	err = client.WriteFile(relPath, []byte("updated"))
+	require.ErrorIs(t, err, ErrSymlinkNotAllowed)
+
+	//nolint:gosec
+	readData, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), readData)
+}
+
 func TestSecureWriteFileAbsolutePathRejected(t *testing.T) {
 	t.Parallel()
 
@@ -400,6 +622,297 @@ func TestSecureReadFileWithOptionsDisallowPerms(t *testing.T) {
 	require.ErrorIs(t, err, ErrPermissionsNotAllowed)
 }
 
+func TestSecureCompareAndSwap(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("v1"))
+	current := sha256.Sum256([]byte("v1"))
+	stale := sha256.Sum256([]byte("v0"))
+
+	client := New()
+
+	swapped, err := client.CompareAndSwap(relPath, stale[:], []byte("lost"))
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	swapped, err = client.CompareAndSwap(relPath, current[:], []byte("v2"))
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	data, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), data)
+
+	swapped, err = client.CompareAndSwap(relPath, nil, []byte("create"))
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	_, err = client.CompareAndSwap(relPath, []byte("short"), []byte("v3"))
+	require.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestSecureCompareAndSwapConcurrentWriters(t *testing.T) {
+	t.Parallel()
+
+	const (
+		writers    = 8
+		increments = 20
+	)
+
+	absPath, relPath := createTempFile(t, []byte("0"))
+	client := New()
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, writers)
+
+	for range writers {
+		wg.Go(func() {
+			for done := 0; done < increments; {
+				//nolint:gosec
+				data, err := os.ReadFile(absPath)
+				if err != nil {
+					errs <- err
+
+					return
+				}
+
+				n, err := strconv.Atoi(string(data))
+				if err != nil {
+					errs <- err
+
+					return
+				}
+
+				digest := sha256.Sum256(data)
+
+				swapped, err := client.CompareAndSwap(relPath, digest[:], []byte(strconv.Itoa(n+1)))
+				if err != nil {
+					errs <- err
+
+					return
+				}
+
+				if swapped {
+					done++
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	//nolint:gosec
+	data, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(writers*increments), string(data), "no swap may be lost")
+}
+
+func TestSecureCompareAndSwapCreateConcurrent(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+	relPath := filepath.Join(dirRel, "config.json")
+	client := New()
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+	)
+
+	for i := range 8 {
+		wg.Go(func() {
+			swapped, err := client.CompareAndSwap(relPath, nil, []byte(strconv.Itoa(i)))
+			assert.NoError(t, err)
+
+			if swapped {
+				created.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), created.Load(), "exactly one creator must win")
+
+	entries, err := os.ReadDir(dirAbs)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		assert.False(t, strings.HasPrefix(entry.Name(), ".sectools-"), "temp file %s left behind", entry.Name())
+	}
+}
+
+func TestSecureCompareAndSwapCreate(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+	relPath := filepath.Join(dirRel, "config.json")
+	expected := sha256.Sum256([]byte("{}"))
+
+	client := New()
+
+	swapped, err := client.CompareAndSwap(relPath, expected[:], []byte("{}"))
+	require.NoError(t, err)
+	assert.False(t, swapped)
+
+	swapped, err = client.CompareAndSwap(relPath, nil, []byte("{}"))
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	data, err := os.ReadFile(filepath.Join(dirAbs, "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("{}"), data)
+}
+
+func TestSecureCreateFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("old"))
+
+	client := New()
+
+	writer, err := client.CreateFile(relPath)
+	require.NoError(t, err)
+
+	_, err = io.WriteString(writer, "new ")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("old"), data)
+
+	_, err = io.WriteString(writer, "contents")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, writer.Close())
+
+	data, err = os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new contents"), data)
+
+	_, err = writer.Write([]byte("late"))
+	require.ErrorIs(t, err, ErrWriterClosed)
+}
+
+func TestSecureCreateFileMaxSize(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+
+	for _, disableAtomic := range []bool{false, true} {
+		client, err := NewWithOptions(WithWriteMaxSize(4), WithWriteDisableAtomic(disableAtomic))
+		require.NoError(t, err)
+
+		name := "bounded.txt"
+		if disableAtomic {
+			name = "bounded-direct.txt"
+		}
+
+		writer, err := client.CreateFile(filepath.Join(dirRel, name))
+		require.NoError(t, err)
+
+		_, err = writer.Write([]byte("toolong"))
+		require.ErrorIs(t, err, ErrFileTooLarge)
+		require.ErrorIs(t, writer.Close(), ErrFileTooLarge)
+
+		entries, err := os.ReadDir(dirAbs)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	}
+}
+
+func TestSecureCreateFileDirect(t *testing.T) {
+	t.Parallel()
+
+	dirAbs, dirRel := createTempDir(t)
+	relPath := filepath.Join(dirRel, "stream.log")
+
+	client, err := NewWithOptions(WithWriteDisableAtomic(true), WithWriteFileMode(0o600))
+	require.NoError(t, err)
+
+	writer, err := client.CreateFile(relPath)
+	require.NoError(t, err)
+
+	_, err = io.WriteString(writer, "line\n")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dirAbs, "stream.log"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("line\n"), data)
+
+	require.NoError(t, writer.Close())
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(dirAbs, "stream.log"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	exclusive, err := NewWithOptions(WithWriteCreateExclusive(true))
+	require.NoError(t, err)
+
+	_, err = exclusive.CreateFile(relPath)
+	require.ErrorIs(t, err, ErrFileExists)
+}
+
+func TestSecureTruncateFile(t *testing.T) {
+	t.Parallel()
+
+	absPath, relPath := createTempFile(t, []byte("secret"))
+
+	before, err := os.Stat(absPath)
+	require.NoError(t, err)
+
+	client := New()
+	require.NoError(t, client.TruncateFile(relPath))
+
+	after, err := os.Stat(absPath)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(before, after))
+	assert.Equal(t, before.Mode(), after.Mode())
+	assert.Zero(t, after.Size())
+
+	err = client.TruncateFile(absPath)
+	require.Error(t, err)
+}
+
+func TestSecureTruncateFileRejectsNonRegular(t *testing.T) {
+	t.Parallel()
+
+	_, dirRel := createTempDir(t)
+
+	client := New()
+	err := client.TruncateFile(dirRel)
+	require.ErrorIs(t, err, ErrNonRegularFile)
+}
+
+func TestSecureTruncateFileSymlinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	targetAbs, _, linkRel := createTempSymlink(t, []byte("secret"))
+
+	client := New()
+	err := client.TruncateFile(linkRel)
+	require.ErrorIs(t, err, ErrSymlinkNotAllowed)
+
+	data, err := os.ReadFile(targetAbs)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), data)
+
+	client, err = NewWithOptions(WithAllowSymlinks(true))
+	require.NoError(t, err)
+	require.NoError(t, client.TruncateFile(linkRel))
+
+	info, err := os.Stat(targetAbs)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
 func createTempFile(t *testing.T, data []byte) (filename, relPath string) {
 	t.Helper()
 
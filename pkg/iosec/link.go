@@ -0,0 +1,23 @@
+package iosec
+
+import (
+	internalio "github.com/hyp3rd/sectools/internal/iosec"
+)
+
+// Readlink returns the target of a symlink securely, without following it.
+func (c *Client) Readlink(path string) (string, error) {
+	if c.log != nil {
+		c.log.WithField("path", path).Debug("Reading symlink securely")
+	}
+
+	return internalio.SecureReadlink(path, c.read, c.log)
+}
+
+// Realpath resolves every symlink in path and returns the result if it stays within the allowed roots.
+func (c *Client) Realpath(path string) (string, error) {
+	if c.log != nil {
+		c.log.WithField("path", path).Debug("Resolving path securely")
+	}
+
+	return internalio.SecureRealpath(path, c.read, c.log)
+}
@@ -0,0 +1,83 @@
+package iosec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureReadlinkAndRealpath(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "target.txt"), []byte("data"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0o600))
+
+	err := os.Symlink("target.txt", filepath.Join(root, "inside"))
+	if err != nil {
+		t.Skipf("symlink not supported: %v", err)
+	}
+
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(root, "escape")))
+
+	client, err := NewWithOptions(WithBaseDir(root))
+	require.NoError(t, err)
+
+	target, err := client.Readlink("inside")
+	require.NoError(t, err)
+	assert.Equal(t, "target.txt", target)
+
+	// Reading an escaping link is allowed; it is only inspected, not followed.
+	target, err = client.Readlink("escape")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outside, "secret.txt"), target)
+
+	_, err = client.Readlink("target.txt")
+	require.Error(t, err)
+
+	_, err = client.Readlink("../inside")
+	require.ErrorIs(t, err, ErrInvalidPath)
+
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+
+	realPath, err := client.Realpath("inside")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(resolvedRoot, "target.txt"), realPath)
+
+	_, err = client.Realpath("escape")
+	require.ErrorIs(t, err, ErrPathEscapesRoot)
+}
+
+func TestSecureReadlinkSymlinkParent(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, "real"), 0o700))
+
+	err := os.Symlink("target.txt", filepath.Join(root, "real", "link"))
+	if err != nil {
+		t.Skipf("symlink not supported: %v", err)
+	}
+
+	require.NoError(t, os.Symlink("real", filepath.Join(root, "alias")))
+
+	client, err := NewWithOptions(WithBaseDir(root))
+	require.NoError(t, err)
+
+	_, err = client.Readlink(filepath.Join("alias", "link"))
+	require.ErrorIs(t, err, ErrSymlinkNotAllowed)
+
+	client, err = NewWithOptions(WithBaseDir(root), WithAllowSymlinks(true))
+	require.NoError(t, err)
+
+	target, err := client.Readlink(filepath.Join("alias", "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "target.txt", target)
+}
@@ -112,6 +112,19 @@ func WithReadMaxSize(maxBytes int64) Option {
 	}
 }
 
+// WithReadMaxEntries caps the number of entries ReadDir and ReadDirIter accept from a directory.
+func WithReadMaxEntries(maxEntries int) Option {
+	return func(c *Client) error {
+		if maxEntries <= 0 {
+			return ErrMaxEntriesInvalid
+		}
+
+		c.read.MaxEntries = maxEntries
+
+		return nil
+	}
+}
+
 // WithReadAllowNonRegular configures non-regular read handling.
 func WithReadAllowNonRegular(allow bool) Option {
 	return func(c *Client) error {
@@ -197,6 +210,24 @@ func WithWriteEnforceFileMode(enable bool) Option {
 	}
 }
 
+// WithWritePreserveMode configures reuse of an existing target's file mode on overwrite.
+func WithWritePreserveMode(enable bool) Option {
+	return func(c *Client) error {
+		c.write.PreserveMode = enable
+
+		return nil
+	}
+}
+
+// WithWriteBackupSuffix configures backups of an existing target before atomic replacement.
+func WithWriteBackupSuffix(suffix string) Option {
+	return func(c *Client) error {
+		c.write.BackupSuffix = suffix
+
+		return nil
+	}
+}
+
 // WithDirMode configures the directory mode used for MkdirAll/TempDir.
 func WithDirMode(mode os.FileMode) Option {
 	return func(c *Client) error {
@@ -251,6 +282,25 @@ func WithRemoveWipe(enable bool) Option {
 	}
 }
 
+// WithRemoveWipePasses configures the number of overwrite passes used by WithRemoveWipe.
+// Zero keeps the default single pass.
+func WithRemoveWipePasses(passes int) Option {
+	return func(c *Client) error {
+		c.remove.WipePasses = passes
+
+		return nil
+	}
+}
+
+// WithRemoveWipeRandom configures random data for wipe passes after the first zero pass.
+func WithRemoveWipeRandom(enable bool) Option {
+	return func(c *Client) error {
+		c.remove.WipeRandom = enable
+
+		return nil
+	}
+}
+
 // WithCopyVerifyChecksum configures checksum verification for copy operations.
 func WithCopyVerifyChecksum(enable bool) Option {
 	return func(c *Client) error {
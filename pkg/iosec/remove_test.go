@@ -22,6 +22,32 @@ func TestSecureRemoveDefaultOptions(t *testing.T) {
 	require.True(t, os.IsNotExist(statErr))
 }
 
+func TestSecureRemoveWithWipePasses(t *testing.T) {
+	t.Parallel()
+
+	_, relPath := createTempFile(t, []byte("wipe-me-three-times"))
+
+	client, err := NewWithOptions(WithRemoveWipe(true), WithRemoveWipePasses(3), WithRemoveWipeRandom(true))
+	require.NoError(t, err)
+
+	err = client.Remove(relPath)
+	require.NoError(t, err)
+
+	_, statErr := os.Stat(filepath.Join(os.TempDir(), relPath))
+	require.Error(t, statErr)
+	require.True(t, os.IsNotExist(statErr))
+}
+
+func TestSecureRemoveWipePassesInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWithOptions(WithRemoveWipe(true), WithRemoveWipePasses(-1))
+	require.ErrorIs(t, err, ErrInvalidWipePasses)
+
+	_, err = NewWithOptions(WithRemoveWipe(true), WithRemoveWipePasses(36))
+	require.ErrorIs(t, err, ErrInvalidWipePasses)
+}
+
 func TestSecureRemoveAllDefaultOptions(t *testing.T) {
 	t.Parallel()
 
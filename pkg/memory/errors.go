@@ -11,4 +11,8 @@ var (
 	ErrBufferTooLarge = ewrap.New("data exceeds maximum size")
 	// ErrLockUnsupported indicates memory locking is not supported on this platform.
 	ErrLockUnsupported = ewrap.New("memory locking is not supported")
+	// ErrPoolSizeInvalid indicates a non-positive byte pool buffer size.
+	ErrPoolSizeInvalid = ewrap.New("pool buffer size must be positive")
+	// ErrSecureStringInvalid indicates SecureString JSON input that is not a valid JSON string.
+	ErrSecureStringInvalid = ewrap.New("secure string must be a JSON string")
 )
@@ -0,0 +1,63 @@
+package memory
+
+import "sync"
+
+// BytePool reuses fixed-size byte slices for short-lived secrets such as raw token bytes
+// or key material that is encoded and then discarded. Buffers are zeroed when they are
+// returned, so a Get never observes data from a previous use.
+// Do not use it for long-lived secrets (use SecureBuffer) or for slices handed to callers,
+// since returning a slice that is still referenced elsewhere wipes it under the other holder.
+// It is safe for concurrent use.
+type BytePool struct {
+	size int
+	pool sync.Pool
+}
+
+// NewBytePool creates a pool of buffers of size bytes.
+// A non-positive size returns ErrPoolSizeInvalid.
+func NewBytePool(size int) (*BytePool, error) {
+	if size <= 0 {
+		return nil, ErrPoolSizeInvalid
+	}
+
+	bp := &BytePool{size: size}
+	bp.pool.New = func() any {
+		buf := make([]byte, size)
+
+		return &buf
+	}
+
+	return bp, nil
+}
+
+// Size returns the length of buffers handed out by Get.
+func (bp *BytePool) Size() int {
+	return bp.size
+}
+
+// Get returns a zeroed buffer of Size bytes.
+func (bp *BytePool) Get() []byte {
+	buf, ok := bp.pool.Get().(*[]byte)
+	if !ok {
+		return make([]byte, bp.size)
+	}
+
+	return (*buf)[:bp.size]
+}
+
+// Put zeroes b and returns it to the pool. The caller must not use b afterwards.
+// Slices whose capacity differs from Size are zeroed but not pooled.
+func (bp *BytePool) Put(b []byte) {
+	if b == nil {
+		return
+	}
+
+	b = b[:cap(b)]
+	ZeroBytes(b)
+
+	if cap(b) != bp.size {
+		return
+	}
+
+	bp.pool.Put(&b)
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+)
+
+const testPoolSize = 32
+
+func TestBytePoolZeroesOnPut(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewBytePool(testPoolSize)
+	if err != nil {
+		t.Fatalf("expected pool, got %v", err)
+	}
+
+	buf := pool.Get()
+	if len(buf) != testPoolSize {
+		t.Fatalf("expected %d bytes, got %d", testPoolSize, len(buf))
+	}
+
+	for i := range buf {
+		buf[i] = 0xAA
+	}
+
+	pool.Put(buf)
+
+	for i, b := range buf {
+		if b != 0 {
+			t.Fatalf("expected byte %d zeroed on Put, got %x", i, b)
+		}
+	}
+
+	for range 10 {
+		next := pool.Get()
+		for i, b := range next {
+			if b != 0 {
+				t.Fatalf("expected zeroed buffer from Get, byte %d is %x", i, b)
+			}
+		}
+
+		pool.Put(next)
+	}
+
+	foreign := []byte("not pooled")
+	pool.Put(foreign)
+
+	for _, b := range foreign {
+		if b != 0 {
+			t.Fatal("expected foreign slice zeroed")
+		}
+	}
+
+	pool.Put(nil)
+}
+
+func TestBytePoolInvalidSize(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{0, -1} {
+		_, err := NewBytePool(size)
+		if !errors.Is(err, ErrPoolSizeInvalid) {
+			t.Fatalf("expected ErrPoolSizeInvalid for %d, got %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package memory
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	secureStringRedacted = "[REDACTED]"
+	jsonUnicodeEscapeLen = 4
+	jsonSurrogatePairLen = 6
+)
+
+// SecureString holds a secret, such as an API key in a config struct, in a SecureBuffer.
+// It marshals to JSON and formats with fmt as "[REDACTED]", so the value cannot leak through
+// logging or re-encoding; UnmarshalJSON decodes a JSON string directly into the buffer without
+// building a Go string. The buffer is locked against swapping on a best-effort basis.
+// The zero value is an empty secret.
+type SecureString struct {
+	buf *SecureBuffer
+}
+
+// NewSecureString copies value into a new SecureString. The caller keeps ownership of value.
+func NewSecureString(value []byte) *SecureString {
+	s := &SecureString{}
+	s.set(value)
+
+	return s
+}
+
+// Reveal returns a copy of the secret, or nil when it is empty or cleared.
+// The caller should zero the copy with ZeroBytes when done.
+func (s *SecureString) Reveal() []byte {
+	if s == nil || s.buf == nil {
+		return nil
+	}
+
+	return s.buf.BytesCopy()
+}
+
+// Len returns the length of the secret in bytes.
+func (s *SecureString) Len() int {
+	if s == nil || s.buf == nil {
+		return 0
+	}
+
+	return s.buf.Len()
+}
+
+// Clear wipes the secret. The SecureString is empty afterwards and may be reused.
+func (s *SecureString) Clear() {
+	if s == nil || s.buf == nil {
+		return
+	}
+
+	s.buf.Clear()
+	s.buf = nil
+}
+
+// String returns "[REDACTED]".
+func (SecureString) String() string {
+	return secureStringRedacted
+}
+
+// GoString returns "[REDACTED]" so %#v does not expose the buffer.
+func (SecureString) GoString() string {
+	return secureStringRedacted
+}
+
+// MarshalJSON always encodes the redaction placeholder, never the secret.
+func (SecureString) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + secureStringRedacted + `"`), nil
+}
+
+// UnmarshalJSON stores a JSON string in the buffer, replacing and wiping any previous value.
+// JSON null leaves the value unchanged; other JSON types return ErrSecureStringInvalid.
+func (s *SecureString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	value, err := decodeJSONString(data)
+	if err != nil {
+		return err
+	}
+
+	defer ZeroBytes(value)
+
+	s.Clear()
+	s.set(value)
+
+	return nil
+}
+
+func (s *SecureString) set(value []byte) {
+	s.buf = NewSecureBuffer(value)
+
+	// Locking is best-effort: it fails on unsupported platforms or when RLIMIT_MEMLOCK is reached.
+	_ = s.buf.Lock()
+}
+
+// decodeJSONString unquotes a JSON string literal into a new byte slice, zeroing it on error.
+// Unpaired surrogate escapes decode to U+FFFD, as in encoding/json.
+func decodeJSONString(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, ErrSecureStringInvalid
+	}
+
+	inner := data[1 : len(data)-1]
+	// Decoding never grows the input, so out is not reallocated and no unzeroed copy is left behind.
+	out := make([]byte, 0, len(inner))
+
+	for i := 0; i < len(inner); {
+		c := inner[i]
+
+		switch {
+		case c == '"' || c < ' ':
+			ZeroBytes(out)
+
+			return nil, ErrSecureStringInvalid
+		case c != '\\':
+			out = append(out, c)
+			i++
+
+			continue
+		}
+
+		if i+1 >= len(inner) {
+			ZeroBytes(out)
+
+			return nil, ErrSecureStringInvalid
+		}
+
+		decoded, consumed, ok := decodeJSONEscape(inner[i:])
+		if !ok {
+			ZeroBytes(out)
+
+			return nil, ErrSecureStringInvalid
+		}
+
+		out = utf8.AppendRune(out, decoded)
+		i += consumed
+	}
+
+	return out, nil
+}
+
+// decodeJSONEscape decodes the escape sequence at the start of seq, which begins with a backslash.
+// It returns the rune and the number of bytes consumed.
+func decodeJSONEscape(seq []byte) (rune, int, bool) {
+	switch seq[1] {
+	case '"', '\\', '/':
+		return rune(seq[1]), 2, true
+	case 'b':
+		return '\b', 2, true
+	case 'f':
+		return '\f', 2, true
+	case 'n':
+		return '\n', 2, true
+	case 'r':
+		return '\r', 2, true
+	case 't':
+		return '\t', 2, true
+	case 'u':
+	default:
+		return 0, 0, false
+	}
+
+	r, ok := decodeHexRune(seq[2:])
+	if !ok {
+		return 0, 0, false
+	}
+
+	consumed := 2 + jsonUnicodeEscapeLen
+	if !utf16.IsSurrogate(r) {
+		return r, consumed, true
+	}
+
+	rest := seq[consumed:]
+	if len(rest) >= jsonSurrogatePairLen && rest[0] == '\\' && rest[1] == 'u' {
+		low, ok := decodeHexRune(rest[2:])
+		if ok {
+			if pair := utf16.DecodeRune(r, low); pair != utf8.RuneError {
+				return pair, consumed + jsonSurrogatePairLen, true
+			}
+		}
+	}
+
+	return utf8.RuneError, consumed, true
+}
+
+func decodeHexRune(digits []byte) (rune, bool) {
+	if len(digits) < jsonUnicodeEscapeLen {
+		return 0, false
+	}
+
+	var r rune
+
+	for _, c := range digits[:jsonUnicodeEscapeLen] {
+		var v byte
+
+		switch {
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		case c >= 'a' && c <= 'f':
+			v = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			return 0, false
+		}
+
+		r = r<<4 | rune(v)
+	}
+
+	return r, true
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type secureStringConfig struct {
+	Name   string       `json:"name"`
+	APIKey SecureString `json:"api_key"`
+}
+
+func TestSecureStringJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var cfg secureStringConfig
+
+	err := json.Unmarshal([]byte(`{"name":"svc","api_key":"sk-é😀\n\"x\""}`), &cfg)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	revealed := cfg.APIKey.Reveal()
+	if want := []byte("sk-é😀\n\"x\""); !bytes.Equal(revealed, want) {
+		t.Fatalf("expected %q, got %q", want, revealed)
+	}
+
+	ZeroBytes(revealed)
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if string(out) != `{"name":"svc","api_key":"[REDACTED]"}` {
+		t.Fatalf("expected redacted output, got %s", out)
+	}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		formatted := fmt.Sprintf(format, cfg)
+		if bytes.Contains([]byte(formatted), []byte("sk-")) {
+			t.Fatalf("expected %s to redact the secret, got %s", format, formatted)
+		}
+	}
+
+	cfg.APIKey.Clear()
+
+	if cfg.APIKey.Len() != 0 || cfg.APIKey.Reveal() != nil {
+		t.Fatal("expected cleared secret")
+	}
+}
+
+func TestSecureStringUnmarshalInvalid(t *testing.T) {
+	t.Parallel()
+
+	secret := NewSecureString([]byte("keep"))
+
+	err := secret.UnmarshalJSON([]byte("null"))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if string(secret.Reveal()) != "keep" {
+		t.Fatal("expected null to leave the value unchanged")
+	}
+
+	for _, input := range []string{`123`, `"unterminated`, `"bad \q escape"`, `"\u12"`, "\"ctrl\x01\""} {
+		err := secret.UnmarshalJSON([]byte(input))
+		if !errors.Is(err, ErrSecureStringInvalid) {
+			t.Fatalf("expected ErrSecureStringInvalid for %q, got %v", input, err)
+		}
+	}
+
+	err = secret.UnmarshalJSON([]byte(`"\ud800x"`))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if string(secret.Reveal()) != "�x" {
+		t.Fatalf("expected replacement rune for unpaired surrogate, got %q", secret.Reveal())
+	}
+
+	secret.Clear()
+}
@@ -75,6 +75,7 @@ type BackupCodeManager struct {
 	alphabet []byte
 	allowed  [byteRange]bool
 	reader   io.Reader
+	locks    *backupUserLocks
 }
 
 // BackupOption configures backup code generation and verification.
@@ -89,6 +90,7 @@ type backupConfig struct {
 	hasherSet   bool
 	reader      io.Reader
 	rateLimiter RateLimiter
+	store       BackupCodeStore
 }
 
 // NewBackupCodeManager constructs a backup code manager with safe defaults.
@@ -132,6 +134,7 @@ func NewBackupCodeManager(opts ...BackupOption) (*BackupCodeManager, error) {
 		alphabet: []byte(normalizedAlphabet),
 		allowed:  allowed,
 		reader:   reader,
+		locks:    newBackupUserLocks(),
 	}, nil
 }
 
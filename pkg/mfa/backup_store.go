@@ -0,0 +1,145 @@
+package mfa
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// BackupCodeStore persists backup code hashes per user.
+// Load returns nil hashes for unknown users. Save replaces the stored set.
+type BackupCodeStore interface {
+	Load(ctx context.Context, userID string) ([]string, error)
+	Save(ctx context.Context, userID string, remaining []string) error
+}
+
+// WithBackupCodeStore sets the store used by VerifyWithStore.
+func WithBackupCodeStore(store BackupCodeStore) BackupOption {
+	return func(cfg *backupConfig) error {
+		if store == nil {
+			return ErrInvalidMFAConfig
+		}
+
+		cfg.store = store
+
+		return nil
+	}
+}
+
+// VerifyWithStore loads the user's hashes, verifies the code, and saves the reduced set on a match.
+// Calls for the same user are serialized within the manager so a code can only be consumed once;
+// stores shared across processes should make Save transactional to keep that guarantee.
+func (m *BackupCodeManager) VerifyWithStore(ctx context.Context, userID, code string) (bool, error) {
+	if m.cfg.store == nil {
+		return false, ErrMFABackupStoreMissing
+	}
+
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return false, ErrMFAMissingUserID
+	}
+
+	unlock := m.locks.lock(userID)
+	defer unlock()
+
+	hashes, err := m.cfg.store.Load(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf(mfaWrapFormat, ErrMFABackupStoreFailed, err)
+	}
+
+	ok, remaining, err := m.Verify(code, hashes)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	err = m.cfg.store.Save(ctx, userID, remaining)
+	if err != nil {
+		return false, fmt.Errorf(mfaWrapFormat, ErrMFABackupStoreFailed, err)
+	}
+
+	return true, nil
+}
+
+// MemoryBackupCodeStore is an in-memory BackupCodeStore intended for tests and single-process use.
+type MemoryBackupCodeStore struct {
+	mu     sync.Mutex
+	hashes map[string][]string
+}
+
+// NewMemoryBackupCodeStore constructs an empty in-memory backup code store.
+func NewMemoryBackupCodeStore() *MemoryBackupCodeStore {
+	return &MemoryBackupCodeStore{hashes: make(map[string][]string)}
+}
+
+// Load returns a copy of the stored hashes for the user.
+func (s *MemoryBackupCodeStore) Load(ctx context.Context, userID string) ([]string, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.hashes[userID]), nil
+}
+
+// Save replaces the stored hashes for the user with a copy of remaining.
+func (s *MemoryBackupCodeStore) Save(ctx context.Context, userID string, remaining []string) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes[userID] = slices.Clone(remaining)
+
+	return nil
+}
+
+type backupUserLocks struct {
+	mu    sync.Mutex
+	locks map[string]*backupUserLock
+}
+
+type backupUserLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newBackupUserLocks() *backupUserLocks {
+	return &backupUserLocks{locks: make(map[string]*backupUserLock)}
+}
+
+// lock acquires the per-user lock and returns its release function.
+func (l *backupUserLocks) lock(userID string) func() {
+	l.mu.Lock()
+
+	entry, ok := l.locks[userID]
+	if !ok {
+		entry = &backupUserLock{}
+		l.locks[userID] = entry
+	}
+
+	entry.refs++
+	l.mu.Unlock()
+
+	entry.mu.Lock()
+
+	return func() {
+		entry.mu.Unlock()
+
+		l.mu.Lock()
+
+		entry.refs--
+		if entry.refs == 0 {
+			delete(l.locks, userID)
+		}
+
+		l.mu.Unlock()
+	}
+}
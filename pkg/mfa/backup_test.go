@@ -1,8 +1,11 @@
 package mfa
 
 import (
+	"context"
 	"errors"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hyp3rd/sectools/pkg/password"
@@ -190,3 +193,100 @@ func TestBackupCodeInvalidOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupCodeVerifyWithStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryBackupCodeStore()
+
+	manager, err := NewBackupCodeManager(
+		WithBackupCodeCount(backupCodeCount),
+		WithBackupHasherBcrypt(password.BcryptInteractiveCost),
+		WithBackupCodeStore(store),
+	)
+	if err != nil {
+		t.Fatalf("expected manager, got %v", err)
+	}
+
+	set, err := manager.Generate()
+	if err != nil {
+		t.Fatalf("expected codes, got %v", err)
+	}
+
+	ctx := context.Background()
+
+	err = store.Save(ctx, "user-1", set.Hashes)
+	if err != nil {
+		t.Fatalf("expected save, got %v", err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		accepted atomic.Int32
+	)
+
+	for range 4 {
+		wg.Go(func() {
+			ok, err := manager.VerifyWithStore(ctx, "user-1", set.Codes[0])
+			if err != nil {
+				t.Errorf("expected verify, got %v", err)
+
+				return
+			}
+
+			if ok {
+				accepted.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if accepted.Load() != 1 {
+		t.Fatalf("expected exactly one acceptance, got %d", accepted.Load())
+	}
+
+	remaining, err := store.Load(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("expected load, got %v", err)
+	}
+
+	if len(remaining) != backupCodeCount-1 {
+		t.Fatalf("expected %d remaining hashes, got %d", backupCodeCount-1, len(remaining))
+	}
+}
+
+func TestBackupCodeVerifyWithStoreErrors(t *testing.T) {
+	t.Parallel()
+
+	manager, err := NewBackupCodeManager(WithBackupHasherBcrypt(password.BcryptInteractiveCost))
+	if err != nil {
+		t.Fatalf("expected manager, got %v", err)
+	}
+
+	_, err = manager.VerifyWithStore(context.Background(), "user-1", "ABCD-EFGH-JKLM")
+	if !errors.Is(err, ErrMFABackupStoreMissing) {
+		t.Fatalf("expected ErrMFABackupStoreMissing, got %v", err)
+	}
+
+	manager, err = NewBackupCodeManager(
+		WithBackupHasherBcrypt(password.BcryptInteractiveCost),
+		WithBackupCodeStore(NewMemoryBackupCodeStore()),
+	)
+	if err != nil {
+		t.Fatalf("expected manager, got %v", err)
+	}
+
+	_, err = manager.VerifyWithStore(context.Background(), " ", "ABCD-EFGH-JKLM")
+	if !errors.Is(err, ErrMFAMissingUserID) {
+		t.Fatalf("expected ErrMFAMissingUserID, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = manager.VerifyWithStore(ctx, "user-1", "ABCD-EFGH-JKLM")
+	if !errors.Is(err, ErrMFABackupStoreFailed) {
+		t.Fatalf("expected ErrMFABackupStoreFailed, got %v", err)
+	}
+}
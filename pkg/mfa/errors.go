@@ -17,16 +17,26 @@ var (
 	ErrMFASecretTooShort = ewrap.New("mfa secret is too short")
 	// ErrMFASecretTooLong indicates the secret is too long.
 	ErrMFASecretTooLong = ewrap.New("mfa secret is too long")
+	// ErrMFASecretGenerationFailed indicates secret generation failed.
+	ErrMFASecretGenerationFailed = ewrap.New("mfa secret generation failed")
 	// ErrMFAInvalidCode indicates the otp code is invalid.
 	ErrMFAInvalidCode = ewrap.New("mfa otp code is invalid")
 	// ErrMFARateLimited indicates an MFA verification was rate limited.
 	ErrMFARateLimited = ewrap.New("mfa rate limit exceeded")
+	// ErrMFARateLimiterMissing indicates VerifyForKey was called without a keyed rate limiter.
+	ErrMFARateLimiterMissing = ewrap.New("mfa keyed rate limiter is required")
 	// ErrMFABackupGenerationFailed indicates backup code generation failed.
 	ErrMFABackupGenerationFailed = ewrap.New("mfa backup code generation failed")
 	// ErrMFABackupHashFailed indicates backup code hashing failed.
 	ErrMFABackupHashFailed = ewrap.New("mfa backup code hashing failed")
 	// ErrMFABackupVerificationFailed indicates backup code verification failed.
 	ErrMFABackupVerificationFailed = ewrap.New("mfa backup code verification failed")
+	// ErrMFABackupStoreMissing indicates no backup code store was configured.
+	ErrMFABackupStoreMissing = ewrap.New("mfa backup code store is required")
+	// ErrMFABackupStoreFailed indicates the backup code store failed to load or save.
+	ErrMFABackupStoreFailed = ewrap.New("mfa backup code store failed")
+	// ErrMFAMissingUserID indicates the user id is required.
+	ErrMFAMissingUserID = ewrap.New("mfa user id is required")
 	// ErrMFAInvalidCounter indicates the hotp counter is invalid.
 	ErrMFAInvalidCounter = ewrap.New("mfa counter is invalid")
 )
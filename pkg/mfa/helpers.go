@@ -148,3 +148,20 @@ func checkRateLimiter(limiter RateLimiter) error {
 
 	return nil
 }
+
+func checkKeyedRateLimiter(limiter RateLimiterKeyed, key string) error {
+	if limiter == nil {
+		return ErrMFARateLimiterMissing
+	}
+
+	if strings.TrimSpace(key) == "" {
+		return ErrMFAMissingUserID
+	}
+
+	err := limiter.AllowN(key, 1)
+	if err != nil {
+		return fmt.Errorf(mfaWrapFormat, ErrMFARateLimited, err)
+	}
+
+	return nil
+}
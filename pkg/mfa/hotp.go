@@ -27,7 +27,9 @@ type hotpConfig struct {
 	lookAhead      uint
 	resyncWindow   uint
 	minSecretBytes int
+	rejectWeak     bool
 	rateLimiter    RateLimiter
+	keyedLimiter   RateLimiterKeyed
 }
 
 // NewHOTP constructs an HOTP helper using the provided base32 secret.
@@ -55,6 +57,13 @@ func NewHOTP(secret string, opts ...HOTPOption) (*HOTP, error) {
 		return nil, err
 	}
 
+	if cfg.rejectWeak {
+		err = checkWeakSecret(normalized)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &HOTP{
 		secret: normalized,
 		opts:   cfg,
@@ -69,33 +78,54 @@ func (h *HOTP) Generate(counter uint64) (string, error) {
 // Verify checks whether the supplied HOTP code is valid for the counter window.
 // On success, it returns true and the next counter to persist.
 func (h *HOTP) Verify(code string, counter uint64) (bool, uint64, error) {
-	err := checkRateLimiter(h.opts.rateLimiter)
+	ok, next, _, err := h.VerifyWithGap(code, counter)
+
+	return ok, next, err
+}
+
+// VerifyForKey checks an HOTP code like Verify after consulting the keyed rate limiter for key,
+// typically the account id. The keyless rate limiter still applies when configured.
+// Without WithHOTPKeyedRateLimiter it returns ErrMFARateLimiterMissing and does not verify.
+func (h *HOTP) VerifyForKey(key, code string, counter uint64) (bool, uint64, error) {
+	err := checkKeyedRateLimiter(h.opts.keyedLimiter, key)
 	if err != nil {
 		return false, counter, err
 	}
 
+	return h.Verify(code, counter)
+}
+
+// VerifyWithGap checks an HOTP code like Verify and also returns the gap, the number of
+// counters skipped before the match within the look-ahead window. A gap of zero means the
+// code matched the expected counter; use larger gaps to flag suspicious jumps.
+func (h *HOTP) VerifyWithGap(code string, counter uint64) (bool, uint64, uint64, error) {
+	err := checkRateLimiter(h.opts.rateLimiter)
+	if err != nil {
+		return false, counter, 0, err
+	}
+
 	normalized, err := normalizeCode(code, h.opts.digits)
 	if err != nil {
-		return false, counter, err
+		return false, counter, 0, err
 	}
 
 	maxOffset := uint64(h.opts.lookAhead)
 	for offset := zeroUint64; offset <= maxOffset; offset++ {
 		if counter > math.MaxUint64-offset {
-			return false, counter, ErrMFAInvalidCounter
+			return false, counter, 0, ErrMFAInvalidCounter
 		}
 
 		candidate, err := h.generateCode(counter + offset)
 		if err != nil {
-			return false, counter, err
+			return false, counter, 0, err
 		}
 
 		if constantTimeEquals(normalized, candidate) {
-			return true, counter + offset + counterIncrement, nil
+			return true, counter + offset + counterIncrement, offset, nil
 		}
 	}
 
-	return false, counter, nil
+	return false, counter, 0, nil
 }
 
 // Resync verifies two consecutive HOTP codes to recover a drifting counter.
@@ -250,6 +280,17 @@ func WithHOTPSecretMinBytes(minBytes int) HOTPOption {
 	}
 }
 
+// WithHOTPRejectWeakSecret rejects secrets that look like placeholders rather than random keys
+// with ErrInvalidMFAConfig: one byte or short block repeated, constant-step sequences, and
+// keys with very low byte entropy. Randomly generated secrets are not affected.
+func WithHOTPRejectWeakSecret() HOTPOption {
+	return func(cfg *hotpConfig) error {
+		cfg.rejectWeak = true
+
+		return nil
+	}
+}
+
 // WithHOTPRateLimiter sets a rate limiter for HOTP verification.
 func WithHOTPRateLimiter(limiter RateLimiter) HOTPOption {
 	return func(cfg *hotpConfig) error {
@@ -263,6 +304,19 @@ func WithHOTPRateLimiter(limiter RateLimiter) HOTPOption {
 	}
 }
 
+// WithHOTPKeyedRateLimiter sets a per-key rate limiter consulted by VerifyForKey.
+func WithHOTPKeyedRateLimiter(limiter RateLimiterKeyed) HOTPOption {
+	return func(cfg *hotpConfig) error {
+		if limiter == nil {
+			return ErrInvalidMFAConfig
+		}
+
+		cfg.keyedLimiter = limiter
+
+		return nil
+	}
+}
+
 func defaultHOTPConfig() hotpConfig {
 	return hotpConfig{
 		digits:         DigitsSix,
@@ -53,6 +53,38 @@ func TestHOTPGenerateAndVerify(t *testing.T) {
 	}
 }
 
+func TestHOTPVerifyWithGap(t *testing.T) {
+	t.Parallel()
+
+	helper, err := NewHOTP(hotpTestSecret)
+	if err != nil {
+		t.Fatalf(errExpectedHelper, err)
+	}
+
+	code, err := helper.Generate(counter + 2)
+	if err != nil {
+		t.Fatalf(errExpectedCode, err)
+	}
+
+	ok, next, gap, err := helper.VerifyWithGap(code, counter)
+	if err != nil || !ok {
+		t.Fatalf("expected valid code within window, got %v (%v)", ok, err)
+	}
+
+	if gap != 2 || next != counter+3 {
+		t.Fatalf("expected gap 2 and next %d, got gap %d and next %d", counter+3, gap, next)
+	}
+
+	ok, next, gap, err = helper.VerifyWithGap("000000", counter)
+	if err != nil {
+		t.Fatalf("expected mismatch without error, got %v", err)
+	}
+
+	if ok || next != counter || gap != 0 {
+		t.Fatalf("expected no match with unchanged counter, got ok=%v next=%d gap=%d", ok, next, gap)
+	}
+}
+
 func TestHOTPVerifyWindow(t *testing.T) {
 	t.Parallel()
 
@@ -163,6 +195,25 @@ func TestHOTPSecretTooShort(t *testing.T) {
 	}
 }
 
+func TestHOTPRejectWeakSecret(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewHOTP(strings.Repeat("A", 32), WithHOTPRejectWeakSecret())
+	if !errors.Is(err, ErrInvalidMFAConfig) {
+		t.Fatalf("expected ErrInvalidMFAConfig, got %v", err)
+	}
+
+	secret, err := GenerateSecret(mfaDefaultSecretSize)
+	if err != nil {
+		t.Fatalf("expected secret, got %v", err)
+	}
+
+	_, err = NewHOTP(secret, WithHOTPRejectWeakSecret())
+	if err != nil {
+		t.Fatalf(errExpectedHelper, err)
+	}
+}
+
 func TestHOTPInvalidOptions(t *testing.T) {
 	t.Parallel()
 
@@ -104,3 +104,95 @@ func TestRateLimiterErrorWraps(t *testing.T) {
 		t.Fatalf(errMsgExpectedErrMFARateLimited, err)
 	}
 }
+
+type testKeyedRateLimiter struct {
+	blocked map[string]bool
+	calls   map[string]int
+}
+
+func (t *testKeyedRateLimiter) AllowN(key string, n int) error {
+	t.calls[key] += n
+
+	if t.blocked[key] {
+		return errors.New("too many attempts")
+	}
+
+	return nil
+}
+
+func TestVerifyForKeyRateLimited(t *testing.T) {
+	t.Parallel()
+
+	limiter := &testKeyedRateLimiter{
+		blocked: map[string]bool{"blocked-user": true},
+		calls:   map[string]int{},
+	}
+
+	totpHelper, err := NewTOTP(totpTestSecret, WithTOTPKeyedRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf(errMsgExpectedTOTPHelper, err)
+	}
+
+	_, err = totpHelper.VerifyForKey("blocked-user", "123456")
+	if !errors.Is(err, ErrMFARateLimited) {
+		t.Fatalf(errMsgExpectedErrMFARateLimited, err)
+	}
+
+	_, err = totpHelper.VerifyForKey("other-user", "123456")
+	if err != nil {
+		t.Fatalf("expected other key to be allowed, got %v", err)
+	}
+
+	_, err = totpHelper.VerifyForKey(" ", "123456")
+	if !errors.Is(err, ErrMFAMissingUserID) {
+		t.Fatalf("expected ErrMFAMissingUserID, got %v", err)
+	}
+
+	hotpHelper, err := NewHOTP(hotpTestSecret, WithHOTPKeyedRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf(errExpectedHelper, err)
+	}
+
+	_, next, err := hotpHelper.VerifyForKey("blocked-user", "123456", 7)
+	if !errors.Is(err, ErrMFARateLimited) || next != 7 {
+		t.Fatalf("expected ErrMFARateLimited with unchanged counter, got %v %d", err, next)
+	}
+
+	if limiter.calls["blocked-user"] != 2 || limiter.calls["other-user"] != 1 {
+		t.Fatalf("unexpected limiter calls %v", limiter.calls)
+	}
+
+	_, err = NewTOTP(totpTestSecret, WithTOTPKeyedRateLimiter(nil))
+	if !errors.Is(err, ErrInvalidMFAConfig) {
+		t.Fatalf("expected ErrInvalidMFAConfig, got %v", err)
+	}
+}
+
+func TestVerifyForKeyRequiresKeyedLimiter(t *testing.T) {
+	t.Parallel()
+
+	totpHelper, err := NewTOTP(totpTestSecret)
+	if err != nil {
+		t.Fatalf(errMsgExpectedTOTPHelper, err)
+	}
+
+	code, err := totpHelper.Generate()
+	if err != nil {
+		t.Fatalf("expected code, got %v", err)
+	}
+
+	ok, err := totpHelper.VerifyForKey("user", code)
+	if ok || !errors.Is(err, ErrMFARateLimiterMissing) {
+		t.Fatalf("expected ErrMFARateLimiterMissing, got %v %v", ok, err)
+	}
+
+	hotpHelper, err := NewHOTP(hotpTestSecret)
+	if err != nil {
+		t.Fatalf(errExpectedHelper, err)
+	}
+
+	_, next, err := hotpHelper.VerifyForKey("user", "123456", 7)
+	if !errors.Is(err, ErrMFARateLimiterMissing) || next != 7 {
+		t.Fatalf("expected ErrMFARateLimiterMissing with unchanged counter, got %v %d", err, next)
+	}
+}
@@ -0,0 +1,134 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+// GenerateSecret returns a base32 (unpadded) secret of size random bytes read from crypto/rand.
+// The size must be between 10 and 64 bytes; 20 bytes matches GenerateTOTPKey.
+func GenerateSecret(size int) (string, error) {
+	return GenerateSecretFrom(rand.Reader, size)
+}
+
+// GenerateSecretFrom is like GenerateSecret but reads the secret bytes from r.
+// It is intended for deterministic tests; production code should use GenerateSecret.
+func GenerateSecretFrom(r io.Reader, size int) (string, error) {
+	if r == nil || size < mfaAbsoluteMinSecret || size > mfaMaxSecret {
+		return "", ErrInvalidMFAConfig
+	}
+
+	raw := make([]byte, size)
+	defer memory.ZeroBytes(raw)
+
+	_, err := io.ReadFull(r, raw)
+	if err != nil {
+		return "", fmt.Errorf(mfaWrapFormat, ErrMFASecretGenerationFailed, err)
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw), nil
+}
+
+// ValidateSecret checks a user-supplied base32 secret with the same rules NewTOTP and NewHOTP apply:
+// surrounding whitespace, inner spaces and dashes, lowercase letters, and padding are accepted.
+// It returns ErrMFAInvalidSecret for malformed base32, ErrMFASecretTooShort when it decodes to fewer
+// than minBytes, ErrMFASecretTooLong above 64 bytes, and ErrInvalidMFAConfig when minBytes is outside 10–64.
+func ValidateSecret(secret string, minBytes int) error {
+	_, err := normalizeSecret(secret, minBytes)
+
+	return err
+}
+
+// checkWeakSecret decodes a normalized base32 secret and returns ErrInvalidMFAConfig when it is weak.
+func checkWeakSecret(normalized string) error {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil {
+		return ErrMFAInvalidSecret
+	}
+
+	defer memory.ZeroBytes(key)
+
+	if isWeakSecret(key) {
+		return ErrInvalidMFAConfig
+	}
+
+	return nil
+}
+
+// isWeakSecret reports placeholder-like keys: a short block repeated (including a single
+// repeated byte), a constant-step sequence such as 0x00 0x01 0x02, or byte entropy below half
+// of what the key length allows. Random keys of 10 bytes or more fail these checks with
+// negligible probability.
+func isWeakSecret(key []byte) bool {
+	if len(key) < 2 {
+		return true
+	}
+
+	return hasRepeatingBlock(key) || isConstantStep(key) || byteEntropy(key) < maxByteEntropy(len(key))/2
+}
+
+// hasRepeatingBlock reports whether key is a prefix of at most half its length repeated.
+func hasRepeatingBlock(key []byte) bool {
+	for period := 1; period <= len(key)/2; period++ {
+		repeats := true
+
+		for i := period; i < len(key); i++ {
+			if key[i] != key[i-period] {
+				repeats = false
+
+				break
+			}
+		}
+
+		if repeats {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isConstantStep(key []byte) bool {
+	step := key[1] - key[0]
+
+	for i := 2; i < len(key); i++ {
+		if key[i]-key[i-1] != step {
+			return false
+		}
+	}
+
+	return true
+}
+
+// byteEntropy returns the Shannon entropy of key in bits per byte.
+func byteEntropy(key []byte) float64 {
+	var counts [256]int
+
+	for _, b := range key {
+		counts[b]++
+	}
+
+	entropy := 0.0
+	total := float64(len(key))
+
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		p := float64(count) / total
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+// maxByteEntropy is the entropy of n distinct bytes, capped at 8 bits.
+func maxByteEntropy(n int) float64 {
+	return math.Log2(float64(min(n, 256)))
+}
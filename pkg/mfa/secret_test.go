@@ -0,0 +1,84 @@
+package mfa
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateSecret(mfaDefaultSecretSize)
+	if err != nil {
+		t.Fatalf("expected secret, got %v", err)
+	}
+
+	if len(secret) != 32 || strings.Contains(secret, secretPaddingCharacter) {
+		t.Fatalf("expected 32 unpadded base32 characters, got %q", secret)
+	}
+
+	_, err = NewTOTP(secret)
+	if err != nil {
+		t.Fatalf("expected generated secret to be usable, got %v", err)
+	}
+
+	for _, size := range []int{mfaAbsoluteMinSecret - 1, mfaMaxSecret + 1} {
+		_, err = GenerateSecret(size)
+		if !errors.Is(err, ErrInvalidMFAConfig) {
+			t.Fatalf("expected ErrInvalidMFAConfig for size %d, got %v", size, err)
+		}
+	}
+}
+
+func TestGenerateSecretFrom(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateSecretFrom(bytes.NewReader([]byte("12345678901234567890")), mfaDefaultSecretSize)
+	if err != nil {
+		t.Fatalf("expected secret, got %v", err)
+	}
+
+	if secret != "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" {
+		t.Fatalf("unexpected deterministic secret %q", secret)
+	}
+
+	_, err = GenerateSecretFrom(bytes.NewReader([]byte("short")), mfaDefaultSecretSize)
+	if !errors.Is(err, ErrMFASecretGenerationFailed) {
+		t.Fatalf("expected ErrMFASecretGenerationFailed, got %v", err)
+	}
+
+	_, err = GenerateSecretFrom(nil, mfaDefaultSecretSize)
+	if !errors.Is(err, ErrInvalidMFAConfig) {
+		t.Fatalf("expected ErrInvalidMFAConfig, got %v", err)
+	}
+}
+
+func TestValidateSecret(t *testing.T) {
+	t.Parallel()
+
+	err := ValidateSecret(" "+strings.ToLower(totpTestSecret)+"== ", mfaDefaultMinSecret)
+	if err != nil {
+		t.Fatalf("expected valid secret, got %v", err)
+	}
+
+	tests := []struct {
+		secret   string
+		minBytes int
+		want     error
+	}{
+		{secret: "not base32!", minBytes: mfaDefaultMinSecret, want: ErrMFAInvalidSecret},
+		{secret: "   ", minBytes: mfaDefaultMinSecret, want: ErrMFAInvalidSecret},
+		{secret: "GEZDGNBVGY3TQOJQ", minBytes: mfaDefaultMinSecret, want: ErrMFASecretTooShort},
+		{secret: strings.Repeat("A", 120), minBytes: mfaDefaultMinSecret, want: ErrMFASecretTooLong},
+		{secret: totpTestSecret, minBytes: mfaAbsoluteMinSecret - 1, want: ErrInvalidMFAConfig},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSecret(tt.secret, tt.minBytes)
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("expected %v for %q, got %v", tt.want, tt.secret, err)
+		}
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"github.com/pquerna/otp/totp"
 
 	"github.com/hyp3rd/sectools/pkg/converters"
+	"github.com/hyp3rd/sectools/pkg/memory"
 )
 
 // TOTP generates and verifies time-based one-time passwords.
 // Instances of TOTP contain immutable configuration and can be used concurrently.
 type TOTP struct {
 	secret string
+	key    *memory.SecureBuffer
 	opts   totpConfig
 }
 
@@ -28,8 +30,11 @@ type totpConfig struct {
 	period         time.Duration
 	skew           uint
 	minSecretBytes int
+	rejectWeak     bool
 	clock          func() time.Time
 	rateLimiter    RateLimiter
+	keyedLimiter   RateLimiterKeyed
+	steamGuard     bool
 }
 
 // NewTOTP constructs a TOTP helper using the provided base32 secret.
@@ -57,6 +62,13 @@ func NewTOTP(secret string, opts ...TOTPOption) (*TOTP, error) {
 		return nil, err
 	}
 
+	if cfg.rejectWeak {
+		err = checkWeakSecret(normalized)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &TOTP{
 		secret: normalized,
 		opts:   cfg,
@@ -75,18 +87,54 @@ func (t *TOTP) Verify(code string) (bool, error) {
 	return ok, err
 }
 
+// VerifyForKey checks a TOTP code like Verify after consulting the keyed rate limiter for key,
+// typically the account id. The keyless rate limiter still applies when configured.
+// Without WithTOTPKeyedRateLimiter it returns ErrMFARateLimiterMissing and does not verify.
+func (t *TOTP) VerifyForKey(key, code string) (bool, error) {
+	err := checkKeyedRateLimiter(t.opts.keyedLimiter, key)
+	if err != nil {
+		return false, err
+	}
+
+	return t.Verify(code)
+}
+
 // VerifyWithStep checks a TOTP code and returns the matched time step.
 // Use the returned step to prevent replays by rejecting codes <= the last accepted step.
 func (t *TOTP) VerifyWithStep(code string) (bool, uint64, error) {
 	return t.verifyAtWithStep(code, t.opts.clock())
 }
 
+// GenerateAt returns the TOTP code for the supplied time instead of the configured clock.
+func (t *TOTP) GenerateAt(now time.Time) (string, error) {
+	return t.generateAt(now)
+}
+
+// VerifyAt checks a TOTP code against the supplied time and returns the matched time step.
+// It applies the same skew window and rate limiter as Verify, independent of the configured clock.
+func (t *TOTP) VerifyAt(code string, now time.Time) (bool, uint64, error) {
+	return t.verifyAtWithStep(code, now)
+}
+
 func (t *TOTP) generateAt(now time.Time) (string, error) {
 	opts, err := t.validateOpts()
 	if err != nil {
 		return "", err
 	}
 
+	if t.opts.steamGuard || t.key != nil {
+		baseCounter, ok, err := t.baseCounter(now)
+		if err != nil {
+			return "", err
+		}
+
+		if !ok {
+			return "", ErrInvalidMFAConfig
+		}
+
+		return t.codeAtCounter(uint64(baseCounter), hotp.ValidateOpts{Digits: opts.Digits, Algorithm: opts.Algorithm})
+	}
+
 	code, err := totp.GenerateCodeCustom(t.secret, now, opts)
 	if err != nil {
 		return "", fmt.Errorf(mfaWrapFormat, ErrInvalidMFAConfig, err)
@@ -101,7 +149,7 @@ func (t *TOTP) verifyAtWithStep(code string, now time.Time) (bool, uint64, error
 		return false, 0, err
 	}
 
-	normalized, err := normalizeCode(code, t.opts.digits)
+	normalized, err := t.normalizeCode(code)
 	if err != nil {
 		return false, 0, err
 	}
@@ -173,9 +221,9 @@ func (t *TOTP) verifyAtCounter(normalized string, counter int64, opts hotp.Valid
 		return false, 0, nil
 	}
 
-	codeCandidate, err := hotp.GenerateCodeCustom(t.secret, uint64(counter), opts)
+	codeCandidate, err := t.codeAtCounter(uint64(counter), opts)
 	if err != nil {
-		return false, 0, fmt.Errorf(mfaWrapFormat, ErrInvalidMFAConfig, err)
+		return false, 0, err
 	}
 
 	if constantTimeEquals(normalized, codeCandidate) {
@@ -185,6 +233,31 @@ func (t *TOTP) verifyAtCounter(normalized string, counter int64, opts hotp.Valid
 	return false, 0, nil
 }
 
+func (t *TOTP) codeAtCounter(counter uint64, opts hotp.ValidateOpts) (string, error) {
+	if t.key != nil {
+		return t.keyCodeAtCounter(counter, opts.Digits, opts.Algorithm)
+	}
+
+	if t.opts.steamGuard {
+		return steamGuardCode(t.secret, counter)
+	}
+
+	code, err := hotp.GenerateCodeCustom(t.secret, counter, opts)
+	if err != nil {
+		return "", fmt.Errorf(mfaWrapFormat, ErrInvalidMFAConfig, err)
+	}
+
+	return code, nil
+}
+
+func (t *TOTP) normalizeCode(code string) (string, error) {
+	if t.opts.steamGuard {
+		return normalizeSteamGuardCode(code)
+	}
+
+	return normalizeCode(code, t.opts.digits)
+}
+
 func (t *TOTP) validateOpts() (totp.ValidateOpts, error) {
 	periodSeconds, err := converters.SafeUintFromInt64(int64(t.opts.period / time.Second))
 	if err != nil {
@@ -264,6 +337,17 @@ func WithTOTPSecretMinBytes(minBytes int) TOTPOption {
 	}
 }
 
+// WithTOTPRejectWeakSecret rejects secrets that look like placeholders rather than random keys
+// with ErrInvalidMFAConfig: one byte or short block repeated, constant-step sequences, and
+// keys with very low byte entropy. Randomly generated secrets are not affected.
+func WithTOTPRejectWeakSecret() TOTPOption {
+	return func(cfg *totpConfig) error {
+		cfg.rejectWeak = true
+
+		return nil
+	}
+}
+
 // WithTOTPClock sets the clock used for code generation and verification.
 func WithTOTPClock(clock func() time.Time) TOTPOption {
 	return func(cfg *totpConfig) error {
@@ -290,6 +374,19 @@ func WithTOTPRateLimiter(limiter RateLimiter) TOTPOption {
 	}
 }
 
+// WithTOTPKeyedRateLimiter sets a per-key rate limiter consulted by VerifyForKey.
+func WithTOTPKeyedRateLimiter(limiter RateLimiterKeyed) TOTPOption {
+	return func(cfg *totpConfig) error {
+		if limiter == nil {
+			return ErrInvalidMFAConfig
+		}
+
+		cfg.keyedLimiter = limiter
+
+		return nil
+	}
+}
+
 func defaultTOTPConfig() totpConfig {
 	return totpConfig{
 		digits:         DigitsSix,
@@ -322,6 +419,10 @@ func validateTOTPConfig(cfg totpConfig) error {
 		return ErrInvalidMFAConfig
 	}
 
+	if cfg.steamGuard && (cfg.algorithm != AlgorithmSHA1 || cfg.period != totpDefaultPeriod) {
+		return ErrMFAConflictingOptions
+	}
+
 	return nil
 }
 
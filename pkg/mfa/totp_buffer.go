@@ -0,0 +1,160 @@
+package mfa
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/base32"
+	"encoding/binary"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const (
+	hotpTruncateMask = 0x7fffffff
+	hotpOffsetMask   = 0x0f
+	hotpDecimalBase  = 10
+)
+
+// NewTOTPFromBuffer constructs a TOTP helper from a base32 secret held in a SecureBuffer.
+// The decoded key is kept in its own SecureBuffer, intermediates are zeroed, and codes are
+// computed without materializing the secret as a Go string. The caller keeps ownership of secret.
+func NewTOTPFromBuffer(secret *memory.SecureBuffer, opts ...TOTPOption) (*TOTP, error) {
+	if secret == nil || secret.IsCleared() {
+		return nil, ErrMFAInvalidSecret
+	}
+
+	cfg := defaultTOTPConfig()
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := validateTOTPConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := decodeSecretBuffer(secret, cfg.minSecretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.rejectWeak {
+		raw := key.BytesCopy()
+		weak := isWeakSecret(raw)
+		memory.ZeroBytes(raw)
+
+		if weak {
+			key.Clear()
+
+			return nil, ErrInvalidMFAConfig
+		}
+	}
+
+	return &TOTP{
+		key:  key,
+		opts: cfg,
+	}, nil
+}
+
+// Clear wipes a secret loaded with NewTOTPFromBuffer. The TOTP must not be used afterwards.
+// It is a no-op for TOTPs constructed from a string secret.
+func (t *TOTP) Clear() {
+	if t.key != nil {
+		t.key.Clear()
+	}
+}
+
+// keyCodeAtCounter computes a code from the buffered key, zeroing the working copy.
+func (t *TOTP) keyCodeAtCounter(counter uint64, digits Digits, algorithm Algorithm) (string, error) {
+	key := t.key.BytesCopy()
+	if len(key) == 0 {
+		return "", ErrMFAInvalidSecret
+	}
+
+	defer memory.ZeroBytes(key)
+
+	if t.opts.steamGuard {
+		return steamGuardEncode(hotpTruncate(key, counter, AlgorithmSHA1)), nil
+	}
+
+	modulus := uint32(1)
+	for range digitsLength(digits) {
+		modulus *= hotpDecimalBase
+	}
+
+	//nolint:gosec // the value is reduced below 10^8 and fits in int32.
+	return digits.Format(int32(hotpTruncate(key, counter, algorithm) % modulus)), nil
+}
+
+// decodeSecretBuffer normalizes and decodes a base32 secret into a new SecureBuffer.
+// It applies the same rules as normalizeSecret without converting the secret to a string.
+func decodeSecretBuffer(secret *memory.SecureBuffer, minBytes int) (*memory.SecureBuffer, error) {
+	if minBytes < mfaAbsoluteMinSecret || minBytes > mfaMaxSecret {
+		return nil, ErrInvalidMFAConfig
+	}
+
+	raw := secret.BytesCopy()
+	defer memory.ZeroBytes(raw)
+
+	normalized := make([]byte, 0, len(raw))
+	defer func() {
+		memory.ZeroBytes(normalized)
+	}()
+
+	for _, c := range bytes.TrimSpace(raw) {
+		switch {
+		case c == ' ' || c == '-':
+			continue
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+
+		normalized = append(normalized, c)
+	}
+
+	trimmed := bytes.TrimRight(normalized, secretPaddingCharacter)
+	if len(trimmed) == 0 {
+		return nil, ErrMFAInvalidSecret
+	}
+
+	decoder := base32.StdEncoding.WithPadding(base32.NoPadding)
+	if decoder.DecodedLen(len(trimmed)) > mfaMaxSecret {
+		return nil, ErrMFASecretTooLong
+	}
+
+	key := make([]byte, decoder.DecodedLen(len(trimmed)))
+	defer memory.ZeroBytes(key)
+
+	n, err := decoder.Decode(key, trimmed)
+	if err != nil {
+		return nil, ErrMFAInvalidSecret
+	}
+
+	if n < minBytes {
+		return nil, ErrMFASecretTooShort
+	}
+
+	return memory.NewSecureBuffer(key[:n]), nil
+}
+
+// hotpTruncate applies RFC 4226 dynamic truncation to the HMAC of counter.
+func hotpTruncate(key []byte, counter uint64, algorithm Algorithm) uint32 {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(algorithm.Hash, key)
+	_, _ = mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & hotpOffsetMask
+
+	return binary.BigEndian.Uint32(sum[offset:]) & hotpTruncateMask
+}
@@ -0,0 +1,91 @@
+package mfa
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+func TestTOTPFromBufferMatchesStringSecret(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	cases := [][]TOTPOption{
+		nil,
+		{WithTOTPDigits(DigitsEight), WithTOTPAlgorithm(AlgorithmSHA256)},
+		{WithTOTPAlgorithm(AlgorithmSHA512), WithTOTPPeriod(time.Minute)},
+		{WithTOTPSteamGuard()},
+	}
+
+	for _, opts := range cases {
+		expected, err := NewTOTP(totpTestSecret, opts...)
+		if err != nil {
+			t.Fatalf("expected totp, got %v", err)
+		}
+
+		secret := memory.NewSecureBuffer([]byte(" " + strings.ToLower(totpTestSecret) + "== "))
+
+		helper, err := NewTOTPFromBuffer(secret, opts...)
+		if err != nil {
+			t.Fatalf("expected buffered totp, got %v", err)
+		}
+
+		want, err := expected.GenerateAt(now)
+		if err != nil {
+			t.Fatalf("expected code, got %v", err)
+		}
+
+		got, err := helper.GenerateAt(now)
+		if err != nil {
+			t.Fatalf("expected code, got %v", err)
+		}
+
+		if got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+
+		ok, _, err := helper.VerifyAt(want, now.Add(30*time.Second))
+		if err != nil || !ok {
+			t.Fatalf("expected verification within skew, got %v, %v", ok, err)
+		}
+	}
+}
+
+func TestTOTPFromBufferClear(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTOTPFromBuffer(nil)
+	if !errors.Is(err, ErrMFAInvalidSecret) {
+		t.Fatalf("expected ErrMFAInvalidSecret, got %v", err)
+	}
+
+	_, err = NewTOTPFromBuffer(memory.NewSecureBuffer([]byte("JBSWY3DP")))
+	if !errors.Is(err, ErrMFASecretTooShort) {
+		t.Fatalf("expected ErrMFASecretTooShort, got %v", err)
+	}
+
+	secret := memory.NewSecureBuffer([]byte(totpTestSecret))
+
+	helper, err := NewTOTPFromBuffer(secret)
+	if err != nil {
+		t.Fatalf("expected buffered totp, got %v", err)
+	}
+
+	secret.Clear()
+
+	_, err = helper.Generate()
+	if err != nil {
+		t.Fatalf("expected key to be independent of the caller buffer, got %v", err)
+	}
+
+	helper.Clear()
+
+	_, err = helper.Generate()
+	if !errors.Is(err, ErrMFAInvalidSecret) {
+		t.Fatalf("expected ErrMFAInvalidSecret after Clear, got %v", err)
+	}
+}
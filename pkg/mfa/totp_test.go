@@ -1,10 +1,13 @@
 package mfa
 
 import (
+	"encoding/base32"
 	"errors"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
 )
 
 const (
@@ -121,6 +124,45 @@ func TestTOTPVerifyWithStep(t *testing.T) {
 	}
 }
 
+func TestTOTPGenerateAndVerifyAt(t *testing.T) {
+	t.Parallel()
+	//nolint:revive
+	trusted := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)
+	clock := func() time.Time {
+		return trusted.Add(24 * time.Hour)
+	}
+
+	helper, err := NewTOTP(totpTestSecret, WithTOTPClock(clock), WithTOTPAllowedSkew(1))
+	if err != nil {
+		t.Fatalf(errMsgExpectedTOTPHelper, err)
+	}
+
+	code, err := helper.GenerateAt(trusted)
+	if err != nil {
+		t.Fatalf(errExpectedCode, err)
+	}
+
+	ok, err := helper.Verify(code)
+	if err != nil || ok {
+		t.Fatalf("expected process clock to reject code, got %v (%v)", ok, err)
+	}
+
+	ok, step, err := helper.VerifyAt(code, trusted.Add(totpDefaultPeriod))
+	if err != nil || !ok {
+		t.Fatalf("expected code valid within skew, got %v (%v)", ok, err)
+	}
+	//nolint:gosec
+	expectedStep := uint64(trusted.Unix() / int64(totpDefaultPeriod/time.Second))
+	if step != expectedStep {
+		t.Fatalf("expected step %d, got %d", expectedStep, step)
+	}
+
+	ok, _, err = helper.VerifyAt(code, trusted.Add(3*totpDefaultPeriod))
+	if err != nil || ok {
+		t.Fatalf("expected code outside skew to fail, got %v (%v)", ok, err)
+	}
+}
+
 func TestTOTPInvalidCode(t *testing.T) {
 	t.Parallel()
 
@@ -144,6 +186,59 @@ func TestTOTPSecretTooShort(t *testing.T) {
 	}
 }
 
+func TestTOTPRejectWeakSecret(t *testing.T) {
+	t.Parallel()
+
+	encoder := base32.StdEncoding.WithPadding(base32.NoPadding)
+	sequence := make([]byte, mfaDefaultSecretSize)
+
+	for i := range sequence {
+		sequence[i] = byte(i)
+	}
+
+	weak := map[string]string{
+		"all-zero":   strings.Repeat("A", 32),
+		"repeated":   totpTestSecret,
+		"rfc-ascii":  encoder.EncodeToString([]byte("12345678901234567890")),
+		"sequence":   encoder.EncodeToString(sequence),
+		"low-spread": encoder.EncodeToString([]byte("aaaaaaaaaaaaaaaaaaab")),
+	}
+
+	for name, secret := range weak {
+		_, err := NewTOTP(secret, WithTOTPRejectWeakSecret())
+		if !errors.Is(err, ErrInvalidMFAConfig) {
+			t.Fatalf("%s: expected ErrInvalidMFAConfig, got %v", name, err)
+		}
+	}
+
+	_, err := NewTOTP(totpTestSecret)
+	if err != nil {
+		t.Fatalf("expected weak secret to be accepted without the option, got %v", err)
+	}
+
+	for _, size := range []int{mfaAbsoluteMinSecret, mfaDefaultSecretSize, mfaMaxSecret} {
+		for range 200 {
+			secret, err := GenerateSecret(size)
+			if err != nil {
+				t.Fatalf("expected secret, got %v", err)
+			}
+
+			_, err = NewTOTP(secret, WithTOTPSecretMinBytes(mfaAbsoluteMinSecret), WithTOTPRejectWeakSecret())
+			if err != nil {
+				t.Fatalf("expected random %d-byte secret to be accepted, got %v", size, err)
+			}
+		}
+	}
+
+	buffer := memory.NewSecureBuffer([]byte(strings.Repeat("A", 32)))
+	defer buffer.Clear()
+
+	_, err = NewTOTPFromBuffer(buffer, WithTOTPRejectWeakSecret())
+	if !errors.Is(err, ErrInvalidMFAConfig) {
+		t.Fatalf("expected ErrInvalidMFAConfig from buffer, got %v", err)
+	}
+}
+
 func TestTOTPInvalidOptions(t *testing.T) {
 	t.Parallel()
 
@@ -204,3 +299,38 @@ func TestGenerateTOTPKey(t *testing.T) {
 		t.Fatalf("expected otpauth url, got %s", key.URL())
 	}
 }
+
+func TestTOTPSteamGuard(t *testing.T) {
+	t.Parallel()
+	//nolint:revive
+	now := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	helper, err := NewTOTP(totpTestSecret, WithTOTPSteamGuard())
+	if err != nil {
+		t.Fatalf(errMsgExpectedTOTPHelper, err)
+	}
+
+	code, err := helper.GenerateAt(now)
+	if err != nil {
+		t.Fatalf(errExpectedCode, err)
+	}
+
+	if code != "RNBPG" {
+		t.Fatalf("expected steam guard code RNBPG, got %s", code)
+	}
+
+	ok, _, err := helper.VerifyAt(strings.ToLower(code), now.Add(totpDefaultPeriod))
+	if err != nil || !ok {
+		t.Fatalf("expected valid steam guard code within skew, got %v (%v)", ok, err)
+	}
+
+	_, _, err = helper.VerifyAt("123456", now)
+	if !errors.Is(err, ErrMFAInvalidCode) {
+		t.Fatalf("expected ErrMFAInvalidCode for decimal code, got %v", err)
+	}
+
+	_, err = NewTOTP(totpTestSecret, WithTOTPSteamGuard(), WithTOTPAlgorithm(AlgorithmSHA256))
+	if !errors.Is(err, ErrMFAConflictingOptions) {
+		t.Fatalf("expected ErrMFAConflictingOptions, got %v", err)
+	}
+}
@@ -25,6 +25,17 @@ const (
 )
 
 // RateLimiter enforces rate limiting for MFA verification attempts.
+// Allow is called once per attempt, before the code is checked; returning false rejects the
+// attempt with ErrMFARateLimited and a non-nil error is wrapped in it. The limiter has no notion
+// of identity, so one instance shared across users throttles them together; use
+// RateLimiterKeyed for per-account limits.
 type RateLimiter interface {
 	Allow() (bool, error)
 }
+
+// RateLimiterKeyed enforces rate limiting per caller-supplied key, such as a user id.
+// AllowN reports whether n attempts for key may proceed; a non-nil error rejects the attempt
+// and is wrapped in ErrMFARateLimited.
+type RateLimiterKeyed interface {
+	AllowN(key string, n int) error
+}
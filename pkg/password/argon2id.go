@@ -36,6 +36,11 @@ const (
 
 	argon2idUint32BitSize = 32
 	argon2idUint8BitSize  = 8
+
+	argon2idDefaultMaxMemoryMiB = 1024
+	argon2idDefaultMaxTime      = 16
+	argon2idDefaultMaxThreads   = 64
+	argon2idMaxEncodedLength    = 1024
 )
 
 // Argon2idParams defines parameters for argon2id hashing.
@@ -80,19 +85,108 @@ func Argon2idHighSecurity() Argon2idParams {
 	}
 }
 
+// Argon2idOption configures an Argon2idHasher.
+type Argon2idOption func(*argon2idOptions) error
+
+type argon2idOptions struct {
+	maxMemory  uint32
+	maxTime    uint32
+	maxThreads uint8
+	pepper     []byte
+}
+
 // Argon2idHasher hashes passwords using argon2id.
 type Argon2idHasher struct {
 	params Argon2idParams
+	opts   argon2idOptions
 }
 
 // NewArgon2id constructs a hasher with custom parameters.
-func NewArgon2id(params Argon2idParams) (*Argon2idHasher, error) {
+// Encoded hashes whose cost exceeds the verification limits are rejected before hashing;
+// the limits default to 1 GiB of memory, 16 passes, and 64 threads, raised to params if higher.
+func NewArgon2id(params Argon2idParams, opts ...Argon2idOption) (*Argon2idHasher, error) {
 	err := params.validate()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Argon2idHasher{params: params}, nil
+	cfg := argon2idOptions{
+		maxMemory:  max(argon2idDefaultMaxMemoryMiB*argon2idKiB, params.Memory),
+		maxTime:    max(argon2idDefaultMaxTime, params.Time),
+		maxThreads: max(argon2idDefaultMaxThreads, params.Threads),
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err = opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.maxMemory < params.Memory || cfg.maxTime < params.Time || cfg.maxThreads < params.Threads {
+		return nil, ErrInvalidParams
+	}
+
+	return &Argon2idHasher{params: params, opts: cfg}, nil
+}
+
+// WithArgon2idMaxMemory caps the memory (KiB) an encoded hash may declare during Verify.
+// NewArgon2id rejects params whose Memory exceeds the cap, so Hash never allocates more either.
+func WithArgon2idMaxMemory(memory uint32) Argon2idOption {
+	return func(cfg *argon2idOptions) error {
+		if memory == 0 {
+			return ErrInvalidParams
+		}
+
+		cfg.maxMemory = memory
+
+		return nil
+	}
+}
+
+// WithArgon2idMaxTime caps the number of passes an encoded hash may declare during Verify.
+func WithArgon2idMaxTime(time uint32) Argon2idOption {
+	return func(cfg *argon2idOptions) error {
+		if time == 0 {
+			return ErrInvalidParams
+		}
+
+		cfg.maxTime = time
+
+		return nil
+	}
+}
+
+// WithArgon2idMaxThreads caps the parallelism an encoded hash may declare during Verify.
+func WithArgon2idMaxThreads(threads uint8) Argon2idOption {
+	return func(cfg *argon2idOptions) error {
+		if threads == 0 {
+			return ErrInvalidParams
+		}
+
+		cfg.maxThreads = threads
+
+		return nil
+	}
+}
+
+// WithArgon2idPepper keys passwords with HMAC-SHA256(pepper, password) before hashing.
+// The pepper is not stored in the encoded hash, so Verify must use the same pepper.
+func WithArgon2idPepper(pepper []byte) Argon2idOption {
+	return func(cfg *argon2idOptions) error {
+		value, err := copyPepper(pepper)
+		if err != nil {
+			return err
+		}
+
+		cfg.pepper = value
+
+		return nil
+	}
 }
 
 // Hash hashes a password using argon2id and returns a PHC string.
@@ -104,7 +198,10 @@ func (h *Argon2idHasher) Hash(password []byte) (string, error) {
 		return "", fmt.Errorf("read salt: %w", err)
 	}
 
-	hash := argon2.IDKey(password, salt, h.params.Time, h.params.Memory, h.params.Threads, h.params.KeyLength)
+	input, release := pepperPassword(h.opts.pepper, password)
+	defer release()
+
+	hash := argon2.IDKey(input, salt, h.params.Time, h.params.Memory, h.params.Threads, h.params.KeyLength)
 
 	return formatArgon2idHash(h.params, salt, hash), nil
 }
@@ -116,7 +213,7 @@ func (h *Argon2idHasher) Verify(password []byte, encoded string) (ok, needsRehas
 		return false, false, err
 	}
 
-	err = validateArgon2idEncodedParams(decoded.params)
+	err = validateArgon2idEncodedParams(decoded.params, h.opts)
 	if err != nil {
 		return false, false, err
 	}
@@ -126,7 +223,10 @@ func (h *Argon2idHasher) Verify(password []byte, encoded string) (ok, needsRehas
 		return false, false, err
 	}
 
-	hash := argon2.IDKey(password, decoded.salt, decoded.params.Time, decoded.params.Memory, decoded.params.Threads, keyLength)
+	input, release := pepperPassword(h.opts.pepper, password)
+	defer release()
+
+	hash := argon2.IDKey(input, decoded.salt, decoded.params.Time, decoded.params.Memory, decoded.params.Threads, keyLength)
 
 	match := subtle.ConstantTimeCompare(hash, decoded.hash) == 1
 	if !match {
@@ -138,6 +238,21 @@ func (h *Argon2idHasher) Verify(password []byte, encoded string) (ok, needsRehas
 	return true, needsRehash, nil
 }
 
+// DeriveKey derives a KeyLength-byte key from passphrase and salt using argon2id.
+// The salt must be at least SaltLength bytes; use a random salt per derived key and store it alongside the data.
+func (p Argon2idParams) DeriveKey(passphrase, salt []byte) ([]byte, error) {
+	err := p.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) == 0 || uint64(len(salt)) < uint64(p.SaltLength) {
+		return nil, ErrInvalidParams
+	}
+
+	return argon2.IDKey(passphrase, salt, p.Time, p.Memory, p.Threads, p.KeyLength), nil
+}
+
 func (p Argon2idParams) validate() error {
 	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 || p.SaltLength == 0 || p.KeyLength == 0 {
 		return ErrInvalidParams
@@ -154,7 +269,7 @@ func (p Argon2idParams) validate() error {
 	return nil
 }
 
-func validateArgon2idEncodedParams(p Argon2idParams) error {
+func validateArgon2idEncodedParams(p Argon2idParams, limits argon2idOptions) error {
 	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 || p.SaltLength == 0 || p.KeyLength == 0 {
 		return ErrInvalidHash
 	}
@@ -163,6 +278,15 @@ func validateArgon2idEncodedParams(p Argon2idParams) error {
 		return ErrInvalidHash
 	}
 
+	// Encoded parameters are untrusted; bound them so a crafted hash cannot force a huge allocation.
+	if p.Memory > limits.maxMemory || p.Time > limits.maxTime || p.Threads > limits.maxThreads {
+		return ErrInvalidHash
+	}
+
+	if p.SaltLength > argon2idMaxEncodedLength || p.KeyLength > argon2idMaxEncodedLength {
+		return ErrInvalidHash
+	}
+
 	return nil
 }
 
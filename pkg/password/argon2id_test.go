@@ -2,7 +2,9 @@ package password
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -87,3 +89,165 @@ func TestArgon2idInvalidHash(t *testing.T) {
 		t.Fatalf("expected ErrInvalidHash, got %v", err)
 	}
 }
+
+func TestArgon2idRejectsOversizedEncodedParams(t *testing.T) {
+	t.Parallel()
+
+	params := Argon2idParams{
+		Memory:     8 * 1024,
+		Time:       1,
+		Threads:    1,
+		SaltLength: 16,
+		KeyLength:  keyLength,
+	}
+
+	hasher, err := NewArgon2id(params, WithArgon2idMaxMemory(64*1024))
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	hash, err := hasher.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	for _, crafted := range []string{
+		strings.Replace(hash, "m=8192", "m=4294967295", 1),
+		strings.Replace(hash, "m=8192", "m=65537", 1),
+		strings.Replace(hash, "t=1", "t=4294967295", 1),
+		strings.Replace(hash, "p=1", "p=255", 1),
+	} {
+		_, _, err = hasher.Verify([]byte("password"), crafted)
+		if !errors.Is(err, ErrInvalidHash) {
+			t.Fatalf("expected ErrInvalidHash for %q, got %v", crafted, err)
+		}
+	}
+
+	_, err = NewArgon2id(params, WithArgon2idMaxMemory(params.Memory-1))
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams for cap below params, got %v", err)
+	}
+}
+
+func TestArgon2idDeriveKey(t *testing.T) {
+	t.Parallel()
+
+	params := Argon2idParams{
+		Memory:     8 * 1024,
+		Time:       1,
+		Threads:    1,
+		SaltLength: 16,
+		KeyLength:  keyLength,
+	}
+
+	salt := []byte("0123456789abcdef")
+
+	first, err := params.DeriveKey([]byte("passphrase"), salt)
+	if err != nil {
+		t.Fatalf("expected key, got error: %v", err)
+	}
+
+	second, err := params.DeriveKey([]byte("passphrase"), salt)
+	if err != nil {
+		t.Fatalf("expected key, got error: %v", err)
+	}
+
+	if len(first) != keyLength || !ConstantTimeCompare(first, second) {
+		t.Fatal("expected deterministic key of the configured length")
+	}
+
+	_, err = params.DeriveKey([]byte("passphrase"), salt[:8])
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+}
+
+func TestArgon2idPepper(t *testing.T) {
+	t.Parallel()
+
+	params := Argon2idParams{
+		Memory:     8 * 1024,
+		Time:       1,
+		Threads:    1,
+		SaltLength: 16,
+		KeyLength:  keyLength,
+	}
+
+	pepper := []byte("0123456789abcdef0123456789abcdef")
+
+	peppered, err := NewArgon2id(params, WithArgon2idPepper(pepper))
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	plain, err := NewArgon2id(params)
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	hash, err := peppered.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$") || strings.Count(hash, "$") != 5 {
+		t.Fatalf("expected plain PHC encoding, got %q", hash)
+	}
+
+	ok, _, err := peppered.Verify([]byte("password"), hash)
+	if err != nil || !ok {
+		t.Fatalf("expected match with pepper, got %v %v", ok, err)
+	}
+
+	ok, _, err = plain.Verify([]byte("password"), hash)
+	if err != nil || ok {
+		t.Fatalf("expected mismatch without pepper, got %v %v", ok, err)
+	}
+
+	_, err = NewArgon2id(params, WithArgon2idPepper([]byte("short")))
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+}
+
+func TestTuneArgon2id(t *testing.T) {
+	t.Parallel()
+
+	// One microsecond per MiB per pass.
+	measure := func(p Argon2idParams) time.Duration {
+		return time.Duration(p.Memory/argon2idKiB*p.Time) * time.Microsecond
+	}
+
+	params, err := tuneArgon2id(256*time.Microsecond, 512, measure)
+	if err != nil {
+		t.Fatalf("expected params, got %v", err)
+	}
+
+	if params.Memory != 256*argon2idKiB || params.Time != 1 {
+		t.Fatalf("expected 256 MiB and 1 pass, got %+v", params)
+	}
+
+	params, err = tuneArgon2id(200*time.Microsecond, 64, measure)
+	if err != nil {
+		t.Fatalf("expected params, got %v", err)
+	}
+
+	if params.Memory != 64*argon2idKiB || params.Time != 3 {
+		t.Fatalf("expected 64 MiB and 3 passes, got %+v", params)
+	}
+
+	params, err = TuneArgon2id(time.Nanosecond, argon2idTuneMinMemoryMiB)
+	if err != nil {
+		t.Fatalf("expected params, got %v", err)
+	}
+
+	_, err = NewArgon2id(params)
+	if err != nil {
+		t.Fatalf("expected tuned params to be accepted, got %v", err)
+	}
+
+	_, err = TuneArgon2id(0, 64)
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package password
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idTuneMinMemoryMiB = 16
+	argon2idTuneSamples      = 3
+)
+
+// TuneArgon2id benchmarks argon2id on the current host and returns the highest-cost parameters
+// whose median hash time stays within target. Memory doubles from 16 MiB up to maxMemoryMiB first,
+// then passes increase up to the default verification limit of 16. When even the minimum
+// parameters exceed target, the minimum is returned. The result can be passed to NewArgon2id.
+func TuneArgon2id(target time.Duration, maxMemoryMiB uint32) (Argon2idParams, error) {
+	return tuneArgon2id(target, maxMemoryMiB, measureArgon2id)
+}
+
+func tuneArgon2id(
+	target time.Duration,
+	maxMemoryMiB uint32,
+	measure func(Argon2idParams) time.Duration,
+) (Argon2idParams, error) {
+	if target <= 0 || maxMemoryMiB < argon2idTuneMinMemoryMiB || maxMemoryMiB > math.MaxUint32/argon2idKiB {
+		return Argon2idParams{}, ErrInvalidParams
+	}
+
+	best := Argon2idParams{
+		Memory:     argon2idTuneMinMemoryMiB * argon2idKiB,
+		Time:       1,
+		Threads:    argon2idDefaultThreads,
+		SaltLength: argon2idDefaultSaltLength,
+		KeyLength:  argon2idDefaultKeyLength,
+	}
+
+	// Warm up so the first sample does not pay for page faults and goroutine start-up.
+	measure(best)
+
+	for {
+		next, ok := nextArgon2idCost(best, maxMemoryMiB*argon2idKiB)
+		if !ok || medianArgon2idDuration(next, measure) > target {
+			return best, nil
+		}
+
+		best = next
+	}
+}
+
+// nextArgon2idCost raises memory towards maxMemory, then the number of passes.
+// maxMemory is bounded by TuneArgon2id, so doubling cannot overflow.
+func nextArgon2idCost(params Argon2idParams, maxMemory uint32) (Argon2idParams, bool) {
+	switch {
+	case params.Memory < maxMemory:
+		params.Memory = min(params.Memory*2, maxMemory)
+	case params.Time < argon2idDefaultMaxTime:
+		params.Time++
+	default:
+		return params, false
+	}
+
+	return params, true
+}
+
+func medianArgon2idDuration(params Argon2idParams, measure func(Argon2idParams) time.Duration) time.Duration {
+	samples := make([]time.Duration, argon2idTuneSamples)
+	for i := range samples {
+		samples[i] = measure(params)
+	}
+
+	slices.Sort(samples)
+
+	return samples[len(samples)/2]
+}
+
+func measureArgon2id(params Argon2idParams) time.Duration {
+	input := make([]byte, params.SaltLength)
+
+	start := time.Now()
+	_ = argon2.IDKey(input, input, params.Time, params.Memory, params.Threads, params.KeyLength)
+
+	return time.Since(start)
+}
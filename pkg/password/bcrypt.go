@@ -1,10 +1,15 @@
 package password
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
 )
 
 // BcryptInteractiveCost is the low-latency bcrypt cost.
@@ -16,41 +21,114 @@ const (
 	BcryptHighCost        = 14
 )
 
+// BcryptOption configures a BcryptHasher.
+type BcryptOption func(*bcryptOptions) error
+
+type bcryptOptions struct {
+	pepper  []byte
+	prehash bool
+}
+
 // BcryptHasher hashes passwords using bcrypt.
 type BcryptHasher struct {
 	cost int
+	opts bcryptOptions
 }
 
 // NewBcrypt constructs a bcrypt hasher with the given cost.
-func NewBcrypt(cost int) (*BcryptHasher, error) {
+func NewBcrypt(cost int, opts ...BcryptOption) (*BcryptHasher, error) {
 	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
 		return nil, ErrInvalidParams
 	}
 
-	return &BcryptHasher{cost: cost}, nil
+	var cfg bcryptOptions
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BcryptHasher{cost: cost, opts: cfg}, nil
+}
+
+// WithBcryptPepper keys passwords with HMAC-SHA256(pepper, password) before hashing.
+// The pepper is not stored in the encoded hash, so Verify must use the same pepper.
+// The 72-byte password limit still applies to the input password unless WithBcryptSHA256Prehash is set.
+func WithBcryptPepper(pepper []byte) BcryptOption {
+	return func(cfg *bcryptOptions) error {
+		value, err := copyPepper(pepper)
+		if err != nil {
+			return err
+		}
+
+		cfg.pepper = value
+
+		return nil
+	}
+}
+
+// WithBcryptSHA256Prehash hashes passwords as bcrypt(base64(SHA-256(password))), so passwords
+// longer than 72 bytes are accepted and no two passwords collide through truncation.
+// Hashes are prefixed with "$sha256" to tell them apart from plain bcrypt; other bcrypt
+// implementations cannot verify them without the same transform. Verify accepts both forms
+// and reports needsRehash when the form differs from this hasher's setting.
+func WithBcryptSHA256Prehash() BcryptOption {
+	return func(cfg *bcryptOptions) error {
+		cfg.prehash = true
+
+		return nil
+	}
 }
 
 // Hash hashes a password using bcrypt.
 func (h *BcryptHasher) Hash(password []byte) (string, error) {
-	if len(password) > bcryptMaxPasswordLength {
+	if !h.opts.prehash && len(password) > bcryptMaxPasswordLength {
 		return "", ErrPasswordTooLong
 	}
 
-	hash, err := bcrypt.GenerateFromPassword(password, h.cost)
+	input, release := pepperPassword(h.opts.pepper, password)
+	defer release()
+
+	if h.opts.prehash {
+		input, release = prehashPassword(input)
+		defer release()
+	}
+
+	hash, err := bcrypt.GenerateFromPassword(input, h.cost)
 	if err != nil {
 		return "", fmt.Errorf("bcrypt hash: %w", err)
 	}
 
+	if h.opts.prehash {
+		return bcryptPrehashPrefix + string(hash), nil
+	}
+
 	return string(hash), nil
 }
 
 // Verify checks a password against a bcrypt hash and reports if it needs rehash.
 func (h *BcryptHasher) Verify(password []byte, encoded string) (ok, needsRehash bool, err error) {
-	if len(password) > bcryptMaxPasswordLength {
+	hash, prehashed := strings.CutPrefix(encoded, bcryptPrehashPrefix)
+
+	if !prehashed && len(password) > bcryptMaxPasswordLength {
 		return false, false, ErrPasswordTooLong
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(encoded), password)
+	input, release := pepperPassword(h.opts.pepper, password)
+	defer release()
+
+	if prehashed {
+		input, release = prehashPassword(input)
+		defer release()
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), input)
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 			return false, false, nil
@@ -59,14 +137,42 @@ func (h *BcryptHasher) Verify(password []byte, encoded string) (ok, needsRehash
 		return false, false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
 	}
 
-	cost, err := bcrypt.Cost([]byte(encoded))
+	cost, err := BcryptCost(encoded)
 	if err != nil {
-		return true, false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+		return true, false, err
 	}
 
-	needsRehash = cost != h.cost
+	// Any drift is reported: lower costs are upgraded and higher costs follow a lowered preset.
+	needsRehash = cost != h.cost || prehashed != h.opts.prehash
 
 	return true, needsRehash, nil
 }
 
-const bcryptMaxPasswordLength = 72
+// BcryptCost returns the cost factor encoded in a bcrypt hash, with or without the prehash prefix.
+func BcryptCost(encoded string) (int, error) {
+	cost, err := bcrypt.Cost([]byte(strings.TrimPrefix(encoded, bcryptPrehashPrefix)))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+
+	return cost, nil
+}
+
+// prehashPassword returns base64(SHA-256(input)). Base64 keeps the 44-byte result free of NUL
+// bytes, which some bcrypt implementations treat as a terminator. The release func zeroes it.
+func prehashPassword(input []byte) ([]byte, func()) {
+	sum := sha256.Sum256(input)
+	defer memory.ZeroBytes(sum[:])
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sum)))
+	base64.StdEncoding.Encode(encoded, sum[:])
+
+	return encoded, func() {
+		memory.ZeroBytes(encoded)
+	}
+}
+
+const (
+	bcryptMaxPasswordLength = 72
+	bcryptPrehashPrefix     = "$sha256"
+)
@@ -2,6 +2,7 @@ package password
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"golang.org/x/crypto/bcrypt"
@@ -67,3 +68,142 @@ func TestBcryptPasswordTooLong(t *testing.T) {
 		t.Fatalf("expected ErrPasswordTooLong, got %v", err)
 	}
 }
+
+func TestBcryptCost(t *testing.T) {
+	t.Parallel()
+
+	weak, err := NewBcrypt(bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	hash, err := weak.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	cost, err := BcryptCost(hash)
+	if err != nil {
+		t.Fatalf("expected cost, got error: %v", err)
+	}
+
+	if cost != bcrypt.MinCost {
+		t.Fatalf("expected cost %d, got %d", bcrypt.MinCost, cost)
+	}
+
+	_, err = BcryptCost("not-a-bcrypt-hash")
+	if !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+
+	verifier, err := NewBcrypt(bcrypt.MinCost + 2)
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	ok, needsRehash, err := verifier.Verify([]byte("wrong"), hash)
+	if err != nil || ok || needsRehash {
+		t.Fatalf("expected mismatch without rehash, got ok=%v needsRehash=%v err=%v", ok, needsRehash, err)
+	}
+
+	ok, needsRehash, err = verifier.Verify([]byte("password"), hash)
+	if err != nil || !ok || !needsRehash {
+		t.Fatalf("expected match needing rehash, got ok=%v needsRehash=%v err=%v", ok, needsRehash, err)
+	}
+}
+
+func TestBcryptPepper(t *testing.T) {
+	t.Parallel()
+
+	peppered, err := NewBcrypt(bcrypt.MinCost, WithBcryptPepper([]byte("0123456789abcdef")))
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	rotated, err := NewBcrypt(bcrypt.MinCost, WithBcryptPepper([]byte("fedcba9876543210")))
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	hash, err := peppered.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	ok, _, err := peppered.Verify([]byte("password"), hash)
+	if err != nil || !ok {
+		t.Fatalf("expected match with pepper, got %v %v", ok, err)
+	}
+
+	ok, _, err = rotated.Verify([]byte("password"), hash)
+	if err != nil || ok {
+		t.Fatalf("expected mismatch with another pepper, got %v %v", ok, err)
+	}
+
+	_, err = NewBcrypt(bcrypt.MinCost, WithBcryptPepper(nil))
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+}
+
+func TestBcryptSHA256Prehash(t *testing.T) {
+	t.Parallel()
+
+	prehashed, err := NewBcrypt(bcrypt.MinCost, WithBcryptSHA256Prehash())
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	long := []byte(strings.Repeat("a", bcryptMaxPasswordLength) + "-one")
+	collision := []byte(strings.Repeat("a", bcryptMaxPasswordLength) + "-two")
+
+	hash, err := prehashed.Hash(long)
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, bcryptPrehashPrefix+"$2") {
+		t.Fatalf("expected prehash marker, got %q", hash)
+	}
+
+	ok, needsRehash, err := prehashed.Verify(long, hash)
+	if err != nil || !ok || needsRehash {
+		t.Fatalf("expected match without rehash, got ok=%v rehash=%v err=%v", ok, needsRehash, err)
+	}
+
+	ok, _, err = prehashed.Verify(collision, hash)
+	if err != nil || ok {
+		t.Fatalf("expected passwords sharing a 72-byte prefix not to match, got ok=%v err=%v", ok, err)
+	}
+
+	cost, err := BcryptCost(hash)
+	if err != nil || cost != bcrypt.MinCost {
+		t.Fatalf("expected cost %d, got %d (%v)", bcrypt.MinCost, cost, err)
+	}
+
+	plain, err := NewBcrypt(bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("expected hasher, got error: %v", err)
+	}
+
+	// Both forms verify on either hasher; a form mismatch asks for a rehash.
+	ok, needsRehash, err = plain.Verify(long, hash)
+	if err != nil || !ok || !needsRehash {
+		t.Fatalf("expected plain hasher to verify prehashed hash and request rehash, got ok=%v rehash=%v err=%v", ok, needsRehash, err)
+	}
+
+	legacy, err := plain.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("expected hash, got error: %v", err)
+	}
+
+	ok, needsRehash, err = prehashed.Verify([]byte("password"), legacy)
+	if err != nil || !ok || !needsRehash {
+		t.Fatalf("expected legacy hash to verify and request rehash, got ok=%v rehash=%v err=%v", ok, needsRehash, err)
+	}
+
+	_, _, err = prehashed.Verify(long, legacy)
+	if !errors.Is(err, ErrPasswordTooLong) {
+		t.Fatalf("expected ErrPasswordTooLong against a plain hash, got %v", err)
+	}
+}
@@ -9,4 +9,8 @@ var (
 	ErrInvalidHash = ewrap.New("invalid password hash")
 	// ErrPasswordTooLong indicates that the provided password is too long.
 	ErrPasswordTooLong = ewrap.New("password is too long")
+	// ErrInvalidPolicyConfig indicates that the password policy configuration is invalid.
+	ErrInvalidPolicyConfig = ewrap.New("invalid password policy config")
+	// ErrPasswordPolicy indicates that the password violates the configured policy.
+	ErrPasswordPolicy = ewrap.New("password violates policy")
 )
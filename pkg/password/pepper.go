@@ -0,0 +1,34 @@
+package password
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const passwordMinPepperLength = 16
+
+func copyPepper(pepper []byte) ([]byte, error) {
+	if len(pepper) < passwordMinPepperLength {
+		return nil, ErrInvalidParams
+	}
+
+	return append([]byte(nil), pepper...), nil
+}
+
+// pepperPassword returns HMAC-SHA256(pepper, password), or password unchanged when no pepper is set.
+// The returned release func zeroes the HMAC output and must be called once the KDF has run.
+func pepperPassword(pepper, password []byte) ([]byte, func()) {
+	if len(pepper) == 0 {
+		return password, func() {}
+	}
+
+	mac := hmac.New(sha256.New, pepper)
+	_, _ = mac.Write(password)
+	sum := mac.Sum(nil)
+
+	return sum, func() {
+		memory.ZeroBytes(sum)
+	}
+}
@@ -0,0 +1,274 @@
+package password
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const (
+	policyDefaultMinLength = 8
+	// policyMinContextLength skips very short usernames that would ban common substrings.
+	policyMinContextLength = 3
+)
+
+// PolicyRule identifies a password policy rule.
+type PolicyRule string
+
+const (
+	// PolicyRuleMinLength requires a minimum number of characters.
+	PolicyRuleMinLength PolicyRule = "min_length"
+	// PolicyRuleUpper requires an uppercase letter.
+	PolicyRuleUpper PolicyRule = "uppercase"
+	// PolicyRuleLower requires a lowercase letter.
+	PolicyRuleLower PolicyRule = "lowercase"
+	// PolicyRuleDigit requires a digit.
+	PolicyRuleDigit PolicyRule = "digit"
+	// PolicyRuleSymbol requires a symbol or punctuation character.
+	PolicyRuleSymbol PolicyRule = "symbol"
+	// PolicyRuleRepeatedRunes limits consecutive repeated characters.
+	PolicyRuleRepeatedRunes PolicyRule = "repeated_runes"
+	// PolicyRuleBanned forbids entries from the banned list.
+	PolicyRuleBanned PolicyRule = "banned"
+	// PolicyRuleContext forbids the username or email from the PolicyContext.
+	PolicyRuleContext PolicyRule = "context"
+)
+
+// PolicyContext carries user attributes that must not appear in the password.
+type PolicyContext struct {
+	Username string
+	Email    string
+}
+
+// PolicyOption configures a Policy.
+type PolicyOption func(*policyOptions) error
+
+type policyOptions struct {
+	minLength    int
+	requireMixed bool
+	maxRepeated  int
+	banned       [][]byte
+}
+
+// Policy validates passwords against an organization policy.
+// Instances of Policy contain only immutable configuration and can be safely
+// used concurrently by multiple goroutines.
+type Policy struct {
+	opts policyOptions
+}
+
+// PolicyError lists every rule a password failed. It unwraps to ErrPasswordPolicy.
+type PolicyError struct {
+	violations []PolicyRule
+}
+
+// Error returns the failed rules as a comma-separated list.
+func (e *PolicyError) Error() string {
+	rules := make([]string, 0, len(e.violations))
+	for _, rule := range e.violations {
+		rules = append(rules, string(rule))
+	}
+
+	return ErrPasswordPolicy.Error() + ": " + strings.Join(rules, ", ")
+}
+
+// Unwrap returns ErrPasswordPolicy.
+func (e *PolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// Violations returns the failed rules in evaluation order.
+func (e *PolicyError) Violations() []PolicyRule {
+	return append([]PolicyRule(nil), e.violations...)
+}
+
+// NewPolicy constructs a password policy; by default only a minimum length of 8 is enforced.
+func NewPolicy(opts ...PolicyOption) (*Policy, error) {
+	cfg := policyOptions{minLength: policyDefaultMinLength}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Policy{opts: cfg}, nil
+}
+
+// WithMinLength sets the minimum password length in characters.
+func WithMinLength(minLength int) PolicyOption {
+	return func(cfg *policyOptions) error {
+		if minLength <= 0 {
+			return ErrInvalidPolicyConfig
+		}
+
+		cfg.minLength = minLength
+
+		return nil
+	}
+}
+
+// WithRequireUpperLowerDigitSymbol requires at least one uppercase letter, lowercase letter,
+// digit, and symbol.
+func WithRequireUpperLowerDigitSymbol() PolicyOption {
+	return func(cfg *policyOptions) error {
+		cfg.requireMixed = true
+
+		return nil
+	}
+}
+
+// WithMaxRepeatedRunes rejects passwords with more than maxRepeated identical consecutive characters.
+func WithMaxRepeatedRunes(maxRepeated int) PolicyOption {
+	return func(cfg *policyOptions) error {
+		if maxRepeated <= 0 {
+			return ErrInvalidPolicyConfig
+		}
+
+		cfg.maxRepeated = maxRepeated
+
+		return nil
+	}
+}
+
+// WithBannedList rejects passwords containing any of the entries, compared case-insensitively.
+func WithBannedList(entries ...string) PolicyOption {
+	return func(cfg *policyOptions) error {
+		for _, entry := range entries {
+			if strings.TrimSpace(entry) == "" {
+				return ErrInvalidPolicyConfig
+			}
+
+			cfg.banned = append(cfg.banned, []byte(strings.ToLower(entry)))
+		}
+
+		return nil
+	}
+}
+
+// Validate checks password against every rule and returns a *PolicyError listing all failures.
+// Comparisons work on a lowercased copy of password that is zeroed before returning.
+func (p *Policy) Validate(password []byte, context PolicyContext) error {
+	var violations []PolicyRule
+
+	if utf8.RuneCount(password) < p.opts.minLength {
+		violations = append(violations, PolicyRuleMinLength)
+	}
+
+	if p.opts.requireMixed {
+		violations = append(violations, missingClasses(password)...)
+	}
+
+	if p.opts.maxRepeated > 0 && maxRun(password) > p.opts.maxRepeated {
+		violations = append(violations, PolicyRuleRepeatedRunes)
+	}
+
+	lowered := bytes.ToLower(password)
+	defer memory.ZeroBytes(lowered)
+
+	for _, entry := range p.opts.banned {
+		if bytes.Contains(lowered, entry) {
+			violations = append(violations, PolicyRuleBanned)
+
+			break
+		}
+	}
+
+	if containsContext(lowered, context) {
+		violations = append(violations, PolicyRuleContext)
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{violations: violations}
+	}
+
+	return nil
+}
+
+func missingClasses(password []byte) []PolicyRule {
+	var upper, lower, digit, symbol bool
+
+	for len(password) > 0 {
+		ch, size := utf8.DecodeRune(password)
+		password = password[size:]
+
+		switch {
+		case unicode.IsUpper(ch):
+			upper = true
+		case unicode.IsLower(ch):
+			lower = true
+		case unicode.IsDigit(ch):
+			digit = true
+		case unicode.IsPunct(ch) || unicode.IsSymbol(ch) || ch == ' ':
+			symbol = true
+		}
+	}
+
+	var missing []PolicyRule
+
+	for _, class := range []struct {
+		present bool
+		rule    PolicyRule
+	}{
+		{upper, PolicyRuleUpper},
+		{lower, PolicyRuleLower},
+		{digit, PolicyRuleDigit},
+		{symbol, PolicyRuleSymbol},
+	} {
+		if !class.present {
+			missing = append(missing, class.rule)
+		}
+	}
+
+	return missing
+}
+
+func maxRun(password []byte) int {
+	longest, current := 0, 0
+	previous := utf8.RuneError
+
+	for len(password) > 0 {
+		ch, size := utf8.DecodeRune(password)
+		password = password[size:]
+
+		if ch == previous {
+			current++
+		} else {
+			previous, current = ch, 1
+		}
+
+		longest = max(longest, current)
+	}
+
+	return longest
+}
+
+func containsContext(lowered []byte, context PolicyContext) bool {
+	candidates := []string{context.Username, context.Email}
+
+	// The local part of an email is usually what users reuse.
+	if local, _, ok := strings.Cut(context.Email, "@"); ok {
+		candidates = append(candidates, local)
+	}
+
+	for _, candidate := range candidates {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if len(candidate) < policyMinContextLength {
+			continue
+		}
+
+		if bytes.Contains(lowered, []byte(candidate)) {
+			return true
+		}
+	}
+
+	return false
+}
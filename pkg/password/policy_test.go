@@ -0,0 +1,98 @@
+package password
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPolicyAcceptsCompliantPassword(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewPolicy(
+		WithMinLength(12),
+		WithRequireUpperLowerDigitSymbol(),
+		WithMaxRepeatedRunes(2),
+		WithBannedList("password", "sectools"),
+	)
+	if err != nil {
+		t.Fatalf("expected policy, got error: %v", err)
+	}
+
+	err = policy.Validate([]byte("Tr0ub4dor&3-horse"), PolicyContext{Username: "alice", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("expected compliant password, got error: %v", err)
+	}
+}
+
+func TestPolicyReportsAllViolations(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewPolicy(
+		WithMinLength(12),
+		WithRequireUpperLowerDigitSymbol(),
+		WithMaxRepeatedRunes(2),
+		WithBannedList("Password"),
+	)
+	if err != nil {
+		t.Fatalf("expected policy, got error: %v", err)
+	}
+
+	err = policy.Validate([]byte("passwordaaa"), PolicyContext{})
+	if !errors.Is(err, ErrPasswordPolicy) {
+		t.Fatalf("expected ErrPasswordPolicy, got %v", err)
+	}
+
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected PolicyError, got %T", err)
+	}
+
+	expected := []PolicyRule{
+		PolicyRuleMinLength,
+		PolicyRuleUpper,
+		PolicyRuleDigit,
+		PolicyRuleSymbol,
+		PolicyRuleRepeatedRunes,
+		PolicyRuleBanned,
+	}
+	if !slices.Equal(policyErr.Violations(), expected) {
+		t.Fatalf("expected %v, got %v", expected, policyErr.Violations())
+	}
+}
+
+func TestPolicyRejectsContext(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewPolicy()
+	if err != nil {
+		t.Fatalf("expected policy, got error: %v", err)
+	}
+
+	ctx := PolicyContext{Username: "jdoe", Email: "john.doe@example.com"}
+
+	for _, candidate := range []string{"xxJDoe2024", "john.doe!2024"} {
+		err = policy.Validate([]byte(candidate), ctx)
+
+		var policyErr *PolicyError
+		if !errors.As(err, &policyErr) || !slices.Contains(policyErr.Violations(), PolicyRuleContext) {
+			t.Fatalf("%s: expected context violation, got %v", candidate, err)
+		}
+	}
+
+	err = policy.Validate([]byte("correct horse"), PolicyContext{Username: "co"})
+	if err != nil {
+		t.Fatalf("expected short usernames to be ignored, got %v", err)
+	}
+}
+
+func TestPolicyRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, opt := range []PolicyOption{WithMinLength(0), WithMaxRepeatedRunes(0), WithBannedList(" ")} {
+		_, err := NewPolicy(opt)
+		if !errors.Is(err, ErrInvalidPolicyConfig) {
+			t.Fatalf("expected ErrInvalidPolicyConfig, got %v", err)
+		}
+	}
+}
@@ -36,6 +36,9 @@ var (
 	// ErrNoSQLInjectionDetected indicates the input matched NoSQL injection heuristics.
 	ErrNoSQLInjectionDetected = ewrap.New("nosql injection detected")
 
+	// ErrJSONStringInvalid indicates the JSON string is not valid UTF-8.
+	ErrJSONStringInvalid = ewrap.New("json string invalid")
+
 	// ErrFilenameEmpty indicates the filename is empty after sanitization.
 	ErrFilenameEmpty = ewrap.New("filename empty")
 	// ErrFilenameTooLong indicates the filename exceeds the configured limit.
@@ -0,0 +1,91 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	jsonLineSeparator      = '\u2028'
+	jsonParagraphSeparator = '\u2029'
+)
+
+// JSONStringOption configures JSON string sanitization.
+type JSONStringOption func(*jsonStringOptions) error
+
+type jsonStringOptions struct {
+	allowNewlines bool
+	normalizeNFC  bool
+}
+
+// WithJSONAllowNewlines keeps tab, newline, and carriage return characters.
+func WithJSONAllowNewlines(allow bool) JSONStringOption {
+	return func(cfg *jsonStringOptions) error {
+		cfg.allowNewlines = allow
+
+		return nil
+	}
+}
+
+// WithJSONNormalizeNFC normalizes the result to Unicode NFC.
+func WithJSONNormalizeNFC(enabled bool) JSONStringOption {
+	return func(cfg *jsonStringOptions) error {
+		cfg.normalizeNFC = enabled
+
+		return nil
+	}
+}
+
+// JSONString removes control and bidirectional formatting characters from a decoded JSON
+// string value. Invalid UTF-8, including encoded lone surrogates, is rejected.
+func JSONString(input string, opts ...JSONStringOption) (string, error) {
+	cfg := jsonStringOptions{}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if !utf8.ValidString(input) {
+		return "", ErrJSONStringInvalid
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(input))
+
+	for _, ch := range input {
+		if isAllowedJSONRune(ch, cfg) {
+			builder.WriteRune(ch)
+		}
+	}
+
+	output := builder.String()
+	if cfg.normalizeNFC {
+		output = norm.NFC.String(output)
+	}
+
+	return output, nil
+}
+
+func isAllowedJSONRune(ch rune, cfg jsonStringOptions) bool {
+	switch ch {
+	case '\t', '\n', '\r', jsonLineSeparator, jsonParagraphSeparator:
+		return cfg.allowNewlines
+	}
+
+	if unicode.IsControl(ch) {
+		return false
+	}
+
+	// Bidi embeddings, overrides, and isolates can make stored text render misleadingly.
+	return !unicode.Is(unicode.Bidi_Control, ch)
+}
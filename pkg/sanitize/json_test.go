@@ -0,0 +1,59 @@
+package sanitize
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONStringStripsControls(t *testing.T) {
+	t.Parallel()
+
+	output, err := JSONString("a\x00b\x1bc\td\ne\u202ef\u0085g")
+	if err != nil {
+		t.Fatalf("expected sanitized string, got %v", err)
+	}
+
+	if output != "abcdefg" {
+		t.Fatalf("expected abcdefg, got %q", output)
+	}
+}
+
+func TestJSONStringAllowNewlines(t *testing.T) {
+	t.Parallel()
+
+	output, err := JSONString("line1\r\nline2\tend\x07", WithJSONAllowNewlines(true))
+	if err != nil {
+		t.Fatalf("expected sanitized string, got %v", err)
+	}
+
+	if output != "line1\r\nline2\tend" {
+		t.Fatalf("expected newlines and tabs kept, got %q", output)
+	}
+}
+
+func TestJSONStringNormalizeNFC(t *testing.T) {
+	t.Parallel()
+
+	output, err := JSONString("cafe\u0301", WithJSONNormalizeNFC(true))
+	if err != nil {
+		t.Fatalf("expected sanitized string, got %v", err)
+	}
+
+	if output != "caf\u00e9" {
+		t.Fatalf("expected NFC output, got %q", output)
+	}
+}
+
+func TestJSONStringRejectsLoneSurrogate(t *testing.T) {
+	t.Parallel()
+
+	_, err := JSONString("bad\xed\xa0\x80")
+	if !errors.Is(err, ErrJSONStringInvalid) {
+		t.Fatalf("expected ErrJSONStringInvalid, got %v", err)
+	}
+
+	_, err = JSONString("bad\xff")
+	if !errors.Is(err, ErrJSONStringInvalid) {
+		t.Fatalf("expected ErrJSONStringInvalid, got %v", err)
+	}
+}
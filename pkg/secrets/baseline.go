@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	baselineVersion    = 1
+	fingerprintContext = "sectools/secret-fingerprint/v1"
+)
+
+// Fingerprint returns a stable identifier for the match, suitable for suppressing known findings.
+// It is the hex SHA-256 of the pattern name, File, and the value with surrounding whitespace trimmed,
+// so the same secret in the same file keeps its fingerprint when lines move. The raw value is not
+// embedded, but like any unsalted hash it can confirm a guessed secret; treat baselines as sensitive.
+func (m SecretMatch) Fingerprint() string {
+	hash := sha256.New()
+
+	for _, field := range []string{fingerprintContext, m.Pattern, m.File, strings.TrimSpace(m.Value)} {
+		// Length prefixes keep field boundaries unambiguous.
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+		_, _ = hash.Write(size[:])
+		_, _ = hash.Write([]byte(field))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// BaselineEntry is one accepted finding. Pattern and File are informational for reviewers;
+// only Fingerprint is used for matching.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Pattern     string `json:"pattern,omitempty"`
+	File        string `json:"file,omitempty"`
+}
+
+// Baseline is a set of accepted findings, in the spirit of detect-secrets baselines.
+// It is not safe for concurrent modification.
+type Baseline struct {
+	entries map[string]BaselineEntry
+}
+
+type baselineDocument struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// NewBaseline returns a baseline that accepts the given matches.
+func NewBaseline(matches []SecretMatch) *Baseline {
+	baseline := &Baseline{entries: make(map[string]BaselineEntry, len(matches))}
+
+	for _, match := range matches {
+		baseline.Add(match)
+	}
+
+	return baseline
+}
+
+// LoadBaseline parses a baseline produced by Baseline.MarshalJSON.
+// Malformed JSON, an unknown version, or an empty fingerprint returns ErrInvalidBaseline.
+func LoadBaseline(data []byte) (*Baseline, error) {
+	var doc baselineDocument
+
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBaseline, err)
+	}
+
+	if doc.Version != baselineVersion {
+		return nil, ErrInvalidBaseline
+	}
+
+	baseline := &Baseline{entries: make(map[string]BaselineEntry, len(doc.Findings))}
+
+	for _, entry := range doc.Findings {
+		if strings.TrimSpace(entry.Fingerprint) == "" {
+			return nil, ErrInvalidBaseline
+		}
+
+		baseline.entries[entry.Fingerprint] = entry
+	}
+
+	return baseline, nil
+}
+
+// Add accepts match.
+func (b *Baseline) Add(match SecretMatch) {
+	if b.entries == nil {
+		b.entries = make(map[string]BaselineEntry)
+	}
+
+	fingerprint := match.Fingerprint()
+	b.entries[fingerprint] = BaselineEntry{Fingerprint: fingerprint, Pattern: match.Pattern, File: match.File}
+}
+
+// Contains reports whether match is accepted by the baseline.
+func (b *Baseline) Contains(match SecretMatch) bool {
+	if b == nil || len(b.entries) == 0 {
+		return false
+	}
+
+	_, ok := b.entries[match.Fingerprint()]
+
+	return ok
+}
+
+// Filter returns the matches that are not in the baseline, for use with Detect results.
+func (b *Baseline) Filter(matches []SecretMatch) []SecretMatch {
+	if b == nil || len(b.entries) == 0 {
+		return matches
+	}
+
+	filtered := make([]SecretMatch, 0, len(matches))
+
+	for _, match := range matches {
+		if !b.Contains(match) {
+			filtered = append(filtered, match)
+		}
+	}
+
+	return filtered
+}
+
+// Len returns the number of accepted findings.
+func (b *Baseline) Len() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.entries)
+}
+
+// MarshalJSON encodes the baseline with findings sorted by fingerprint, so output is stable
+// across runs and diffs cleanly in version control. Secret values are never included.
+func (b *Baseline) MarshalJSON() ([]byte, error) {
+	doc := baselineDocument{Version: baselineVersion, Findings: make([]BaselineEntry, 0, b.Len())}
+
+	if b != nil {
+		for _, entry := range b.entries {
+			doc.Findings = append(doc.Findings, entry)
+		}
+	}
+
+	slices.SortFunc(doc.Findings, func(left, right BaselineEntry) int {
+		return strings.Compare(left.Fingerprint, right.Fingerprint)
+	})
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBaseline, err)
+	}
+
+	return data, nil
+}
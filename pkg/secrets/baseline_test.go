@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSecretMatchFingerprint(t *testing.T) {
+	t.Parallel()
+
+	match := SecretMatch{Pattern: "aws_access_key", Value: "AKIA1234567890ABCD12", Start: 4, End: 24, File: "config.env"}
+
+	moved := match
+	moved.Start, moved.End = 100, 120
+	moved.Value = " AKIA1234567890ABCD12\n"
+
+	if match.Fingerprint() != moved.Fingerprint() {
+		t.Fatal("expected fingerprint independent of offsets and surrounding whitespace")
+	}
+
+	if strings.Contains(match.Fingerprint(), match.Value) {
+		t.Fatal("expected fingerprint not to embed the secret")
+	}
+
+	otherFile := match
+	otherFile.File = "other.env"
+
+	otherPattern := match
+	otherPattern.Pattern = "generic"
+
+	if match.Fingerprint() == otherFile.Fingerprint() || match.Fingerprint() == otherPattern.Fingerprint() {
+		t.Fatal("expected fingerprint to depend on pattern and file")
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScanFile(t, dir, "config.env", "key=AKIA1234567890ABCD12\ntoken="+writerTestToken+"\n")
+
+	detector, err := NewSecretDetector()
+	if err != nil {
+		t.Fatalf(errMsgDetector, err)
+	}
+
+	matches, err := ScanFile("config.env", detector, nil, WithScanFileBaseDir(dir))
+	if err != nil || len(matches) < 2 {
+		t.Fatalf("expected matches, got %+v (%v)", matches, err)
+	}
+
+	data, err := NewBaseline(matches[:1]).MarshalJSON()
+	if err != nil {
+		t.Fatalf("expected baseline encoded, got %v", err)
+	}
+
+	if strings.Contains(string(data), matches[0].Value) {
+		t.Fatalf("expected baseline without secret values, got %s", data)
+	}
+
+	baseline, err := LoadBaseline(data)
+	if err != nil || baseline.Len() != 1 {
+		t.Fatalf("expected baseline loaded, got %v", err)
+	}
+
+	filtered, err := ScanFile("config.env", detector, nil, WithScanFileBaseDir(dir), WithScanFileBaseline(baseline))
+	if err != nil {
+		t.Fatalf("expected scan, got %v", err)
+	}
+
+	if len(filtered) != len(matches)-1 {
+		t.Fatalf("expected one match suppressed, got %+v", filtered)
+	}
+
+	for _, match := range filtered {
+		if match.Fingerprint() == matches[0].Fingerprint() {
+			t.Fatalf("expected baselined match suppressed, got %+v", match)
+		}
+	}
+
+	// Detect results carry no file, so they do not match file-scoped entries.
+	detected, err := detector.Detect("key=AKIA1234567890ABCD12")
+	if err != nil || len(baseline.Filter(detected)) != len(detected) {
+		t.Fatalf("expected in-memory matches kept, got %+v (%v)", detected, err)
+	}
+}
+
+func TestLoadBaselineInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{
+		`not json`,
+		`{"version":2,"findings":[]}`,
+		`{"version":1,"findings":[{"fingerprint":" "}]}`,
+	} {
+		_, err := LoadBaseline([]byte(data))
+		if !errors.Is(err, ErrInvalidBaseline) {
+			t.Fatalf("expected ErrInvalidBaseline for %s, got %v", data, err)
+		}
+	}
+
+	detector, err := NewSecretDetector()
+	if err != nil {
+		t.Fatalf(errMsgDetector, err)
+	}
+
+	_, err = ScanFile("config.env", detector, nil, WithScanFileBaseline(nil))
+	if !errors.Is(err, ErrInvalidSecretConfig) {
+		t.Fatalf("expected ErrInvalidSecretConfig, got %v", err)
+	}
+}
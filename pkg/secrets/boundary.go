@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"regexp/syntax"
+	"unicode"
+	"unicode/utf8"
+)
+
+// matches reports whether the pattern has at least one match that satisfies its boundary requirements.
+func (p secretCompiled) matches(input string) bool {
+	if !p.boundStart && !p.boundEnd {
+		return p.re.MatchString(input)
+	}
+
+	for _, index := range p.re.FindAllStringIndex(input, -1) {
+		if p.bounded(input, index[0], index[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bounded reports whether the match span satisfies the compiled word-boundary requirements.
+func (p secretCompiled) bounded(input string, start, end int) bool {
+	if p.boundStart && start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(input[:start])
+		if isSecretTokenRune(r) {
+			return false
+		}
+	}
+
+	if p.boundEnd && end < len(input) {
+		r, _ := utf8.DecodeRuneInString(input[end:])
+		if isSecretTokenRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// secretBoundaryEdges reports which edges of a pattern need a boundary check.
+// Edges the pattern already anchors with ^, $, \A, \z or \b are skipped.
+func secretBoundaryEdges(pattern string) (bool, bool) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return true, true
+	}
+
+	parsed = parsed.Simplify()
+	if parsed.Op != syntax.OpConcat || len(parsed.Sub) == 0 {
+		return !isSecretAnchor(parsed.Op), !isSecretAnchor(parsed.Op)
+	}
+
+	first := parsed.Sub[0].Op
+	last := parsed.Sub[len(parsed.Sub)-1].Op
+
+	return !isSecretAnchor(first), !isSecretAnchor(last)
+}
+
+func isSecretAnchor(op syntax.Op) bool {
+	switch op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSecretTokenRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -13,38 +13,83 @@ const (
 	secretDefaultMask      = "[REDACTED]"
 )
 
+// Severity ranks how serious a detected secret is.
+type Severity int
+
+const (
+	// SeverityUnset indicates no severity was configured; it is treated as SeverityMedium.
+	SeverityUnset Severity = iota
+	// SeverityLow indicates a low-risk detection.
+	SeverityLow
+	// SeverityMedium indicates a medium-risk detection.
+	SeverityMedium
+	// SeverityHigh indicates a high-risk detection.
+	SeverityHigh
+	// SeverityCritical indicates a critical detection, such as private key material.
+	SeverityCritical
+)
+
+// String returns the lowercase severity name.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unset"
+	}
+}
+
 // SecretPattern defines a named regex pattern for secret detection.
+// Severity defaults to SeverityMedium when unset.
 type SecretPattern struct {
-	Name    string
-	Pattern string
+	Name     string
+	Pattern  string
+	Severity Severity
+	Category string
 }
 
 type secretCompiled struct {
-	name string
-	re   *regexp.Regexp
+	name       string
+	re         *regexp.Regexp
+	severity   Severity
+	category   string
+	boundStart bool
+	boundEnd   bool
 }
 
 // SecretMatch describes a detected secret match.
 type SecretMatch struct {
-	Pattern string
-	Value   string
-	Start   int
-	End     int
+	Pattern  string
+	Value    string
+	Start    int
+	End      int
+	Severity Severity
+	Category string
+	// File is the path passed to ScanFile, or empty for in-memory detection.
+	File string
 }
 
 // SecretDetectOption configures SecretDetector.
 type SecretDetectOption func(*secretOptions) error
 
 type secretOptions struct {
-	maxLength int
-	mask      string
-	patterns  []SecretPattern
+	maxLength      int
+	mask           string
+	patterns       []SecretPattern
+	wordBoundaries bool
 }
 
 // SecretDetector detects secrets in text and can redact them.
 type SecretDetector struct {
 	opts     secretOptions
 	patterns []secretCompiled
+	maxMatch int
 }
 
 // NewSecretDetector constructs a detector with safe defaults.
@@ -74,6 +119,7 @@ func NewSecretDetector(opts ...SecretDetectOption) (*SecretDetector, error) {
 	return &SecretDetector{
 		opts:     cfg,
 		patterns: patterns,
+		maxMatch: secretMaxMatchLength(cfg.patterns, cfg.maxLength),
 	}, nil
 }
 
@@ -125,6 +171,17 @@ func WithSecretMask(mask string) SecretDetectOption {
 	}
 }
 
+// WithSecretRequireWordBoundaries requires matches to be bounded by non-token characters or string edges.
+// Token characters are letters, digits, '_' and '-'; patterns that already anchor an edge are left unchanged on that edge.
+// Reported offsets cover only the matched secret, never the surrounding boundary characters.
+func WithSecretRequireWordBoundaries() SecretDetectOption {
+	return func(cfg *secretOptions) error {
+		cfg.wordBoundaries = true
+
+		return nil
+	}
+}
+
 // Detect scans input and returns all matches.
 func (d *SecretDetector) Detect(input string) ([]SecretMatch, error) {
 	if len(input) > d.opts.maxLength {
@@ -135,32 +192,63 @@ func (d *SecretDetector) Detect(input string) ([]SecretMatch, error) {
 		return nil, nil
 	}
 
-	matches := make([]SecretMatch, 0)
+	return d.appendMatches(make([]SecretMatch, 0), input), nil
+}
 
+func (d *SecretDetector) appendMatches(dst []SecretMatch, input string) []SecretMatch {
 	for _, pattern := range d.patterns {
-		indexes := pattern.re.FindAllStringIndex(input, -1)
-		for _, index := range indexes {
-			if len(index) != 2 {
-				continue
-			}
-
-			start := index[0]
-
-			end := index[1]
-			if start < 0 || end <= start || end > len(input) {
-				continue
-			}
-
-			matches = append(matches, SecretMatch{
-				Pattern: pattern.name,
-				Value:   input[start:end],
-				Start:   start,
-				End:     end,
-			})
+		dst = pattern.appendMatches(dst, input)
+	}
+
+	return dst
+}
+
+func (p secretCompiled) appendMatches(dst []SecretMatch, input string) []SecretMatch {
+	indexes := p.re.FindAllStringIndex(input, -1)
+	for _, index := range indexes {
+		if len(index) != 2 {
+			continue
+		}
+
+		start := index[0]
+
+		end := index[1]
+		if start < 0 || end <= start || end > len(input) {
+			continue
+		}
+
+		if !p.bounded(input, start, end) {
+			continue
+		}
+
+		dst = append(dst, SecretMatch{
+			Pattern:  p.name,
+			Value:    input[start:end],
+			Start:    start,
+			End:      end,
+			Severity: p.severity,
+			Category: p.category,
+		})
+	}
+
+	return dst
+}
+
+// DetectAbove returns matches whose severity is at least minSeverity.
+func (d *SecretDetector) DetectAbove(input string, minSeverity Severity) ([]SecretMatch, error) {
+	matches, err := d.Detect(input)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]SecretMatch, 0, len(matches))
+	for _, match := range matches {
+		if match.Severity >= minSeverity {
+			filtered = append(filtered, match)
 		}
 	}
 
-	return matches, nil
+	return filtered, nil
 }
 
 // DetectAny returns ErrSecretDetected when a secret is found.
@@ -177,6 +265,26 @@ func (d *SecretDetector) DetectAny(input string) error {
 	return nil
 }
 
+// DetectAnyNamed returns the name of the first matching pattern with ErrSecretDetected.
+// Patterns are checked in configuration order and scanning stops at the first match.
+func (d *SecretDetector) DetectAnyNamed(input string) (string, error) {
+	if len(input) > d.opts.maxLength {
+		return "", ErrSecretInputTooLong
+	}
+
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+
+	for _, pattern := range d.patterns {
+		if pattern.matches(input) {
+			return pattern.name, ErrSecretDetected
+		}
+	}
+
+	return "", nil
+}
+
 // Redact replaces detected secrets with the configured mask.
 func (d *SecretDetector) Redact(input string) (string, []SecretMatch, error) {
 	matches, err := d.Detect(input)
@@ -270,6 +378,12 @@ func mergeIntoLastSecretMatch(merged *[]SecretMatch, match SecretMatch) bool {
 	return true
 }
 
+// chunkTail returns how many bytes to retain between windows of limit bytes: enough for the
+// longest possible match, but at most half the window so every window makes progress.
+func (d *SecretDetector) chunkTail(limit int) int {
+	return min(d.maxMatch, limit/2)
+}
+
 func compileSecretPatterns(cfg secretOptions) ([]secretCompiled, error) {
 	if cfg.maxLength <= 0 || strings.TrimSpace(cfg.mask) == "" {
 		return nil, ErrInvalidSecretConfig
@@ -290,24 +404,54 @@ func compileSecretPatterns(cfg secretOptions) ([]secretCompiled, error) {
 			return nil, fmt.Errorf("%w: %w", ErrInvalidSecretConfig, err)
 		}
 
-		compiled = append(compiled, secretCompiled{
-			name: pattern.Name,
-			re:   re,
-		})
+		severity, err := normalizeSeverity(pattern.Severity)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := secretCompiled{
+			name:     pattern.Name,
+			re:       re,
+			severity: severity,
+			category: strings.TrimSpace(pattern.Category),
+		}
+
+		if cfg.wordBoundaries {
+			entry.boundStart, entry.boundEnd = secretBoundaryEdges(pattern.Pattern)
+		}
+
+		compiled = append(compiled, entry)
 	}
 
 	return compiled, nil
 }
 
+func normalizeSeverity(severity Severity) (Severity, error) {
+	if severity == SeverityUnset {
+		return SeverityMedium, nil
+	}
+
+	if severity < SeverityLow || severity > SeverityCritical {
+		return SeverityUnset, ErrInvalidSecretConfig
+	}
+
+	return severity, nil
+}
+
 func defaultSecretPatterns() []SecretPattern {
 	return []SecretPattern{
-		{Name: "aws-access-key", Pattern: `AKIA[0-9A-Z]{16}|ASIA[0-9A-Z]{16}`},
-		{Name: "github-token", Pattern: `gh[pousr]_[A-Za-z0-9]{36,}`},
-		{Name: "slack-token", Pattern: `xox[baprs]-[A-Za-z0-9-]{10,}`},
-		{Name: "google-api-key", Pattern: `AIza[0-9A-Za-z_-]{35}`},
-		{Name: "stripe-secret", Pattern: `sk_live_[0-9a-zA-Z]{24,}`},
-		{Name: "jwt", Pattern: `eyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+`},
-		{Name: "private-key", Pattern: `-----BEGIN (?:RSA|EC|DSA|OPENSSH|PGP) PRIVATE KEY-----`},
-		{Name: "bearer-token", Pattern: `(?i)bearer\s+[a-z0-9._-]{16,}`},
+		{Name: "aws-access-key", Pattern: `AKIA[0-9A-Z]{16}|ASIA[0-9A-Z]{16}`, Severity: SeverityHigh, Category: "cloud-credential"},
+		{Name: "github-token", Pattern: `gh[pousr]_[A-Za-z0-9]{36,}`, Severity: SeverityHigh, Category: "source-control"},
+		{Name: "slack-token", Pattern: `xox[baprs]-[A-Za-z0-9-]{10,}`, Severity: SeverityHigh, Category: "messaging"},
+		{Name: "google-api-key", Pattern: `AIza[0-9A-Za-z_-]{35}`, Severity: SeverityHigh, Category: "cloud-credential"},
+		{Name: "stripe-secret", Pattern: `sk_live_[0-9a-zA-Z]{24,}`, Severity: SeverityCritical, Category: "payment"},
+		{Name: "jwt", Pattern: `eyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+`, Severity: SeverityMedium, Category: "auth-token"},
+		{Name: "private-key", Pattern: `-----BEGIN (?:RSA|EC|DSA|OPENSSH|PGP) PRIVATE KEY-----`, Severity: SeverityCritical, Category: "private-key"},
+		{Name: "pkcs8-private-key", Pattern: `-----BEGIN (?:ENCRYPTED )?PRIVATE KEY-----`, Severity: SeverityHigh, Category: "private-key"},
+		// Base64 of the "openssh-key-v1\x00" magic that starts every OpenSSH private key blob.
+		{Name: "openssh-key-blob", Pattern: `b3BlbnNzaC1rZXktdjEA[A-Za-z0-9+/]{16,}`, Severity: SeverityHigh, Category: "private-key"},
+		{Name: "putty-private-key", Pattern: `PuTTY-User-Key-File-[23]: `, Severity: SeverityHigh, Category: "private-key"},
+		{Name: "key-parameters", Pattern: `-----BEGIN (?:DSA|EC) PARAMETERS-----`, Severity: SeverityHigh, Category: "private-key"},
+		{Name: "bearer-token", Pattern: `(?i)bearer\s+[a-z0-9._-]{16,}`, Severity: SeverityMedium, Category: "auth-token"},
 	}
 }
@@ -0,0 +1,130 @@
+package secrets
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// secretBatchParallelMin is the batch size at which DetectBatch fans out across patterns.
+const secretBatchParallelMin = 64
+
+// DetectBatch scans each input and returns its matches at the same index.
+// Match storage is shared across inputs, and large batches are scanned with one worker per pattern.
+// Inputs without matches yield nil entries. It is safe for concurrent use.
+func (d *SecretDetector) DetectBatch(inputs []string) ([][]SecretMatch, error) {
+	for _, input := range inputs {
+		if len(input) > d.opts.maxLength {
+			return nil, ErrSecretInputTooLong
+		}
+	}
+
+	if len(inputs) >= secretBatchParallelMin && len(d.patterns) > 1 {
+		return d.detectBatchParallel(inputs), nil
+	}
+
+	results := make([][]SecretMatch, len(inputs))
+
+	var buf []SecretMatch
+
+	for i, input := range inputs {
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+
+		start := len(buf)
+
+		buf = d.appendMatches(buf, input)
+		if len(buf) > start {
+			results[i] = buf[start:len(buf):len(buf)]
+		}
+	}
+
+	return results, nil
+}
+
+type secretBatchMatch struct {
+	input int
+	match SecretMatch
+}
+
+func (d *SecretDetector) detectBatchParallel(inputs []string) [][]SecretMatch {
+	perPattern := make([][]secretBatchMatch, len(d.patterns))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(runtime.GOMAXPROCS(0), len(d.patterns)) {
+		wg.Go(func() {
+			for idx := range jobs {
+				perPattern[idx] = detectPatternBatch(d.patterns[idx], inputs)
+			}
+		})
+	}
+
+	for idx := range d.patterns {
+		jobs <- idx
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return mergeSecretBatch(perPattern, len(inputs))
+}
+
+// mergeSecretBatch groups per-pattern matches by input, keeping pattern order as Detect does.
+func mergeSecretBatch(perPattern [][]secretBatchMatch, size int) [][]SecretMatch {
+	counts := make([]int, size)
+	total := 0
+
+	for _, matches := range perPattern {
+		for _, entry := range matches {
+			counts[entry.input]++
+		}
+
+		total += len(matches)
+	}
+
+	results := make([][]SecretMatch, size)
+	if total == 0 {
+		return results
+	}
+
+	backing := make([]SecretMatch, 0, total)
+
+	offset := 0
+	for i, count := range counts {
+		if count > 0 {
+			results[i] = backing[offset : offset : offset+count]
+			offset += count
+		}
+	}
+
+	for _, matches := range perPattern {
+		for _, entry := range matches {
+			results[entry.input] = append(results[entry.input], entry.match)
+		}
+	}
+
+	return results
+}
+
+func detectPatternBatch(pattern secretCompiled, inputs []string) []secretBatchMatch {
+	var (
+		found   []secretBatchMatch
+		scratch []SecretMatch
+	)
+
+	for i, input := range inputs {
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+
+		scratch = pattern.appendMatches(scratch[:0], input)
+		for _, match := range scratch {
+			found = append(found, secretBatchMatch{input: i, match: match})
+		}
+	}
+
+	return found
+}
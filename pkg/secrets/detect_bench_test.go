@@ -0,0 +1,80 @@
+package secrets
+
+import (
+	"strconv"
+	"testing"
+)
+
+const benchBatchSize = 256
+
+func benchSecretInputs() []string {
+	inputs := make([]string, benchBatchSize)
+	for i := range inputs {
+		if i%8 == 0 {
+			inputs[i] = "user=" + strconv.Itoa(i) + " key=AKIA1234567890ABCD12"
+
+			continue
+		}
+
+		inputs[i] = "request " + strconv.Itoa(i) + " completed without credentials"
+	}
+
+	return inputs
+}
+
+func BenchmarkSecretDetectorDetectLoop(b *testing.B) {
+	detector, err := NewSecretDetector()
+	if err != nil {
+		b.Fatalf(errMsgDetector, err)
+	}
+
+	inputs := benchSecretInputs()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		results := make([][]SecretMatch, len(inputs))
+		for i, input := range inputs {
+			results[i], err = detector.Detect(input)
+			if err != nil {
+				b.Fatalf("expected matches, got %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkSecretDetectorDetectBatch(b *testing.B) {
+	detector, err := NewSecretDetector()
+	if err != nil {
+		b.Fatalf(errMsgDetector, err)
+	}
+
+	inputs := benchSecretInputs()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err = detector.DetectBatch(inputs)
+		if err != nil {
+			b.Fatalf("expected matches, got %v", err)
+		}
+	}
+}
+
+func BenchmarkSecretDetectorDetectBatchSmall(b *testing.B) {
+	detector, err := NewSecretDetector()
+	if err != nil {
+		b.Fatalf(errMsgDetector, err)
+	}
+
+	inputs := benchSecretInputs()[:secretBatchParallelMin-1]
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err = detector.DetectBatch(inputs)
+		if err != nil {
+			b.Fatalf("expected matches, got %v", err)
+		}
+	}
+}
@@ -10,6 +10,12 @@ var (
 
 	// ErrSecretInputTooLong indicates the input exceeds the configured max length.
 	ErrSecretInputTooLong = ewrap.New("secret input too long")
+	// ErrRedactionInvalidJSON indicates that the redaction input is not valid JSON.
+	ErrRedactionInvalidJSON = ewrap.New("redaction input is not valid json")
 	// ErrSecretDetected indicates that a secret was detected in the input.
 	ErrSecretDetected = ewrap.New("secret detected")
+	// ErrRedactingWriterClosed indicates a write to a closed RedactingWriter.
+	ErrRedactingWriterClosed = ewrap.New("redacting writer closed")
+	// ErrInvalidBaseline indicates a malformed secret baseline.
+	ErrInvalidBaseline = ewrap.New("invalid secret baseline")
 )
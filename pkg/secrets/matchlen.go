@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// secretMaxMatchLength returns the longest match, in bytes, that any compiled pattern can
+// produce, capped at limit. Patterns with unbounded repetition count as limit.
+func secretMaxMatchLength(patterns []SecretPattern, limit int) int {
+	longest := 0
+
+	for _, pattern := range patterns {
+		parsed, err := syntax.Parse(pattern.Pattern, syntax.Perl)
+		if err != nil {
+			return limit
+		}
+
+		length, bounded := regexpMaxLength(parsed, limit)
+		if !bounded {
+			return limit
+		}
+
+		longest = max(longest, length)
+	}
+
+	return longest
+}
+
+// regexpMaxLength reports the longest UTF-8 match of re and whether it is within limit.
+func regexpMaxLength(re *syntax.Regexp, limit int) (int, bool) {
+	length := 0
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			length = len(re.Rune) * utf8.UTFMax
+		} else {
+			for _, r := range re.Rune {
+				length += utf8.RuneLen(r)
+			}
+		}
+	case syntax.OpCharClass:
+		if len(re.Rune) > 0 {
+			length = utf8.RuneLen(re.Rune[len(re.Rune)-1])
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		length = utf8.UTFMax
+	case syntax.OpCapture, syntax.OpQuest:
+		return regexpMaxLength(re.Sub[0], limit)
+	case syntax.OpStar, syntax.OpPlus:
+		return limit, false
+	case syntax.OpRepeat:
+		if re.Max < 0 {
+			return limit, false
+		}
+
+		sub, bounded := regexpMaxLength(re.Sub[0], limit)
+		if !bounded || (sub > 0 && re.Max > limit/sub) {
+			return limit, false
+		}
+
+		length = sub * re.Max
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			subLength, bounded := regexpMaxLength(sub, limit)
+			if !bounded {
+				return limit, false
+			}
+
+			length += subLength
+		}
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			subLength, bounded := regexpMaxLength(sub, limit)
+			if !bounded {
+				return limit, false
+			}
+
+			length = max(length, subLength)
+		}
+	default:
+		// Empty-width assertions and empty matches consume nothing.
+	}
+
+	if length > limit {
+		return limit, false
+	}
+
+	return length, true
+}
@@ -0,0 +1,25 @@
+package secrets
+
+import "sync"
+
+//nolint:gochecknoglobals // the default detector is immutable and built once on first use.
+var defaultSecretDetector = sync.OnceValues(func() (*SecretDetector, error) {
+	return NewSecretDetector()
+})
+
+// QuickRedact masks secrets found by the default detector patterns.
+// Input the detector cannot scan, such as values over the default length limit, is
+// replaced by the mask entirely rather than returned unredacted.
+func QuickRedact(input string) string {
+	detector, err := defaultSecretDetector()
+	if err != nil {
+		return secretDefaultMask
+	}
+
+	redacted, _, err := detector.Redact(input)
+	if err != nil {
+		return secretDefaultMask
+	}
+
+	return redacted
+}
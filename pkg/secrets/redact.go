@@ -1,7 +1,11 @@
 package secrets
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
+
+	"github.com/goccy/go-json"
 )
 
 const (
@@ -14,8 +18,12 @@ type RedactorOption func(*redactorOptions) error
 type redactorOptions struct {
 	mask     string
 	keys     map[string]struct{}
+	patterns []*regexp.Regexp
+	valueRes []*regexp.Regexp
 	detector *SecretDetector
 	maxDepth int
+	policies map[string]RedactionPolicy
+	hashSalt []byte
 }
 
 // Redactor redacts secrets from structured fields.
@@ -47,6 +55,11 @@ func NewRedactor(opts ...RedactorOption) (*Redactor, error) {
 		return nil, err
 	}
 
+	err = ensureRedactionHashSalt(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Redactor{opts: cfg}, nil
 }
 
@@ -107,6 +120,33 @@ func WithRedactionKeys(keys ...string) RedactorOption {
 	}
 }
 
+// WithRedactionKeyPatterns adds regular expressions that mark matching keys as sensitive, for
+// dynamic keys such as "header.x-api-key-123". Patterns are matched case-insensitively against
+// the normalized key; a key is sensitive if it is in the exact key set or matches any pattern.
+// An empty or invalid pattern returns ErrInvalidRedactorConfig.
+func WithRedactionKeyPatterns(patterns ...string) RedactorOption {
+	return func(cfg *redactorOptions) error {
+		if len(patterns) == 0 {
+			return ErrInvalidRedactorConfig
+		}
+
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return ErrInvalidRedactorConfig
+			}
+
+			compiled, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidRedactorConfig, err)
+			}
+
+			cfg.patterns = append(cfg.patterns, compiled)
+		}
+
+		return nil
+	}
+}
+
 // WithRedactionDetector uses a detector to redact secrets inside string values.
 func WithRedactionDetector(detector *SecretDetector) RedactorOption {
 	return func(cfg *redactorOptions) error {
@@ -120,6 +160,33 @@ func WithRedactionDetector(detector *SecretDetector) RedactorOption {
 	}
 }
 
+// WithRedactionValuePatterns adds regular expressions whose matches inside string values are
+// replaced with the mask, as a lighter alternative to WithRedactionDetector for one or two custom
+// formats. Patterns apply after the detector, if any, and are case-sensitive; use (?i) to opt out.
+// An empty or invalid pattern returns ErrInvalidRedactorConfig.
+func WithRedactionValuePatterns(patterns ...string) RedactorOption {
+	return func(cfg *redactorOptions) error {
+		if len(patterns) == 0 {
+			return ErrInvalidRedactorConfig
+		}
+
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return ErrInvalidRedactorConfig
+			}
+
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidRedactorConfig, err)
+			}
+
+			cfg.valueRes = append(cfg.valueRes, compiled)
+		}
+
+		return nil
+	}
+}
+
 // WithRedactionMaxDepth sets the maximum recursion depth for nested values.
 func WithRedactionMaxDepth(depth int) RedactorOption {
 	return func(cfg *redactorOptions) error {
@@ -165,7 +232,7 @@ func (r *Redactor) redactValue(value any, depth int, key string) (any, bool) {
 	}
 
 	if key != "" && r.isSensitiveKey(key) {
-		return r.maskValue(value), true
+		return r.redactSensitiveValue(key, value), true
 	}
 
 	switch typed := value.(type) {
@@ -179,11 +246,68 @@ func (r *Redactor) redactValue(value any, depth int, key string) (any, bool) {
 		return r.redactSliceString(typed, depth+1), true
 	case string:
 		return r.redactStringValue(typed), true
+	case json.RawMessage:
+		return r.redactRawMessage(typed, depth+1), true
+	case []byte:
+		return r.redactBytesValue(typed), true
+	case json.Number:
+		// Numbers are only masked under sensitive keys; checked before fmt.Stringer, which it implements.
+		return value, false
+	case fmt.Stringer:
+		return r.redactStringerValue(typed)
 	default:
 		return value, false
 	}
 }
 
+// redactBytesValue scans a byte slice as a string, returning the original slice when nothing matched.
+func (r *Redactor) redactBytesValue(value []byte) []byte {
+	if !r.scansValues() || value == nil {
+		return value
+	}
+
+	input := string(value)
+
+	redacted := r.redactStringValue(input)
+	if redacted == input {
+		return value
+	}
+
+	return []byte(redacted)
+}
+
+// redactStringerValue scans String() and returns the redacted string only when a secret was found,
+// so non-matching values keep their original type.
+func (r *Redactor) redactStringerValue(value fmt.Stringer) (any, bool) {
+	if !r.scansValues() {
+		return value, false
+	}
+
+	input, ok := stringerValue(value)
+	if !ok {
+		return value, false
+	}
+
+	redacted := r.redactStringValue(input)
+	if redacted == input {
+		return value, false
+	}
+
+	return redacted, true
+}
+
+// stringerValue calls String, reporting false if it panics (for example on a nil pointer receiver),
+// mirroring how fmt tolerates faulty Stringers.
+func stringerValue(value fmt.Stringer) (str string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			str, ok = "", false
+		}
+	}()
+
+	return value.String(), true
+}
+
 func (r *Redactor) redactMapAny(fields map[string]any, depth int) map[string]any {
 	if fields == nil {
 		return nil
@@ -191,6 +315,10 @@ func (r *Redactor) redactMapAny(fields map[string]any, depth int) map[string]any
 
 	redacted := make(map[string]any, len(fields))
 	for key, value := range fields {
+		if r.isRemovedKey(key) {
+			continue
+		}
+
 		next, _ := r.redactValue(value, depth, key)
 		redacted[key] = next
 	}
@@ -205,8 +333,12 @@ func (r *Redactor) redactMapString(fields map[string]string, depth int) map[stri
 
 	redacted := make(map[string]string, len(fields))
 	for key, value := range fields {
+		if r.isRemovedKey(key) {
+			continue
+		}
+
 		if r.isSensitiveKey(key) {
-			redacted[key] = r.RedactString(value)
+			redacted[key] = r.redactSensitiveString(key, value)
 
 			continue
 		}
@@ -255,16 +387,27 @@ func (r *Redactor) redactSliceString(values []string, _ int) []string {
 	return redacted
 }
 
+// scansValues reports whether string values are scanned for secrets, not just sensitive keys.
+func (r *Redactor) scansValues() bool {
+	return r.opts.detector != nil || len(r.opts.valueRes) > 0
+}
+
 func (r *Redactor) redactStringValue(value string) string {
-	if r.opts.detector == nil {
-		return value
+	redacted := value
+
+	if r.opts.detector != nil {
+		detected, _, err := r.opts.detector.Redact(value)
+		if err != nil {
+			// If the detector fails, treat this as a failure condition rather than
+			// forcing full redaction. Return the original value unchanged.
+			return value
+		}
+
+		redacted = detected
 	}
 
-	redacted, _, err := r.opts.detector.Redact(value)
-	if err != nil {
-		// If the detector fails, treat this as a failure condition rather than
-		// forcing full redaction. Return the original value unchanged.
-		return value
+	for _, pattern := range r.opts.valueRes {
+		redacted = pattern.ReplaceAllLiteralString(redacted, r.opts.mask)
 	}
 
 	return redacted
@@ -276,9 +419,22 @@ func (r *Redactor) isSensitiveKey(key string) bool {
 		return false
 	}
 
-	_, ok := r.opts.keys[normalized]
+	if _, ok := r.opts.keys[normalized]; ok {
+		return true
+	}
+
+	for _, pattern := range r.opts.patterns {
+		if pattern.MatchString(normalized) {
+			return true
+		}
+	}
+
+	return false
+}
 
-	return ok
+// isRemovedKey reports whether key is dropped from output under RedactionPolicyRemove.
+func (r *Redactor) isRemovedKey(key string) bool {
+	return r.fieldPolicy(key) == RedactionPolicyRemove
 }
 
 func (r *Redactor) maskValue(value any) any {
@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+
+	sectencoding "github.com/hyp3rd/sectools/pkg/encoding"
+)
+
+// RedactJSON redacts sensitive keys and detected secrets in a JSON document.
+// Numbers, booleans, and nulls are preserved exactly; only string values and
+// values under sensitive keys are rewritten. The configured max depth applies.
+func (r *Redactor) RedactJSON(data []byte) ([]byte, error) {
+	value, err := decodeRedactionJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted, _ := r.redactValue(value, 0, "")
+
+	output, err := json.MarshalNoEscape(redacted)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRedactionInvalidJSON, err)
+	}
+
+	return output, nil
+}
+
+// MarshalRedacted redacts fields with RedactFields and marshals the result as JSON.
+// Map keys are sorted at every level, so equal inputs always produce identical bytes.
+func MarshalRedacted(fields map[string]any, r *Redactor) ([]byte, error) {
+	if r == nil {
+		return nil, ErrInvalidRedactorConfig
+	}
+
+	output, err := json.MarshalNoEscape(r.RedactFields(fields))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRedactionInvalidJSON, err)
+	}
+
+	return output, nil
+}
+
+func (r *Redactor) redactRawMessage(raw json.RawMessage, depth int) json.RawMessage {
+	value, err := decodeRedactionJSON(raw)
+	if err != nil {
+		return raw
+	}
+
+	redacted, _ := r.redactValue(value, depth, "")
+
+	output, err := json.MarshalNoEscape(redacted)
+	if err != nil {
+		return raw
+	}
+
+	return output
+}
+
+func decodeRedactionJSON(data []byte) (any, error) {
+	var value any
+
+	err := sectencoding.DecodeJSON(data, &value, sectencoding.WithJSONUseNumber(true))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRedactionInvalidJSON, err)
+	}
+
+	return value, nil
+}
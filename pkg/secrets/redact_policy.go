@@ -0,0 +1,177 @@
+package secrets
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	redactionHashPrefix        = "sha256:"
+	redactionHashSaltBytes     = 32
+	redactionMinHashSaltBytes  = 16
+	redactionPartialVisible    = 4
+	redactionPartialMinVisible = 2 * redactionPartialVisible
+)
+
+// RedactionPolicy selects how the value of a sensitive field is redacted.
+type RedactionPolicy int
+
+const (
+	// RedactionPolicyMask replaces the value with the redaction mask. It is the default.
+	RedactionPolicyMask RedactionPolicy = iota
+	// RedactionPolicyRemove deletes the field from the output map.
+	RedactionPolicyRemove
+	// RedactionPolicyHashSHA256 replaces the value with a salted HMAC-SHA256 digest,
+	// so equal values correlate across records without being revealed.
+	RedactionPolicyHashSHA256
+	// RedactionPolicyPartial keeps the last four characters of strings of at least eight
+	// characters, prefixed by the mask. Shorter strings and non-strings are fully masked.
+	RedactionPolicyPartial
+)
+
+func (p RedactionPolicy) isValid() bool {
+	return p >= RedactionPolicyMask && p <= RedactionPolicyPartial
+}
+
+// WithRedactionFieldPolicy sets a redaction policy per key. Keys are normalized like
+// WithRedactionKeys and are treated as sensitive. Keys without a policy are masked.
+// An empty map, an empty key, or an unknown policy returns ErrInvalidRedactorConfig.
+func WithRedactionFieldPolicy(policies map[string]RedactionPolicy) RedactorOption {
+	return func(cfg *redactorOptions) error {
+		if len(policies) == 0 {
+			return ErrInvalidRedactorConfig
+		}
+
+		if cfg.keys == nil {
+			cfg.keys = make(map[string]struct{})
+		}
+
+		if cfg.policies == nil {
+			cfg.policies = make(map[string]RedactionPolicy, len(policies))
+		}
+
+		for key, policy := range policies {
+			normalized := normalizeRedactionKey(key)
+			if normalized == "" || !policy.isValid() {
+				return ErrInvalidRedactorConfig
+			}
+
+			cfg.keys[normalized] = struct{}{}
+			cfg.policies[normalized] = policy
+		}
+
+		return nil
+	}
+}
+
+// WithRedactionHashSalt sets the salt used by RedactionPolicyHashSHA256 (minimum 16 bytes).
+// Without it, NewRedactor generates a random salt, so hashes correlate only within one Redactor.
+// Share a salt across processes to correlate their output, and treat it as a secret.
+func WithRedactionHashSalt(salt []byte) RedactorOption {
+	return func(cfg *redactorOptions) error {
+		if len(salt) < redactionMinHashSaltBytes {
+			return ErrInvalidRedactorConfig
+		}
+
+		cfg.hashSalt = append([]byte(nil), salt...)
+
+		return nil
+	}
+}
+
+// ensureRedactionHashSalt generates a random salt when a hash policy is configured without one.
+func ensureRedactionHashSalt(cfg *redactorOptions) error {
+	if cfg.hashSalt != nil {
+		return nil
+	}
+
+	for _, policy := range cfg.policies {
+		if policy != RedactionPolicyHashSHA256 {
+			continue
+		}
+
+		salt := make([]byte, redactionHashSaltBytes)
+
+		_, err := rand.Read(salt)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRedactorConfig, err)
+		}
+
+		cfg.hashSalt = salt
+
+		return nil
+	}
+
+	return nil
+}
+
+func (r *Redactor) fieldPolicy(key string) RedactionPolicy {
+	if len(r.opts.policies) == 0 {
+		return RedactionPolicyMask
+	}
+
+	return r.opts.policies[normalizeRedactionKey(key)]
+}
+
+// redactSensitiveValue applies the policy for key to the value of a sensitive field.
+// RedactionPolicyRemove is handled by the map walkers, which drop the key instead.
+func (r *Redactor) redactSensitiveValue(key string, value any) any {
+	switch r.fieldPolicy(key) {
+	case RedactionPolicyHashSHA256:
+		if value == nil {
+			return r.opts.mask
+		}
+
+		return r.hashValue(value)
+	case RedactionPolicyPartial:
+		if str, ok := value.(string); ok {
+			return r.partialString(str)
+		}
+
+		return r.maskValue(value)
+	default:
+		return r.maskValue(value)
+	}
+}
+
+// redactSensitiveString is redactSensitiveValue for values that must stay strings.
+func (r *Redactor) redactSensitiveString(key, value string) string {
+	switch r.fieldPolicy(key) {
+	case RedactionPolicyHashSHA256:
+		return r.hashValue(value)
+	case RedactionPolicyPartial:
+		return r.partialString(value)
+	default:
+		return r.RedactString(value)
+	}
+}
+
+func (r *Redactor) hashValue(value any) string {
+	var data []byte
+
+	switch typed := value.(type) {
+	case string:
+		data = []byte(typed)
+	case []byte:
+		data = typed
+	default:
+		data = fmt.Append(nil, typed)
+	}
+
+	mac := hmac.New(sha256.New, r.opts.hashSalt)
+	_, _ = mac.Write(data)
+
+	return redactionHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r *Redactor) partialString(value string) string {
+	runes := []rune(value)
+	if len(runes) < redactionPartialMinVisible {
+		return r.RedactString(value)
+	}
+
+	return r.opts.mask + string(runes[len(runes)-redactionPartialVisible:])
+}
@@ -0,0 +1,281 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/hyp3rd/hyperlogger"
+
+	internalio "github.com/hyp3rd/sectools/internal/iosec"
+)
+
+const (
+	scanFileDefaultMaxSize = 10 << 20
+	scanFileReadChunk      = 32 << 10
+	// scanFileBinarySniff matches the prefix git inspects when deciding a file is binary.
+	scanFileBinarySniff = 8000
+)
+
+// ScanFileOption configures ScanFile.
+type ScanFileOption func(*scanFileOptions) error
+
+type scanFileOptions struct {
+	read          internalio.ReadOptions
+	includeBinary bool
+	baseline      *Baseline
+}
+
+// WithScanFileMaxSize sets the largest file ScanFile reads (default 10 MiB).
+// Larger files fail with iosec.ErrFileTooLarge.
+func WithScanFileMaxSize(maxBytes int64) ScanFileOption {
+	return func(cfg *scanFileOptions) error {
+		if maxBytes <= 0 {
+			return ErrInvalidSecretConfig
+		}
+
+		cfg.read.MaxSizeBytes = maxBytes
+
+		return nil
+	}
+}
+
+// WithScanFileBaseDir sets the directory relative paths are resolved against (default os.TempDir()).
+func WithScanFileBaseDir(dir string) ScanFileOption {
+	return func(cfg *scanFileOptions) error {
+		if strings.TrimSpace(dir) == "" {
+			return ErrInvalidSecretConfig
+		}
+
+		cfg.read.BaseDir = dir
+
+		return nil
+	}
+}
+
+// WithScanFileAllowedRoots permits absolute paths inside the given roots.
+func WithScanFileAllowedRoots(roots ...string) ScanFileOption {
+	return func(cfg *scanFileOptions) error {
+		if len(roots) == 0 {
+			return ErrInvalidSecretConfig
+		}
+
+		cfg.read.AllowedRoots = append([]string(nil), roots...)
+		cfg.read.AllowAbsolute = true
+
+		return nil
+	}
+}
+
+// WithScanFileIncludeBinary scans files that look binary instead of skipping them.
+func WithScanFileIncludeBinary() ScanFileOption {
+	return func(cfg *scanFileOptions) error {
+		cfg.includeBinary = true
+
+		return nil
+	}
+}
+
+// WithScanFileBaseline suppresses matches whose fingerprint is in baseline.
+func WithScanFileBaseline(baseline *Baseline) ScanFileOption {
+	return func(cfg *scanFileOptions) error {
+		if baseline == nil {
+			return ErrInvalidSecretConfig
+		}
+
+		cfg.baseline = baseline
+
+		return nil
+	}
+}
+
+// ScanFile streams the file at path through detector and returns every match, with Start and End
+// as byte offsets into the file. The file is opened with the iosec read policy (no traversal,
+// no symlinks, regular files only) and the max size is enforced while reading.
+// A NUL byte in the first 8000 bytes marks the file as binary; such files return no matches
+// unless WithScanFileIncludeBinary is set. Lines longer than the detector's max length are
+// scanned in overlapping windows, as in RedactingWriter. Each match has File set to path,
+// which is part of its Fingerprint, so scan with the same path form the baseline was built from.
+func ScanFile(path string, detector *SecretDetector, log hyperlogger.Logger, opts ...ScanFileOption) ([]SecretMatch, error) {
+	if detector == nil {
+		return nil, ErrInvalidSecretConfig
+	}
+
+	cfg := scanFileOptions{
+		read: internalio.ReadOptions{MaxSizeBytes: scanFileDefaultMaxSize},
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader, err := internalio.SecureOpenFileLimited(path, cfg.read, log)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		closeErr := reader.Close()
+		if closeErr != nil && log != nil {
+			log.WithError(closeErr).Errorf("failed to close file with path %v", path)
+		}
+	}()
+
+	scanner := &fileScanner{detector: detector, limit: detector.opts.maxLength, file: path}
+
+	binary, err := scanner.scan(reader, cfg.includeBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	if binary {
+		if log != nil {
+			log.WithField("file", path).Debug("Skipping binary file in secret scan")
+		}
+
+		return nil, nil
+	}
+
+	return cfg.baseline.Filter(scanner.matches), nil
+}
+
+// fileScanner buffers a stream by line and runs detection on each complete line.
+type fileScanner struct {
+	detector *SecretDetector
+	limit    int
+	file     string
+	buf      []byte
+	// offset is the position of buf[0] in the stream.
+	offset  int
+	matches []SecretMatch
+}
+
+// scan reads reader to EOF and reports whether it was skipped as binary.
+func (s *fileScanner) scan(reader io.Reader, includeBinary bool) (bool, error) {
+	chunk := make([]byte, scanFileReadChunk)
+	sniffed := 0
+
+	for {
+		n, err := reader.Read(chunk)
+		if n > 0 {
+			if !includeBinary && sniffed < scanFileBinarySniff {
+				window := chunk[:min(n, scanFileBinarySniff-sniffed)]
+				if bytes.IndexByte(window, 0) >= 0 {
+					return true, nil
+				}
+
+				sniffed += len(window)
+			}
+
+			s.buf = append(s.buf, chunk[:n]...)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return false, s.drain(true)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		// Hold lines back until the binary check is done, so a skipped file reports nothing.
+		if includeBinary || sniffed >= scanFileBinarySniff {
+			err = s.drain(false)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// drain detects complete lines and, for overlong lines, windows that can no longer grow into
+// a match. With final set, the remaining partial line is detected as well.
+func (s *fileScanner) drain(final bool) error {
+	for {
+		idx := bytes.IndexByte(s.buf, '\n')
+
+		switch {
+		case idx >= 0 && idx < s.limit:
+			err := s.detect(s.buf[:idx], idx+1)
+			if err != nil {
+				return err
+			}
+		case len(s.buf) >= s.limit:
+			err := s.detectWindow()
+			if err != nil {
+				return err
+			}
+		case final && len(s.buf) > 0:
+			return s.detect(s.buf, len(s.buf))
+		default:
+			return nil
+		}
+	}
+}
+
+// detect records the matches in line and drops consumed bytes from the buffer.
+func (s *fileScanner) detect(line []byte, consumed int) error {
+	matches, err := s.detector.Detect(string(line))
+	if err != nil {
+		return err
+	}
+
+	s.record(matches, len(line))
+	s.advance(consumed)
+
+	return nil
+}
+
+// detectWindow scans the first limit bytes of an overlong line. A tail sized by
+// SecretDetector.chunkTail is rescanned with the next window; the cut is moved back to the
+// start of any match that crosses it, and matches past the cut are left for the next window.
+func (s *fileScanner) detectWindow() error {
+	matches, err := s.detector.Detect(string(s.buf[:s.limit]))
+	if err != nil {
+		return err
+	}
+
+	cut := s.limit - s.detector.chunkTail(s.limit)
+
+	for _, match := range mergeSecretRanges(matches) {
+		if match.Start < cut && match.End > cut {
+			cut = match.Start
+		}
+	}
+
+	if cut <= 0 {
+		cut = s.limit
+	}
+
+	s.record(matches, cut)
+	s.advance(cut)
+
+	return nil
+}
+
+// record keeps matches that end within the first end bytes of the buffer, as stream offsets.
+func (s *fileScanner) record(matches []SecretMatch, end int) {
+	for _, match := range matches {
+		if match.End > end {
+			continue
+		}
+
+		match.Start += s.offset
+		match.End += s.offset
+		match.File = s.file
+		s.matches = append(s.matches, match)
+	}
+}
+
+func (s *fileScanner) advance(consumed int) {
+	s.buf = append(s.buf[:0], s.buf[consumed:]...)
+	s.offset += consumed
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	internalio "github.com/hyp3rd/sectools/internal/iosec"
+)
+
+func writeScanFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("expected file written, got %v", err)
+	}
+}
+
+func TestScanFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	content := "first line\nkey=AKIA1234567890ABCD12\n\ntoken=" + writerTestToken
+	writeScanFile(t, dir, "config.env", content)
+
+	detector, err := NewSecretDetector()
+	if err != nil {
+		t.Fatalf(errMsgDetector, err)
+	}
+
+	matches, err := ScanFile("config.env", detector, nil, WithScanFileBaseDir(dir))
+	if err != nil {
+		t.Fatalf("expected scan, got %v", err)
+	}
+
+	if len(matches) < 2 {
+		t.Fatalf("expected matches on two lines, got %+v", matches)
+	}
+
+	for _, match := range matches {
+		if content[match.Start:match.End] != match.Value {
+			t.Fatalf("expected file offsets for %+v, got %q", match, content[match.Start:match.End])
+		}
+	}
+
+	_, err = ScanFile(filepath.Join(dir, "config.env"), detector, nil, WithScanFileAllowedRoots(dir))
+	if err != nil {
+		t.Fatalf("expected absolute path inside allowed root, got %v", err)
+	}
+
+	_, err = ScanFile("../config.env", detector, nil, WithScanFileBaseDir(dir))
+	if err == nil {
+		t.Fatal("expected traversal to be rejected")
+	}
+
+	_, err = ScanFile("config.env", nil, nil)
+	if !errors.Is(err, ErrInvalidSecretConfig) {
+		t.Fatalf("expected ErrInvalidSecretConfig, got %v", err)
+	}
+}
+
+func TestScanFileBinaryAndSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScanFile(t, dir, "blob.bin", "\x00\x01key=AKIA1234567890ABCD12\n")
+
+	detector, err := NewSecretDetector()
+	if err != nil {
+		t.Fatalf(errMsgDetector, err)
+	}
+
+	matches, err := ScanFile("blob.bin", detector, nil, WithScanFileBaseDir(dir))
+	if err != nil || len(matches) != 0 {
+		t.Fatalf("expected binary file skipped, got %+v (%v)", matches, err)
+	}
+
+	matches, err = ScanFile("blob.bin", detector, nil, WithScanFileBaseDir(dir), WithScanFileIncludeBinary())
+	if err != nil || len(matches) == 0 {
+		t.Fatalf("expected binary file scanned when included, got %+v (%v)", matches, err)
+	}
+
+	_, err = ScanFile("blob.bin", detector, nil, WithScanFileBaseDir(dir), WithScanFileMaxSize(8))
+	if !errors.Is(err, internalio.ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+}
+
+func TestScanFileLongLine(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// The token straddles the first window boundary of a single line with no newline.
+	content := strings.Repeat("x ", testWriterMaxLen/2-10) + writerTestToken + strings.Repeat(" y", testWriterMaxLen)
+	writeScanFile(t, dir, "minified.js", content)
+
+	detector, err := NewSecretDetector(WithSecretMaxLength(testWriterMaxLen))
+	if err != nil {
+		t.Fatalf(errMsgDetector, err)
+	}
+
+	matches, err := ScanFile("minified.js", detector, nil, WithScanFileBaseDir(dir))
+	if err != nil {
+		t.Fatalf("expected scan, got %v", err)
+	}
+
+	found := 0
+
+	for _, match := range matches {
+		if match.Value == writerTestToken && content[match.Start:match.End] == writerTestToken {
+			found++
+		}
+	}
+
+	if found != 1 {
+		t.Fatalf("expected the token reported once, got %+v", matches)
+	}
+}
@@ -0,0 +1,160 @@
+package secrets
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// RedactingWriter redacts secrets from a byte stream before forwarding it to an underlying writer.
+// Output is buffered by line so secrets split across writes are still detected.
+// It is safe for concurrent use.
+type RedactingWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	detector *SecretDetector
+	buf      []byte
+	closed   bool
+}
+
+// NewRedactingWriter wraps w so every complete line is passed through detector.Redact.
+// Lines longer than the detector's max length are forwarded in chunks, retaining a tail as long
+// as the detector's longest possible match (at most half the max length) so secrets that straddle
+// a chunk boundary are not split. Call Close to flush a trailing partial line.
+func NewRedactingWriter(w io.Writer, detector *SecretDetector) (*RedactingWriter, error) {
+	if w == nil || detector == nil {
+		return nil, ErrInvalidSecretConfig
+	}
+
+	return &RedactingWriter{w: w, detector: detector}, nil
+}
+
+// Write buffers p and forwards the redacted form of every complete line.
+// Once p is buffered it is reported as written, even if forwarding fails, so a caller that
+// retries after an error does not write it twice.
+func (rw *RedactingWriter) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.closed {
+		return 0, ErrRedactingWriterClosed
+	}
+
+	rw.buf = append(rw.buf, p...)
+
+	err := rw.drain()
+	if err != nil {
+		return len(p), err
+	}
+
+	return len(p), nil
+}
+
+// Close redacts and flushes any buffered partial line. The underlying writer is not closed.
+func (rw *RedactingWriter) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.closed {
+		return nil
+	}
+
+	rw.closed = true
+
+	if len(rw.buf) == 0 {
+		return nil
+	}
+
+	err := rw.emit(rw.buf, false)
+	rw.buf = nil
+
+	return err
+}
+
+// drain forwards complete lines and, for overlong lines, chunks that can no longer grow into a match.
+func (rw *RedactingWriter) drain() error {
+	limit := rw.detector.opts.maxLength
+
+	for {
+		idx := bytes.IndexByte(rw.buf, '\n')
+
+		var err error
+
+		switch {
+		case idx >= 0 && idx < limit:
+			err = rw.emit(rw.buf[:idx], true)
+			rw.buf = append(rw.buf[:0], rw.buf[idx+1:]...)
+		case len(rw.buf) >= limit:
+			err = rw.emitChunk(limit)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// emit redacts line and writes it, followed by a newline when requested.
+func (rw *RedactingWriter) emit(line []byte, newline bool) error {
+	redacted, _, err := rw.detector.Redact(string(line))
+	if err != nil {
+		return err
+	}
+
+	if newline {
+		redacted += "\n"
+	}
+
+	_, err = io.WriteString(rw.w, redacted)
+
+	return err
+}
+
+// emitChunk forwards the redacted head of a line that exceeds limit bytes. A tail sized by
+// SecretDetector.chunkTail is retained; the cut is moved back to the start of any match that crosses it.
+func (rw *RedactingWriter) emitChunk(limit int) error {
+	window := string(rw.buf[:limit])
+
+	matches, err := rw.detector.Detect(window)
+	if err != nil {
+		return err
+	}
+
+	ranges := mergeSecretRanges(matches)
+	cut := limit - rw.detector.chunkTail(limit)
+
+	for _, match := range ranges {
+		if match.Start < cut && match.End > cut {
+			cut = match.Start
+		}
+	}
+
+	if cut <= 0 {
+		// The match fills the whole window; nothing can be retained, so mask it all.
+		cut = limit
+	}
+
+	kept := ranges[:0]
+
+	for _, match := range ranges {
+		if match.End <= cut {
+			kept = append(kept, match)
+		}
+	}
+
+	redacted := window[:cut]
+	if len(kept) > 0 {
+		redacted = redactMatches(redacted, kept, rw.detector.opts.mask)
+	}
+
+	_, err = io.WriteString(rw.w, redacted)
+	if err != nil {
+		return err
+	}
+
+	rw.buf = append(rw.buf[:0], rw.buf[cut:]...)
+
+	return nil
+}
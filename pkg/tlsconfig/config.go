@@ -1,14 +1,21 @@
 package tlsconfig
 
 import (
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io"
+	"math/big"
 	"strings"
+	"time"
+
+	"github.com/hyp3rd/sectools/internal/options"
 )
 
 const (
 	tlsDefaultMinVersion = tls.VersionTLS13
+	tlsDefaultMinRSABits = 2048
 )
 
 // Option configures TLS settings.
@@ -29,6 +36,11 @@ type config struct {
 	clientAuth           tls.ClientAuthType
 	insecureSkipVerify   bool
 	keyLogWriter         io.Writer
+	verifyConnection     []func(tls.ConnectionState) error
+	ticketRotator        *TicketKeyRotator
+	crlGrace             time.Duration
+	minRSABits           int
+	collect              bool
 }
 
 // NewClientConfig returns a TLS client config with safe defaults.
@@ -55,6 +67,7 @@ func NewClientConfig(opts ...Option) (*tls.Config, error) {
 		GetClientCertificate: cfg.getClientCertificate,
 		InsecureSkipVerify:   cfg.insecureSkipVerify, // #nosec G402 -- explicit opt-in for local/testing use.
 		KeyLogWriter:         cfg.keyLogWriter,
+		VerifyConnection:     chainVerifyConnection(cfg.verifyConnection),
 	}, nil
 }
 
@@ -75,7 +88,7 @@ func NewServerConfig(opts ...Option) (*tls.Config, error) {
 		return nil, err
 	}
 
-	return &tls.Config{
+	tlsCfg := &tls.Config{
 		MinVersion:               cfg.minVersion, // #nosec G402 -- validated against tls.VersionTLS12 in validateCommonConfig.
 		MaxVersion:               cfg.maxVersion,
 		CipherSuites:             cfg.cipherSuites,
@@ -87,7 +100,17 @@ func NewServerConfig(opts ...Option) (*tls.Config, error) {
 		ClientCAs:                cfg.clientCAs,
 		PreferServerCipherSuites: true,
 		KeyLogWriter:             cfg.keyLogWriter,
-	}, nil
+		VerifyConnection:         chainVerifyConnection(cfg.verifyConnection),
+	}
+
+	if cfg.ticketRotator != nil {
+		err = cfg.ticketRotator.Apply(tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tlsCfg, nil
 }
 
 // WithMinVersion sets the minimum TLS version.
@@ -278,6 +301,31 @@ func WithInsecureSkipVerify(allow bool) Option {
 	}
 }
 
+// WithCollectAllErrors makes constructors run every option and report all failures together
+// as an *errs.MultiError, instead of stopping at the first one.
+func WithCollectAllErrors() Option {
+	return func(cfg *config) error {
+		cfg.collect = true
+
+		return nil
+	}
+}
+
+// WithMinRSAKeySize sets the smallest accepted RSA key in bits for certificates from
+// WithCertificates and NewMTLSClientConfig (default 2048). Smaller keys return ErrTLSInvalidKeyPair;
+// values below 2048 return ErrInvalidTLSConfig. Certificates from callbacks are not checked.
+func WithMinRSAKeySize(bits int) Option {
+	return func(cfg *config) error {
+		if bits < tlsDefaultMinRSABits {
+			return ErrInvalidTLSConfig
+		}
+
+		cfg.minRSABits = bits
+
+		return nil
+	}
+}
+
 // WithKeyLogWriter enables TLS key logging for debugging.
 func WithKeyLogWriter(writer io.Writer) Option {
 	return func(cfg *config) error {
@@ -296,22 +344,37 @@ func applyOptions(opts []Option) (config, error) {
 		minVersion:       tlsDefaultMinVersion,
 		cipherSuites:     defaultCipherSuites(),
 		curvePreferences: defaultCurvePreferences(),
+		minRSABits:       tlsDefaultMinRSABits,
 	}
 
-	for _, opt := range opts {
-		if opt == nil {
-			continue
-		}
-
-		err := opt(&cfg)
-		if err != nil {
-			return config{}, err
-		}
+	err := options.Apply(&cfg, opts, func(cfg *config) bool { return cfg.collect })
+	if err != nil {
+		return config{}, err
 	}
 
 	return cfg, nil
 }
 
+// chainVerifyConnection runs each connection check in order and stops at the first failure.
+func chainVerifyConnection(checks []func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	checks = append([]func(tls.ConnectionState) error(nil), checks...)
+
+	return func(state tls.ConnectionState) error {
+		for _, check := range checks {
+			err := check(state)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func validateCommonConfig(cfg config) error {
 	if cfg.minVersion < tlsDefaultMinVersion {
 		return ErrTLSVersionTooLow
@@ -335,9 +398,58 @@ func validateCommonConfig(cfg config) error {
 		}
 	}
 
+	for _, cert := range cfg.certificates {
+		err := checkRSAKeySize(cert, cfg.minRSABits)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRSAKeySize rejects certificates whose RSA key is shorter than minBits.
+// An *rsa.PrivateKey is checked directly; any other key, such as an opaque crypto.Signer,
+// is checked through the leaf's public key.
+func checkRSAKeySize(cert tls.Certificate, minBits int) error {
+	var modulus *big.Int
+
+	if key, ok := cert.PrivateKey.(*rsa.PrivateKey); ok {
+		modulus = key.N
+	} else {
+		leaf, err := certificateLeaf(cert)
+		if err != nil {
+			return err
+		}
+
+		if leaf != nil {
+			if pub, ok := leaf.PublicKey.(*rsa.PublicKey); ok {
+				modulus = pub.N
+			}
+		}
+	}
+
+	if modulus != nil && modulus.BitLen() < minBits {
+		return fmt.Errorf("%w: rsa key is %d bits, minimum is %d", ErrTLSInvalidKeyPair, modulus.BitLen(), minBits)
+	}
+
 	return nil
 }
 
+// certificateLeaf returns cert.Leaf, parsing the first certificate in the chain when it is unset.
+func certificateLeaf(cert tls.Certificate) (*x509.Certificate, error) {
+	if cert.Leaf != nil || len(cert.Certificate) == 0 {
+		return cert.Leaf, nil
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidKeyPair, err)
+	}
+
+	return leaf, nil
+}
+
 func validateServerConfig(cfg config) error {
 	if len(cfg.certificates) == 0 && cfg.getCertificate == nil {
 		return ErrTLSMissingCertificate
@@ -1,15 +1,19 @@
 package tlsconfig
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"math/big"
 	"testing"
 	"time"
+
+	"github.com/hyp3rd/sectools/pkg/errs"
 )
 
 const errMsgUnexpected = "expected config, got %v"
@@ -89,6 +93,26 @@ func TestClientConfigRejectsWeakCipher(t *testing.T) {
 	}
 }
 
+func TestClientConfigCollectAllErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClientConfig(WithServerName(" "), WithRootCAs(nil))
+	if errors.As(err, new(*errs.MultiError)) {
+		t.Fatalf("expected first error only without collection, got %v", err)
+	}
+
+	_, err = NewClientConfig(WithServerName(" "), WithRootCAs(nil), WithKeyLogWriter(nil), WithCollectAllErrors())
+
+	var multi *errs.MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 3 {
+		t.Fatalf("expected three collected errors, got %v", err)
+	}
+
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig in collected errors, got %v", err)
+	}
+}
+
 func TestServerConfigRequiresCertificate(t *testing.T) {
 	t.Parallel()
 
@@ -138,6 +162,78 @@ func TestServerConfigClientAuthWithCAs(t *testing.T) {
 	}
 }
 
+func TestConfigMinRSAKeySize(t *testing.T) {
+	t.Parallel()
+
+	weak := testRSACertificate(t, 1024)
+	strong := testRSACertificate(t, tlsDefaultMinRSABits)
+
+	_, err := NewClientConfig(WithCertificates(weak))
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair, got %v", err)
+	}
+
+	_, err = NewServerConfig(WithCertificates(weak))
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair, got %v", err)
+	}
+
+	_, err = NewServerConfig(WithCertificates(strong))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	_, err = NewServerConfig(WithCertificates(strong), WithMinRSAKeySize(3072))
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair below raised minimum, got %v", err)
+	}
+
+	_, err = NewClientConfig(WithMinRSAKeySize(1024))
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+
+	// An opaque signer, as used for HSM or KMS keys, is checked through the parsed leaf.
+	weakKey, ok := weak.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("expected rsa key, got %T", weak.PrivateKey)
+	}
+
+	opaque := tls.Certificate{Certificate: weak.Certificate, PrivateKey: opaqueSigner{Signer: weakKey}}
+
+	_, err = NewServerConfig(WithCertificates(opaque))
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair for opaque signer, got %v", err)
+	}
+}
+
+type opaqueSigner struct {
+	crypto.Signer
+}
+
+func testRSACertificate(t *testing.T, bits int) tls.Certificate {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("expected cert, got %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privateKey}
+}
+
 func testCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
 	t.Helper()
 
@@ -0,0 +1,306 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	crlMaxBytes       = 16 << 20
+	crlPEMType        = "X509 CRL"
+	crlMinInterval    = time.Minute
+	crlDefaultTimeout = 30 * time.Second
+)
+
+// ParseCRL parses a PEM or DER encoded certificate revocation list.
+func ParseCRL(data []byte) (*x509.RevocationList, error) {
+	der := data
+
+	block, _ := pem.Decode(data)
+	if block != nil {
+		if block.Type != crlPEMType {
+			return nil, ErrTLSInvalidCRL
+		}
+
+		der = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCRL, err)
+	}
+
+	return crl, nil
+}
+
+// LoadCRLFile reads and parses a PEM or DER encoded CRL from disk.
+func LoadCRLFile(path string) (*x509.RevocationList, error) {
+	// #nosec G304 -- the CRL path is supplied by the caller's trusted configuration.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCRL, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	data, err := readCRL(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCRL(data)
+}
+
+// WithCRLVerification rejects peers whose leaf certificate is listed in one of the CRLs.
+// Only CRLs signed by the leaf's issuer in a verified chain are consulted, and the check runs
+// after normal chain verification rather than replacing it.
+func WithCRLVerification(crls ...*x509.RevocationList) Option {
+	return func(cfg *config) error {
+		if len(crls) == 0 {
+			return ErrInvalidTLSConfig
+		}
+
+		for _, crl := range crls {
+			if crl == nil {
+				return ErrInvalidTLSConfig
+			}
+		}
+
+		static := append([]*x509.RevocationList(nil), crls...)
+		cfg.verifyConnection = append(cfg.verifyConnection, func(state tls.ConnectionState) error {
+			// cfg is read at handshake time so WithCRLGracePeriod may come after this option.
+			return checkRevocation(state, static, time.Now(), cfg.crlGrace)
+		})
+
+		return nil
+	}
+}
+
+// WithCRLFetcher rejects peers revoked by the CRLs most recently fetched by fetcher.
+// When the fetched CRL has gone stale, the handshake error also carries the last refresh failure.
+func WithCRLFetcher(fetcher *CRLFetcher) Option {
+	return func(cfg *config) error {
+		if fetcher == nil {
+			return ErrInvalidTLSConfig
+		}
+
+		cfg.verifyConnection = append(cfg.verifyConnection, func(state tls.ConnectionState) error {
+			err := checkRevocation(state, fetcher.CRLs(), time.Now(), cfg.crlGrace)
+			if errors.Is(err, ErrTLSCRLExpired) {
+				lastErr := fetcher.LastError()
+				if lastErr != nil {
+					return fmt.Errorf("%w: last refresh failed: %w", err, lastErr)
+				}
+			}
+
+			return err
+		})
+
+		return nil
+	}
+}
+
+// WithCRLGracePeriod accepts CRLs for grace past their NextUpdate time before failing handshakes
+// with ErrTLSCRLExpired (default 0). It applies to WithCRLVerification and WithCRLFetcher.
+func WithCRLGracePeriod(grace time.Duration) Option {
+	return func(cfg *config) error {
+		if grace < 0 {
+			return ErrInvalidTLSConfig
+		}
+
+		cfg.crlGrace = grace
+
+		return nil
+	}
+}
+
+// CRLFetcher periodically downloads a CRL from a URL and keeps the last valid copy.
+// It is safe for concurrent use.
+type CRLFetcher struct {
+	url      string
+	client   *http.Client
+	interval time.Duration
+
+	mu      sync.RWMutex
+	current *x509.RevocationList
+	lastErr error
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCRLFetcher fetches the CRL at rawURL once and then refreshes it every interval.
+// A nil client uses an http.Client with a 30s timeout. Failed refreshes keep the previous CRL.
+func NewCRLFetcher(rawURL string, interval time.Duration, client *http.Client) (*CRLFetcher, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidTLSConfig
+	}
+
+	if interval < crlMinInterval {
+		return nil, ErrInvalidTLSConfig
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: crlDefaultTimeout}
+	}
+
+	fetcher := &CRLFetcher{
+		url:      parsed.String(),
+		client:   client,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	err = fetcher.Refresh(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	go fetcher.run()
+
+	return fetcher, nil
+}
+
+// CRLs returns the most recently fetched CRL.
+func (f *CRLFetcher) CRLs() []*x509.RevocationList {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.current == nil {
+		return nil
+	}
+
+	return []*x509.RevocationList{f.current}
+}
+
+// LastError returns the error from the most recent refresh, or nil if it succeeded.
+func (f *CRLFetcher) LastError() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.lastErr
+}
+
+// Refresh downloads and installs the CRL immediately.
+func (f *CRLFetcher) Refresh(ctx context.Context) error {
+	crl, err := f.fetch(ctx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastErr = err
+	if err == nil {
+		f.current = crl
+	}
+
+	return err
+}
+
+// Close stops background refreshes.
+func (f *CRLFetcher) Close() {
+	f.closeOnce.Do(func() {
+		close(f.stop)
+		<-f.done
+	})
+}
+
+func (f *CRLFetcher) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			_ = f.Refresh(context.Background())
+		}
+	}
+}
+
+func (f *CRLFetcher) fetch(ctx context.Context) (*x509.RevocationList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCRL, err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCRL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrTLSInvalidCRL, resp.StatusCode)
+	}
+
+	data, err := readCRL(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCRL(data)
+}
+
+func readCRL(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, crlMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCRL, err)
+	}
+
+	if len(data) > crlMaxBytes {
+		return nil, ErrTLSInvalidCRL
+	}
+
+	return data, nil
+}
+
+// checkRevocation reports ErrTLSRevoked when a verified leaf appears in a CRL signed by its issuer,
+// and ErrTLSCRLExpired when such a CRL is more than grace past its NextUpdate, since a stale CRL
+// would miss later revocations.
+func checkRevocation(state tls.ConnectionState, crls []*x509.RevocationList, now time.Time, grace time.Duration) error {
+	for _, chain := range state.VerifiedChains {
+		if len(chain) < 2 {
+			continue
+		}
+
+		leaf, issuer := chain[0], chain[1]
+
+		for _, crl := range crls {
+			if crl.CheckSignatureFrom(issuer) != nil {
+				continue
+			}
+
+			if !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate.Add(grace)) {
+				return fmt.Errorf("%w: next update was %s", ErrTLSCRLExpired, crl.NextUpdate.UTC().Format(time.RFC3339))
+			}
+
+			for _, entry := range crl.RevokedCertificateEntries {
+				if entry.SerialNumber != nil && entry.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+					return fmt.Errorf("%w: serial %s", ErrTLSRevoked, leaf.SerialNumber.String())
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,381 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testPKI struct {
+	caCert  *x509.Certificate
+	caKey   *ecdsa.PrivateKey
+	pool    *x509.CertPool
+	leaf    tls.Certificate
+	leafCrt *x509.Certificate
+}
+
+func TestCRLVerificationRejectsRevokedLeaf(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	crl := pki.revocationList(t, pki.leafCrt.SerialNumber)
+
+	clientCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLVerification(crl),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, clientCfg, pki.leaf)
+	if !errors.Is(err, ErrTLSRevoked) {
+		t.Fatalf("expected ErrTLSRevoked, got %v", err)
+	}
+}
+
+func TestCRLVerificationAllowsUnrevokedLeaf(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	crl := pki.revocationList(t, big.NewInt(9999))
+
+	clientCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLVerification(crl),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, clientCfg, pki.leaf)
+	if err != nil {
+		t.Fatalf("expected handshake, got %v", err)
+	}
+}
+
+func TestCRLVerificationIgnoresUntrustedSigner(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	other := newTestPKI(t)
+	crl := other.revocationList(t, pki.leafCrt.SerialNumber)
+
+	err := checkRevocation(tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{pki.leafCrt, pki.caCert}},
+	}, []*x509.RevocationList{crl}, time.Now(), 0)
+	if err != nil {
+		t.Fatalf("expected CRL from another issuer to be ignored, got %v", err)
+	}
+}
+
+func TestCRLVerificationRejectsStaleCRL(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	stale := pki.revocationListUntil(t, time.Now().Add(-time.Hour), big.NewInt(9999))
+
+	clientCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLVerification(stale),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, clientCfg, pki.leaf)
+	if !errors.Is(err, ErrTLSCRLExpired) {
+		t.Fatalf("expected ErrTLSCRLExpired, got %v", err)
+	}
+
+	graceCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLVerification(stale),
+		WithCRLGracePeriod(2*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, graceCfg, pki.leaf)
+	if err != nil {
+		t.Fatalf("expected handshake within the grace period, got %v", err)
+	}
+
+	_, err = NewClientConfig(WithCRLGracePeriod(-time.Second))
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+}
+
+func TestCRLFetcherStaleCRLReportsRefreshFailure(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	stale := pki.revocationListUntil(t, time.Now().Add(-time.Hour), big.NewInt(9999))
+
+	var failing atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write(stale.Raw)
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher, err := NewCRLFetcher(server.URL, time.Hour, server.Client())
+	if err != nil {
+		t.Fatalf("expected fetcher, got %v", err)
+	}
+	t.Cleanup(fetcher.Close)
+
+	failing.Store(true)
+
+	err = fetcher.Refresh(context.Background())
+	if err == nil {
+		t.Fatal("expected refresh failure")
+	}
+
+	clientCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLFetcher(fetcher),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, clientCfg, pki.leaf)
+	if !errors.Is(err, ErrTLSCRLExpired) || !strings.Contains(err.Error(), "last refresh failed") {
+		t.Fatalf("expected ErrTLSCRLExpired with the refresh failure, got %v", err)
+	}
+}
+
+func TestLoadCRLFileAndFetcher(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	crl := pki.revocationList(t, pki.leafCrt.SerialNumber)
+	encoded := pem.EncodeToMemory(&pem.Block{Type: crlPEMType, Bytes: crl.Raw})
+
+	path := filepath.Join(t.TempDir(), "ca.crl")
+
+	err := os.WriteFile(path, encoded, 0o600)
+	if err != nil {
+		t.Fatalf("expected crl file, got %v", err)
+	}
+
+	loaded, err := LoadCRLFile(path)
+	if err != nil {
+		t.Fatalf("expected loaded crl, got %v", err)
+	}
+
+	if len(loaded.RevokedCertificateEntries) != 1 {
+		t.Fatalf("expected one revoked entry, got %d", len(loaded.RevokedCertificateEntries))
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(crl.Raw)
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher, err := NewCRLFetcher(server.URL, time.Hour, server.Client())
+	if err != nil {
+		t.Fatalf("expected fetcher, got %v", err)
+	}
+	t.Cleanup(fetcher.Close)
+
+	err = fetcher.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("expected refresh, got %v", err)
+	}
+
+	clientCfg, err := NewClientConfig(
+		WithRootCAs(pki.pool),
+		WithServerName("localhost"),
+		WithCRLFetcher(fetcher),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	err = handshake(t, clientCfg, pki.leaf)
+	if !errors.Is(err, ErrTLSRevoked) {
+		t.Fatalf("expected ErrTLSRevoked, got %v", err)
+	}
+
+	_, err = NewCRLFetcher("ftp://example.com/ca.crl", time.Hour, nil)
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+}
+
+func handshake(t *testing.T, clientCfg *tls.Config, serverCert tls.Certificate) error {
+	t.Helper()
+
+	serverCfg, err := NewServerConfig(WithCertificates(serverCert))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	_, err = connect(t, clientCfg, serverCfg)
+
+	return err
+}
+
+// connect completes one handshake and reads a byte so post-handshake session tickets are processed.
+func connect(t *testing.T, clientCfg, serverCfg *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	if err != nil {
+		t.Fatalf("expected listener, got %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		conn, acceptErr := listener.Accept()
+		if acceptErr != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte{1})
+		_ = conn.Close()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientCfg)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Read(make([]byte, 1))
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	return conn.ConnectionState(), nil
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("expected ca cert, got %v", err)
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("expected parsed ca cert, got %v", err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	leafTemplate := x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, &leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("expected leaf cert, got %v", err)
+	}
+
+	leafCert, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatalf("expected parsed leaf cert, got %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	return testPKI{
+		caCert:  caCert,
+		caKey:   caKey,
+		pool:    pool,
+		leaf:    tls.Certificate{Certificate: [][]byte{leafDER}, PrivateKey: leafKey},
+		leafCrt: leafCert,
+	}
+}
+
+func (p testPKI) revocationList(t *testing.T, serials ...*big.Int) *x509.RevocationList {
+	t.Helper()
+
+	return p.revocationListUntil(t, time.Now().Add(time.Hour), serials...)
+}
+
+func (p testPKI) revocationListUntil(t *testing.T, nextUpdate time.Time, serials ...*big.Int) *x509.RevocationList {
+	t.Helper()
+
+	entries := make([]x509.RevocationListEntry, 0, len(serials))
+	for _, serial := range serials {
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: serial, RevocationTime: time.Now()})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                nextUpdate.Add(-2 * time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, p.caCert, p.caKey)
+	if err != nil {
+		t.Fatalf("expected crl, got %v", err)
+	}
+
+	crl, err := ParseCRL(der)
+	if err != nil {
+		t.Fatalf("expected parsed crl, got %v", err)
+	}
+
+	return crl
+}
@@ -17,4 +17,18 @@ var (
 	ErrTLSInvalidCurvePreferences = ewrap.New("tls curve preferences invalid")
 	// ErrTLSMissingClientCAs indicates client CAs are required for mTLS verification.
 	ErrTLSMissingClientCAs = ewrap.New("tls client ca required")
+	// ErrTLSRevoked indicates the peer certificate has been revoked.
+	ErrTLSRevoked = ewrap.New("tls certificate revoked")
+	// ErrTLSCRLExpired indicates a CRL is past its NextUpdate time plus the configured grace period.
+	ErrTLSCRLExpired = ewrap.New("tls crl expired")
+	// ErrTLSInvalidCRL indicates a certificate revocation list could not be loaded or parsed.
+	ErrTLSInvalidCRL = ewrap.New("tls crl invalid")
+	// ErrTLSMissingSNI indicates the client did not send a server name.
+	ErrTLSMissingSNI = ewrap.New("tls server name required")
+	// ErrTLSALPNNotAllowed indicates the negotiated application protocol is not allowed.
+	ErrTLSALPNNotAllowed = ewrap.New("tls alpn protocol not allowed")
+	// ErrTLSInvalidKeyPair indicates a certificate and key could not be loaded or are unusable.
+	ErrTLSInvalidKeyPair = ewrap.New("tls key pair invalid")
+	// ErrTLSInvalidCA indicates a CA bundle could not be loaded or contains no certificates.
+	ErrTLSInvalidCA = ewrap.New("tls ca invalid")
 )
@@ -0,0 +1,71 @@
+package tlsconfig
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	httpDialTimeout           = 30 * time.Second
+	httpKeepAlive             = 30 * time.Second
+	httpMaxIdleConns          = 100
+	httpIdleConnTimeout       = 90 * time.Second
+	httpTLSHandshakeTimeout   = 10 * time.Second
+	httpExpectContinueTimeout = time.Second
+	httpResponseHeaderTimeout = 30 * time.Second
+	httpReadHeaderTimeout     = 10 * time.Second
+	httpServerIdleTimeout     = 120 * time.Second
+)
+
+// NewHTTPTransport returns an http.Transport using a client config built by NewClientConfig.
+// The transport honors proxy environment variables, attempts HTTP/2, and sets dial,
+// handshake, idle, and response header timeouts.
+func NewHTTPTransport(opts ...Option) (*http.Transport, error) {
+	tlsCfg, err := NewClientConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   httpDialTimeout,
+		KeepAlive: httpKeepAlive,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsCfg,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          httpMaxIdleConns,
+		IdleConnTimeout:       httpIdleConnTimeout,
+		TLSHandshakeTimeout:   httpTLSHandshakeTimeout,
+		ExpectContinueTimeout: httpExpectContinueTimeout,
+		ResponseHeaderTimeout: httpResponseHeaderTimeout,
+	}, nil
+}
+
+// ApplyToServer sets srv.TLSConfig to a config built by NewServerConfig.
+// ReadHeaderTimeout and IdleTimeout are set when srv leaves them at zero; other fields are untouched.
+func ApplyToServer(srv *http.Server, opts ...Option) error {
+	if srv == nil {
+		return ErrInvalidTLSConfig
+	}
+
+	tlsCfg, err := NewServerConfig(opts...)
+	if err != nil {
+		return err
+	}
+
+	srv.TLSConfig = tlsCfg
+
+	if srv.ReadHeaderTimeout == 0 {
+		srv.ReadHeaderTimeout = httpReadHeaderTimeout
+	}
+
+	if srv.IdleTimeout == 0 {
+		srv.IdleTimeout = httpServerIdleTimeout
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package tlsconfig
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestHTTPTransportAndServer(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+
+	err := ApplyToServer(srv, WithCertificates(pki.leaf))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if srv.TLSConfig.MinVersion != tlsDefaultMinVersion || srv.ReadHeaderTimeout == 0 {
+		t.Fatalf("expected server defaults, got min %x read header timeout %v", srv.TLSConfig.MinVersion, srv.ReadHeaderTimeout)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected listener, got %v", err)
+	}
+
+	go func() {
+		_ = srv.ServeTLS(listener, "", "")
+	}()
+
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	transport, err := NewHTTPTransport(WithRootCAs(pki.pool), WithServerName("localhost"))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	t.Cleanup(transport.CloseIdleConnections)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://"+listener.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("expected request, got %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected response, got %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent || resp.ProtoMajor != 2 {
+		t.Fatalf("expected HTTP/2 204, got %s %d", resp.Proto, resp.StatusCode)
+	}
+}
+
+func TestHTTPHelpersRejectInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	err := ApplyToServer(nil)
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+
+	err = ApplyToServer(&http.Server{})
+	if !errors.Is(err, ErrTLSMissingCertificate) {
+		t.Fatalf("expected ErrTLSMissingCertificate, got %v", err)
+	}
+
+	_, err = NewHTTPTransport(WithMinVersion(0x0301))
+	if !errors.Is(err, ErrTLSVersionTooLow) {
+		t.Fatalf("expected ErrTLSVersionTooLow, got %v", err)
+	}
+}
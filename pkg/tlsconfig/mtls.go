@@ -0,0 +1,77 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// NewMTLSClientConfig returns a client config for mutual TLS. It loads the client key pair and
+// the root CA bundle from disk, sets the server name, and presents the certificate to servers.
+// The key pair must match, the leaf must be valid now and usable for client authentication,
+// and the CA file must contain at least one certificate. Extra options are applied afterwards.
+func NewMTLSClientConfig(certFile, keyFile, caFile, serverName string, opts ...Option) (*tls.Config, error) {
+	cert, err := loadClientKeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := loadCAPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	base := []Option{
+		WithCertificates(cert),
+		WithRootCAs(roots),
+		WithServerName(serverName),
+	}
+
+	return NewClientConfig(append(base, opts...)...)
+}
+
+func loadClientKeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("%w: %w", ErrTLSInvalidKeyPair, err)
+	}
+
+	leaf := cert.Leaf
+	if leaf == nil {
+		leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("%w: %w", ErrTLSInvalidKeyPair, err)
+		}
+	}
+
+	now := time.Now()
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return tls.Certificate{}, fmt.Errorf("%w: certificate is not valid at %s", ErrTLSInvalidKeyPair, now.UTC().Format(time.RFC3339))
+	}
+
+	if len(leaf.ExtKeyUsage) > 0 &&
+		!slices.Contains(leaf.ExtKeyUsage, x509.ExtKeyUsageClientAuth) &&
+		!slices.Contains(leaf.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return tls.Certificate{}, fmt.Errorf("%w: certificate is not valid for client authentication", ErrTLSInvalidKeyPair)
+	}
+
+	return cert, nil
+}
+
+func loadCAPool(caFile string) (*x509.CertPool, error) {
+	// #nosec G304 -- the CA path is supplied by the caller's trusted configuration.
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTLSInvalidCA, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrTLSInvalidCA
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,123 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMTLSClientConfigHandshake(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	dir := t.TempDir()
+	certFile, keyFile := pki.writeKeyPair(t, dir, x509.ExtKeyUsageClientAuth)
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", pki.caCert.Raw)
+
+	clientCfg, err := NewMTLSClientConfig(certFile, keyFile, caFile, "localhost")
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	serverCfg, err := NewServerConfig(
+		WithCertificates(pki.leaf),
+		WithClientAuth(tls.RequireAndVerifyClientCert),
+		WithClientCAs(pki.pool),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	_, err = connect(t, clientCfg, serverCfg)
+	if err != nil {
+		t.Fatalf("expected handshake, got %v", err)
+	}
+}
+
+func TestMTLSClientConfigRejectsMisconfiguration(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+	other := newTestPKI(t)
+	dir := t.TempDir()
+	certFile, keyFile := pki.writeKeyPair(t, dir, x509.ExtKeyUsageClientAuth)
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", pki.caCert.Raw)
+
+	_, otherKey := other.writeKeyPair(t, t.TempDir(), x509.ExtKeyUsageClientAuth)
+
+	_, err := NewMTLSClientConfig(certFile, otherKey, caFile, "localhost")
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair for mismatched key, got %v", err)
+	}
+
+	serverCert, serverKey := pki.writeKeyPair(t, t.TempDir(), x509.ExtKeyUsageServerAuth)
+
+	_, err = NewMTLSClientConfig(serverCert, serverKey, caFile, "localhost")
+	if !errors.Is(err, ErrTLSInvalidKeyPair) {
+		t.Fatalf("expected ErrTLSInvalidKeyPair for server-only certificate, got %v", err)
+	}
+
+	badCA := writePEM(t, dir, "bad.pem", "CRL", []byte("not a certificate"))
+
+	_, err = NewMTLSClientConfig(certFile, keyFile, badCA, "localhost")
+	if !errors.Is(err, ErrTLSInvalidCA) {
+		t.Fatalf("expected ErrTLSInvalidCA, got %v", err)
+	}
+
+	_, err = NewMTLSClientConfig(certFile, keyFile, caFile, " ")
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+}
+
+// writeKeyPair issues a certificate from the test CA with the given usage and writes it as PEM files.
+func (p testPKI) writeKeyPair(t *testing.T, dir string, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, p.caCert, &key.PublicKey, p.caKey)
+	if err != nil {
+		t.Fatalf("expected cert, got %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("expected key encoding, got %v", err)
+	}
+
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client.key", "PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatalf("expected pem file, got %v", err)
+	}
+
+	return path
+}
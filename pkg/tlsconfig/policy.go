@@ -0,0 +1,57 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithRequireSNI rejects handshakes where the client did not send a server name.
+func WithRequireSNI() Option {
+	return func(cfg *config) error {
+		cfg.verifyConnection = append(cfg.verifyConnection, func(state tls.ConnectionState) error {
+			if strings.TrimSpace(state.ServerName) == "" {
+				return fmt.Errorf("%w: client did not send a server name", ErrTLSMissingSNI)
+			}
+
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithRequireALPN rejects handshakes whose negotiated protocol is not in protos.
+// When no ALPN protocols are configured yet, protos are also advertised.
+func WithRequireALPN(protos ...string) Option {
+	return func(cfg *config) error {
+		allowed := make([]string, 0, len(protos))
+		for _, proto := range protos {
+			value := strings.TrimSpace(proto)
+			if value == "" {
+				return ErrInvalidTLSConfig
+			}
+
+			allowed = append(allowed, value)
+		}
+
+		if len(allowed) == 0 {
+			return ErrInvalidTLSConfig
+		}
+
+		if len(cfg.nextProtos) == 0 {
+			cfg.nextProtos = slices.Clone(allowed)
+		}
+
+		cfg.verifyConnection = append(cfg.verifyConnection, func(state tls.ConnectionState) error {
+			if !slices.Contains(allowed, state.NegotiatedProtocol) {
+				return fmt.Errorf("%w: negotiated %q, allowed %q", ErrTLSALPNNotAllowed, state.NegotiatedProtocol, allowed)
+			}
+
+			return nil
+		})
+
+		return nil
+	}
+}
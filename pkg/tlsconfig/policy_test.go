@@ -0,0 +1,67 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestConnectionPoliciesCompose(t *testing.T) {
+	t.Parallel()
+
+	pki := newTestPKI(t)
+
+	cfg, err := NewServerConfig(
+		WithCertificates(pki.leaf),
+		WithRequireSNI(),
+		WithRequireALPN("h2", "http/1.1"),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if !slices.Equal(cfg.NextProtos, []string{"h2", "http/1.1"}) {
+		t.Fatalf("expected ALPN protocols to be advertised, got %v", cfg.NextProtos)
+	}
+
+	cases := []struct {
+		name  string
+		state tls.ConnectionState
+		want  error
+	}{
+		{name: "allowed", state: tls.ConnectionState{ServerName: "example.com", NegotiatedProtocol: "h2"}},
+		{name: "missing sni", state: tls.ConnectionState{NegotiatedProtocol: "h2"}, want: ErrTLSMissingSNI},
+		{name: "no alpn", state: tls.ConnectionState{ServerName: "example.com"}, want: ErrTLSALPNNotAllowed},
+		{name: "other alpn", state: tls.ConnectionState{ServerName: "example.com", NegotiatedProtocol: "h3"}, want: ErrTLSALPNNotAllowed},
+	}
+
+	for _, tc := range cases {
+		err := cfg.VerifyConnection(tc.state)
+		if tc.want == nil && err != nil {
+			t.Fatalf("%s: expected no error, got %v", tc.name, err)
+		}
+
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestConnectionPoliciesKeepExplicitNextProtos(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewClientConfig(WithNextProtos("h2", "http/1.1"), WithRequireALPN("h2"))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	if !slices.Equal(cfg.NextProtos, []string{"h2", "http/1.1"}) {
+		t.Fatalf("expected explicit ALPN protocols, got %v", cfg.NextProtos)
+	}
+
+	_, err = NewClientConfig(WithRequireALPN(" "))
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package tlsconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const ticketKeyMinInterval = time.Minute
+
+// WithSessionTicketRotation encrypts session tickets on server configs with the rotator's keys.
+// The option has no effect on client configs.
+func WithSessionTicketRotation(rotator *TicketKeyRotator) Option {
+	return func(cfg *config) error {
+		if rotator == nil {
+			return ErrInvalidTLSConfig
+		}
+
+		cfg.ticketRotator = rotator
+
+		return nil
+	}
+}
+
+// TicketKeyRotator generates random session ticket keys and rotates them on an interval.
+// The previous key is kept so tickets issued before a rotation can still be decrypted.
+// It is safe for concurrent use.
+type TicketKeyRotator struct {
+	interval time.Duration
+	random   io.Reader
+
+	mu      sync.Mutex
+	keys    [][32]byte
+	lastErr error
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewTicketKeyRotator generates an initial key and rotates it every interval (minimum 1m).
+func NewTicketKeyRotator(interval time.Duration) (*TicketKeyRotator, error) {
+	if interval < ticketKeyMinInterval {
+		return nil, ErrInvalidTLSConfig
+	}
+
+	rotator := &TicketKeyRotator{
+		interval: interval,
+		random:   rand.Reader,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	err := rotator.rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	go rotator.run()
+
+	return rotator, nil
+}
+
+// Apply sets cfg.WrapSession and cfg.UnwrapSession so tickets are sealed with the rotator's current
+// key on every handshake. The hooks survive tls.Config.Clone, so servers that clone their config,
+// such as http.Server.ServeTLS, follow rotations too.
+func (r *TicketKeyRotator) Apply(cfg *tls.Config) error {
+	if cfg == nil {
+		return ErrInvalidTLSConfig
+	}
+
+	cfg.WrapSession = r.wrapSession
+	cfg.UnwrapSession = r.unwrapSession
+
+	return nil
+}
+
+// Close stops background rotation. Configs keep using the last keys.
+func (r *TicketKeyRotator) Close() {
+	r.closeOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+}
+
+func (r *TicketKeyRotator) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			// Failures are recorded for LastError; the current keys stay in use.
+			_ = r.rotate() //nolint:errcheck
+		}
+	}
+}
+
+// LastError returns the error from the most recent rotation, or nil if it succeeded.
+// After a failed rotation the previous keys stay in use until the next successful one.
+func (r *TicketKeyRotator) LastError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastErr
+}
+
+// rotate generates a new primary key and demotes the current one.
+func (r *TicketKeyRotator) rotate() error {
+	var key [32]byte
+
+	_, err := io.ReadFull(r.random, key[:])
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.lastErr = fmt.Errorf("%w: rotate session ticket key: %w", ErrInvalidTLSConfig, err)
+
+		return r.lastErr
+	}
+
+	r.lastErr = nil
+
+	keys := [][32]byte{key}
+	if len(r.keys) > 0 {
+		keys = append(keys, r.keys[0])
+	}
+
+	r.keys = keys
+
+	return nil
+}
+
+func (r *TicketKeyRotator) currentKeys() [][32]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.keys
+}
+
+// wrapSession seals the session state as nonce || AES-256-GCM(state) under the primary key.
+func (r *TicketKeyRotator) wrapSession(_ tls.ConnectionState, session *tls.SessionState) ([]byte, error) {
+	plaintext, err := session.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	defer clear(plaintext)
+
+	key := r.currentKeys()[0]
+
+	aead, err := ticketAEAD(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// unwrapSession opens a ticket with the primary or previous key. Tickets that no current key opens
+// return (nil, nil), which makes the server fall back to a full handshake.
+func (r *TicketKeyRotator) unwrapSession(identity []byte, _ tls.ConnectionState) (*tls.SessionState, error) {
+	for _, key := range r.currentKeys() {
+		aead, err := ticketAEAD(key[:])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(identity) < aead.NonceSize() {
+			return nil, nil //nolint:nilnil // tls.Config.UnwrapSession reports an unusable ticket as (nil, nil).
+		}
+
+		plaintext, err := aead.Open(nil, identity[:aead.NonceSize()], identity[aead.NonceSize():], nil)
+		if err != nil {
+			continue
+		}
+
+		// The parsed state aliases plaintext, so it must not be cleared here.
+		session, err := tls.ParseSessionState(plaintext)
+		if err != nil {
+			return nil, nil //nolint:nilerr,nilnil // an unparsable ticket only disables resumption.
+		}
+
+		return session, nil
+	}
+
+	return nil, nil //nolint:nilnil // no key opened the ticket; fall back to a full handshake.
+}
+
+func ticketAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
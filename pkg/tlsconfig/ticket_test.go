@@ -0,0 +1,197 @@
+package tlsconfig
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestTicketKeyRotatorKeepsPreviousKey(t *testing.T) {
+	t.Parallel()
+
+	rotator, err := NewTicketKeyRotator(time.Hour)
+	if err != nil {
+		t.Fatalf("expected rotator, got %v", err)
+	}
+	t.Cleanup(rotator.Close)
+
+	pki := newTestPKI(t)
+
+	serverCfg, err := NewServerConfig(
+		WithCertificates(pki.leaf),
+		WithSessionTicketRotation(rotator),
+	)
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	clientCfg, err := NewClientConfig(WithRootCAs(pki.pool), WithServerName("localhost"))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	clientCfg.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+
+	_, err = connect(t, clientCfg, serverCfg)
+	if err != nil {
+		t.Fatalf("expected handshake, got %v", err)
+	}
+
+	first := rotator.keys[0]
+
+	err = rotator.rotate()
+	if err != nil {
+		t.Fatalf("expected rotation, got %v", err)
+	}
+
+	if len(rotator.keys) != 2 || rotator.keys[1] != first || rotator.keys[0] == first {
+		t.Fatal("expected new primary key with previous key retained")
+	}
+
+	state, err := connect(t, clientCfg, serverCfg)
+	if err != nil {
+		t.Fatalf("expected handshake, got %v", err)
+	}
+
+	if !state.DidResume {
+		t.Fatal("expected session resumption with the previous ticket key")
+	}
+}
+
+func TestTicketKeyRotatorHTTPServer(t *testing.T) {
+	t.Parallel()
+
+	rotator, err := NewTicketKeyRotator(time.Hour)
+	if err != nil {
+		t.Fatalf("expected rotator, got %v", err)
+	}
+	t.Cleanup(rotator.Close)
+
+	pki := newTestPKI(t)
+
+	serverCfg, err := NewServerConfig(WithCertificates(pki.leaf), WithSessionTicketRotation(rotator))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected listener, got %v", err)
+	}
+
+	// ServeTLS clones TLSConfig, so rotations must reach the clone rather than serverCfg.
+	srv := &http.Server{
+		Handler:           http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+		TLSConfig:         serverCfg,
+		ReadHeaderTimeout: time.Second,
+	}
+
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+
+	t.Cleanup(func() { _ = srv.Close() })
+
+	clientCfg, err := NewClientConfig(WithRootCAs(pki.pool), WithServerName("localhost"))
+	if err != nil {
+		t.Fatalf(errMsgUnexpected, err)
+	}
+
+	clientCfg.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientCfg, DisableKeepAlives: true}}
+	serverURL := "https://" + listener.Addr().String()
+
+	resumed := func() bool {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, serverURL, http.NoBody)
+		if err != nil {
+			t.Fatalf("expected request, got %v", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("expected response, got %v", err)
+		}
+
+		_ = resp.Body.Close()
+
+		return resp.TLS.DidResume
+	}
+
+	if resumed() {
+		t.Fatal("expected a full first handshake")
+	}
+
+	err = rotator.rotate()
+	if err != nil {
+		t.Fatalf("expected rotation, got %v", err)
+	}
+
+	if !resumed() {
+		t.Fatal("expected resumption with the previous ticket key")
+	}
+
+	for range 2 {
+		err = rotator.rotate()
+		if err != nil {
+			t.Fatalf("expected rotation, got %v", err)
+		}
+	}
+
+	if resumed() {
+		t.Fatal("expected rotated-out ticket keys to be rejected by the running server")
+	}
+}
+
+func TestTicketKeyRotatorRecordsRotationFailure(t *testing.T) {
+	t.Parallel()
+
+	rotator, err := NewTicketKeyRotator(time.Hour)
+	if err != nil {
+		t.Fatalf("expected rotator, got %v", err)
+	}
+
+	// Stop the background loop so the test owns the reader.
+	rotator.Close()
+
+	if rotator.LastError() != nil {
+		t.Fatalf("expected no error after construction, got %v", rotator.LastError())
+	}
+
+	before := rotator.currentKeys()
+	rotator.random = iotest.ErrReader(errors.New("entropy unavailable"))
+
+	err = rotator.rotate()
+	if !errors.Is(err, ErrInvalidTLSConfig) || !errors.Is(rotator.LastError(), ErrInvalidTLSConfig) {
+		t.Fatalf("expected recorded rotation failure, got %v / %v", err, rotator.LastError())
+	}
+
+	if after := rotator.currentKeys(); len(after) != len(before) || after[0] != before[0] {
+		t.Fatal("expected keys unchanged after a failed rotation")
+	}
+
+	rotator.random = rand.Reader
+
+	err = rotator.rotate()
+	if err != nil || rotator.LastError() != nil {
+		t.Fatalf("expected successful rotation to clear the error, got %v / %v", err, rotator.LastError())
+	}
+}
+
+func TestTicketKeyRotatorRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTicketKeyRotator(time.Second)
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+
+	_, err = NewServerConfig(WithSessionTicketRotation(nil))
+	if !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Fatalf("expected ErrInvalidTLSConfig, got %v", err)
+	}
+}
@@ -15,4 +15,8 @@ var (
 	ErrTokenInvalid = ewrap.New("token is invalid")
 	// ErrTokenInsufficientEntropy indicates the token lacks required entropy.
 	ErrTokenInsufficientEntropy = ewrap.New("token entropy is insufficient")
+	// ErrTokenSignatureInvalid indicates the token signature does not match.
+	ErrTokenSignatureInvalid = ewrap.New("token signature is invalid")
+	// ErrTokenExpired indicates the token expiry has passed.
+	ErrTokenExpired = ewrap.New("token is expired")
 )
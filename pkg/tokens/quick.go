@@ -0,0 +1,22 @@
+package tokens
+
+import "sync"
+
+//nolint:gochecknoglobals // the default generator and validator are immutable and built once on first use.
+var defaultTokenPair = sync.OnceValues(func() (*TokenGenerator, *TokenValidator) {
+	cfg := defaultTokenOptions()
+
+	generator, err := newTokenGenerator(cfg)
+	if err != nil {
+		// The default options always need a positive byte count.
+		panic(err)
+	}
+
+	return generator, &TokenValidator{opts: cfg}
+})
+
+// NewDefault returns a shared generator and validator with the default options:
+// base64url encoding and 128 bits of entropy.
+func NewDefault() (*TokenGenerator, *TokenValidator) {
+	return defaultTokenPair()
+}
@@ -0,0 +1,208 @@
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/hyp3rd/sectools/internal/options"
+)
+
+const (
+	signerMinKeyBytes    = 32
+	signerExpiryBytes    = 8
+	signerTokenSeparator = "."
+	// signerMACContext separates Signer MACs from other HMAC uses of the same key.
+	signerMACContext = "sectools/tokens signer v1\x00"
+)
+
+// Format bytes lead every signed body, so tokens with and without an expiry cannot be confused.
+const (
+	signerFormatPlain byte = 1
+	signerFormatTTL   byte = 2
+)
+
+// SignerOption configures a Signer.
+type SignerOption func(*signerOptions) error
+
+type signerOptions struct {
+	ttl       time.Duration
+	maxLength int
+	now       func() time.Time
+	collect   bool
+}
+
+// Signer produces and verifies HMAC-SHA256 signed tokens of the form base64url(body).base64url(mac),
+// where body is a format byte, an optional big-endian expiry, and the payload.
+// Instances of Signer contain only immutable configuration and can be safely
+// used concurrently by multiple goroutines.
+type Signer struct {
+	key     []byte
+	context string
+	opts    signerOptions
+}
+
+// NewSigner constructs a signer with a key of at least 32 bytes.
+func NewSigner(key []byte, opts ...SignerOption) (*Signer, error) {
+	if len(key) < signerMinKeyBytes {
+		return nil, ErrInvalidTokenConfig
+	}
+
+	cfg := signerOptions{
+		maxLength: tokenDefaultMaxLength,
+		now:       time.Now,
+	}
+
+	err := options.Apply(&cfg, opts, func(cfg *signerOptions) bool { return cfg.collect })
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{key: append([]byte(nil), key...), context: signerMACContext, opts: cfg}, nil
+}
+
+// WithSignerCollectAllErrors makes NewSigner run every option and report all failures together
+// as an *errs.MultiError, instead of stopping at the first one. A short key is still reported alone.
+func WithSignerCollectAllErrors() SignerOption {
+	return func(cfg *signerOptions) error {
+		cfg.collect = true
+
+		return nil
+	}
+}
+
+// WithSignerTTL embeds an expiry timestamp in signed tokens and rejects them once it passes.
+// Signers that verify these tokens must be configured with a TTL as well.
+func WithSignerTTL(ttl time.Duration) SignerOption {
+	return func(cfg *signerOptions) error {
+		if ttl <= 0 {
+			return ErrInvalidTokenConfig
+		}
+
+		cfg.ttl = ttl
+
+		return nil
+	}
+}
+
+// WithSignerMaxLength sets the maximum accepted token length in characters.
+func WithSignerMaxLength(maxLength int) SignerOption {
+	return func(cfg *signerOptions) error {
+		if maxLength <= 0 {
+			return ErrInvalidTokenConfig
+		}
+
+		cfg.maxLength = maxLength
+
+		return nil
+	}
+}
+
+// WithSignerClock overrides the clock used for expiry.
+func WithSignerClock(now func() time.Time) SignerOption {
+	return func(cfg *signerOptions) error {
+		if now == nil {
+			return ErrInvalidTokenConfig
+		}
+
+		cfg.now = now
+
+		return nil
+	}
+}
+
+// Sign returns a signed token carrying payload. An empty payload is allowed.
+func (s *Signer) Sign(payload []byte) (string, error) {
+	header := 1
+	if s.opts.ttl > 0 {
+		header += signerExpiryBytes
+	}
+
+	body := make([]byte, header, header+len(payload))
+	body[0] = signerFormatPlain
+
+	if s.opts.ttl > 0 {
+		body[0] = signerFormatTTL
+		binary.BigEndian.PutUint64(body[1:], uint64(s.opts.now().Add(s.opts.ttl).Unix())) //nolint:gosec // expiry is after the epoch.
+	}
+
+	body = append(body, payload...)
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	token := encoded + signerTokenSeparator + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+
+	if len(token) > s.opts.maxLength {
+		return "", ErrTokenTooLong
+	}
+
+	return token, nil
+}
+
+// Verify checks the token signature in constant time and returns the payload.
+// Tokens signed with a different TTL setting return ErrTokenInvalid.
+func (s *Signer) Verify(token string) ([]byte, error) {
+	payload, _, err := s.verify(token)
+
+	return payload, err
+}
+
+// verify returns the payload and, for TTL signers, the expiry.
+func (s *Signer) verify(token string) ([]byte, time.Time, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, time.Time{}, ErrTokenEmpty
+	}
+
+	if len(token) > s.opts.maxLength {
+		return nil, time.Time{}, ErrTokenTooLong
+	}
+
+	encoded, sig, ok := strings.Cut(token, signerTokenSeparator)
+	if !ok || encoded == "" || strings.Contains(sig, signerTokenSeparator) {
+		return nil, time.Time{}, ErrTokenInvalid
+	}
+
+	// Strict decoding keeps tokens canonical, so each token has a single accepted spelling.
+	mac, err := base64.RawURLEncoding.Strict().DecodeString(sig)
+	if err != nil || len(mac) != sha256.Size {
+		return nil, time.Time{}, ErrTokenInvalid
+	}
+
+	if !hmac.Equal(mac, s.mac(encoded)) {
+		return nil, time.Time{}, ErrTokenSignatureInvalid
+	}
+
+	body, err := base64.RawURLEncoding.Strict().DecodeString(encoded)
+	if err != nil || len(body) == 0 {
+		return nil, time.Time{}, ErrTokenInvalid
+	}
+
+	if s.opts.ttl == 0 {
+		if body[0] != signerFormatPlain {
+			return nil, time.Time{}, ErrTokenInvalid
+		}
+
+		return body[1:], time.Time{}, nil
+	}
+
+	if body[0] != signerFormatTTL || len(body) < 1+signerExpiryBytes {
+		return nil, time.Time{}, ErrTokenInvalid
+	}
+
+	expiry := binary.BigEndian.Uint64(body[1 : 1+signerExpiryBytes])
+	if uint64(s.opts.now().Unix()) >= expiry { //nolint:gosec // current time is after the epoch.
+		return nil, time.Time{}, ErrTokenExpired
+	}
+
+	return body[1+signerExpiryBytes:], time.Unix(int64(expiry), 0), nil //nolint:gosec // expiry was signed by this key.
+}
+
+func (s *Signer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	_, _ = h.Write([]byte(s.context))
+	_, _ = h.Write([]byte(encoded))
+
+	return h.Sum(nil)
+}
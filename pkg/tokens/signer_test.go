@@ -0,0 +1,151 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const errMsgSigner = "expected signer, got %v"
+
+var testSignerKey = bytes.Repeat([]byte{0x42}, signerMinKeyBytes)
+
+func TestSignerSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	signer, err := NewSigner(testSignerKey)
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	token, err := signer.Sign([]byte("user:42"))
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	payload, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+
+	if string(payload) != "user:42" {
+		t.Fatalf("expected payload, got %q", payload)
+	}
+
+	tampered := "dXNlcjo0Mw" + token[strings.Index(token, signerTokenSeparator):]
+
+	_, err = signer.Verify(tampered)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+
+	other, err := NewSigner(bytes.Repeat([]byte{0x24}, signerMinKeyBytes))
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	_, err = other.Verify(token)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+
+	_, err = signer.Verify("no-separator")
+	if !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid, got %v", err)
+	}
+}
+
+func TestSignerTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	signer, err := NewSigner(testSignerKey, WithSignerTTL(time.Minute), WithSignerClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	token, err := signer.Sign([]byte("session"))
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	payload, err := signer.Verify(token)
+	if err != nil || string(payload) != "session" {
+		t.Fatalf("expected payload, got %q, %v", payload, err)
+	}
+
+	later, err := NewSigner(testSignerKey, WithSignerTTL(time.Minute), WithSignerClock(func() time.Time {
+		return now.Add(time.Minute)
+	}))
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	_, err = later.Verify(token)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestSignerFormatSeparation(t *testing.T) {
+	t.Parallel()
+
+	plain, err := NewSigner(testSignerKey)
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	withTTL, err := NewSigner(testSignerKey, WithSignerTTL(time.Hour))
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	for _, payload := range [][]byte{nil, {}} {
+		token, err := plain.Sign(payload)
+		if err != nil {
+			t.Fatalf("expected token for empty payload, got %v", err)
+		}
+
+		got, err := plain.Verify(token)
+		if err != nil || len(got) != 0 {
+			t.Fatalf("expected empty payload, got %q, %v", got, err)
+		}
+	}
+
+	ttlToken, err := withTTL.Sign([]byte("session"))
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	_, err = plain.Verify(ttlToken)
+	if !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid for a TTL token on a plain signer, got %v", err)
+	}
+
+	plainToken, err := plain.Sign([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	_, err = withTTL.Verify(plainToken)
+	if !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid for a plain token on a TTL signer, got %v", err)
+	}
+}
+
+func TestSignerInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSigner([]byte("short"))
+	if !errors.Is(err, ErrInvalidTokenConfig) {
+		t.Fatalf("expected ErrInvalidTokenConfig, got %v", err)
+	}
+
+	_, err = NewSigner(testSignerKey, WithSignerTTL(0))
+	if !errors.Is(err, ErrInvalidTokenConfig) {
+		t.Fatalf("expected ErrInvalidTokenConfig, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/hyp3rd/sectools/internal/options"
+	"github.com/hyp3rd/sectools/pkg/memory"
+)
+
+const (
+	timedDefaultRandomBytes = tokenDefaultMinEntropyBits / bitsPerByte
+	// timedMACContext keeps timed tokens apart from Signer tokens made with the same key.
+	timedMACContext = "sectools/tokens timed v1\x00"
+)
+
+// TimedOption configures a TimedGenerator.
+type TimedOption func(*timedOptions) error
+
+type timedOptions struct {
+	randomBytes int
+	now         func() time.Time
+	collect     bool
+}
+
+// TimedGenerator produces stateless, tamper-evident expiring tokens, such as password-reset links.
+// Tokens are Signer tokens with a TTL whose payload is random bytes, MACed under a context of their
+// own so a Signer sharing the key cannot mint or accept them.
+// Instances of TimedGenerator contain only immutable configuration and can be safely
+// used concurrently by multiple goroutines.
+type TimedGenerator struct {
+	signer      *Signer
+	randomBytes int
+}
+
+// NewTimedGenerator constructs a timed generator with a key of at least 32 bytes and a positive ttl.
+func NewTimedGenerator(key []byte, ttl time.Duration, opts ...TimedOption) (*TimedGenerator, error) {
+	if len(key) < signerMinKeyBytes || ttl <= 0 {
+		return nil, ErrInvalidTokenConfig
+	}
+
+	cfg := timedOptions{
+		randomBytes: timedDefaultRandomBytes,
+		now:         time.Now,
+	}
+
+	err := options.Apply(&cfg, opts, func(cfg *timedOptions) bool { return cfg.collect })
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &Signer{
+		key:     append([]byte(nil), key...),
+		context: timedMACContext,
+		opts:    signerOptions{ttl: ttl, maxLength: tokenDefaultMaxLength, now: cfg.now},
+	}
+
+	return &TimedGenerator{signer: signer, randomBytes: cfg.randomBytes}, nil
+}
+
+// WithTimedRandomBytes sets the number of random bytes per token (default 16, minimum 16).
+func WithTimedRandomBytes(n int) TimedOption {
+	return func(cfg *timedOptions) error {
+		if n < timedDefaultRandomBytes {
+			return ErrInvalidTokenConfig
+		}
+
+		cfg.randomBytes = n
+
+		return nil
+	}
+}
+
+// WithTimedClock overrides the clock used for expiry.
+func WithTimedClock(now func() time.Time) TimedOption {
+	return func(cfg *timedOptions) error {
+		if now == nil {
+			return ErrInvalidTokenConfig
+		}
+
+		cfg.now = now
+
+		return nil
+	}
+}
+
+// WithTimedCollectAllErrors makes NewTimedGenerator run every option and report all failures
+// together as an *errs.MultiError, instead of stopping at the first one.
+func WithTimedCollectAllErrors() TimedOption {
+	return func(cfg *timedOptions) error {
+		cfg.collect = true
+
+		return nil
+	}
+}
+
+// Generate returns a new token that expires after the configured ttl.
+func (g *TimedGenerator) Generate() (string, error) {
+	random := make([]byte, g.randomBytes)
+	defer memory.ZeroBytes(random)
+
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+
+	return g.signer.Sign(random)
+}
+
+// Validate checks the token MAC in constant time and returns the token's expiry.
+// Tampered tokens or tokens from another key return ErrTokenSignatureInvalid; expired tokens
+// return ErrTokenExpired.
+func (g *TimedGenerator) Validate(token string) (time.Time, error) {
+	random, expiry, err := g.signer.verify(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(random) != g.randomBytes {
+		return time.Time{}, ErrTokenInvalid
+	}
+
+	return expiry, nil
+}
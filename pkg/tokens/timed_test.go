@@ -0,0 +1,144 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const errMsgTimed = "expected timed generator, got %v"
+
+func TestTimedGenerator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	clock := func() time.Time { return now }
+
+	generator, err := NewTimedGenerator(testSignerKey, time.Hour, WithTimedClock(clock))
+	if err != nil {
+		t.Fatalf(errMsgTimed, err)
+	}
+
+	token, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	expiry, err := generator.Validate(token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+
+	if !expiry.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected expiry %v, got %v", now.Add(time.Hour), expiry)
+	}
+
+	second, err := generator.Generate()
+	if err != nil || second == token {
+		t.Fatalf("expected distinct tokens, got %q (%v)", second, err)
+	}
+
+	encoded, sig, _ := strings.Cut(token, signerTokenSeparator)
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("expected base64url token, got %v", err)
+	}
+
+	// Extending the expiry must break the MAC.
+	raw[signerExpiryBytes]++
+
+	_, err = generator.Validate(base64.RawURLEncoding.EncodeToString(raw) + signerTokenSeparator + sig)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+
+	other, err := NewTimedGenerator(bytes.Repeat([]byte{0x24}, signerMinKeyBytes), time.Hour, WithTimedClock(clock))
+	if err != nil {
+		t.Fatalf(errMsgTimed, err)
+	}
+
+	_, err = other.Validate(token)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+
+	_, err = generator.Validate(token)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("expected ErrTokenExpired, got %v", err)
+	}
+
+	for _, bad := range []string{"short", token + "A", token[:len(token)-1] + "!"} {
+		_, err = generator.Validate(bad)
+		if !errors.Is(err, ErrTokenInvalid) {
+			t.Fatalf("expected ErrTokenInvalid for %q, got %v", bad, err)
+		}
+	}
+
+	_, err = generator.Validate(" ")
+	if !errors.Is(err, ErrTokenEmpty) {
+		t.Fatalf("expected ErrTokenEmpty, got %v", err)
+	}
+}
+
+func TestTimedGeneratorSeparatedFromSigner(t *testing.T) {
+	t.Parallel()
+
+	withTTL, err := NewSigner(testSignerKey, WithSignerTTL(time.Hour))
+	if err != nil {
+		t.Fatalf(errMsgSigner, err)
+	}
+
+	timed, err := NewTimedGenerator(testSignerKey, time.Hour)
+	if err != nil {
+		t.Fatalf(errMsgTimed, err)
+	}
+
+	// A TTL signer token with a timed-sized payload must not pass as a timed token, or the reverse.
+	forged, err := withTTL.Sign(bytes.Repeat([]byte{0x01}, timedDefaultRandomBytes))
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	_, err = timed.Validate(forged)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid for a signer token, got %v", err)
+	}
+
+	timedToken, err := timed.Generate()
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	_, err = withTTL.Verify(timedToken)
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Fatalf("expected ErrTokenSignatureInvalid for a timed token, got %v", err)
+	}
+}
+
+func TestTimedGeneratorInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key  []byte
+		ttl  time.Duration
+		opts []TimedOption
+	}{
+		{key: []byte("short"), ttl: time.Hour},
+		{key: testSignerKey, ttl: 0},
+		{key: testSignerKey, ttl: time.Hour, opts: []TimedOption{WithTimedRandomBytes(8)}},
+		{key: testSignerKey, ttl: time.Hour, opts: []TimedOption{WithTimedClock(nil)}},
+	}
+
+	for i, tt := range tests {
+		_, err := NewTimedGenerator(tt.key, tt.ttl, tt.opts...)
+		if !errors.Is(err, ErrInvalidTokenConfig) {
+			t.Fatalf("case %d: expected ErrInvalidTokenConfig, got %v", i, err)
+		}
+	}
+}
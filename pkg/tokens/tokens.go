@@ -2,6 +2,7 @@ package tokens
 
 import (
 	"crypto/rand"
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -9,14 +10,21 @@ import (
 	"unicode"
 
 	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/sectools/internal/options"
+	"github.com/hyp3rd/sectools/pkg/memory"
 )
 
 const (
 	bitsPerByte                = 8
 	tokenDefaultMinEntropyBits = 128
 	tokenDefaultMaxLength      = 4096
+	crockfordAlphabet          = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
 )
 
+//nolint:gochecknoglobals // the encoding is immutable and shared.
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
 // TokenEncoding defines the string encoding for tokens.
 type TokenEncoding int
 
@@ -25,23 +33,31 @@ const (
 	TokenEncodingBase64URL TokenEncoding = iota
 	// TokenEncodingHex encodes tokens using hexadecimal encoding.
 	TokenEncodingHex
+	// TokenEncodingBase64Std encodes tokens using standard base64 encoding with padding.
+	TokenEncodingBase64Std
+	// TokenEncodingCrockfordBase32 encodes tokens using Crockford base32 without padding.
+	// Decoding is case-insensitive, maps O to 0 and I/L to 1, and ignores hyphens.
+	TokenEncodingCrockfordBase32
 )
 
 // TokenOption configures token generation and validation.
 type TokenOption func(*tokenOptions) error
 
 type tokenOptions struct {
-	encoding       TokenEncoding
-	minEntropyBits int
-	minBytes       int
-	maxLength      int
+	encoding        TokenEncoding
+	minEntropyBits  int
+	minBytes        int
+	maxLength       int
+	acceptAnyBase64 bool
+	collect         bool
 }
 
 // TokenGenerator generates cryptographically secure tokens.
-// Instances of TokenGenerator contain only immutable configuration and can be safely
-// used concurrently by multiple goroutines.
+// Instances of TokenGenerator contain only immutable configuration and a zeroing buffer
+// pool, and can be safely used concurrently by multiple goroutines.
 type TokenGenerator struct {
 	opts tokenOptions
+	pool *memory.BytePool
 }
 
 // TokenValidator validates token strings.
@@ -55,41 +71,39 @@ type TokenValidator struct {
 func NewGenerator(opts ...TokenOption) (*TokenGenerator, error) {
 	cfg := defaultTokenOptions()
 
-	for _, opt := range opts {
-		if opt == nil {
-			continue
-		}
-
-		err := opt(&cfg)
-		if err != nil {
-			return nil, err
-		}
+	err := options.Apply(&cfg, opts, func(cfg *tokenOptions) bool { return cfg.collect })
+	if err != nil {
+		return nil, err
 	}
 
-	err := validateTokenOptions(cfg)
+	err = validateTokenOptions(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TokenGenerator{opts: cfg}, nil
+	return newTokenGenerator(cfg)
+}
+
+// newTokenGenerator builds a generator with a buffer pool sized for cfg.
+func newTokenGenerator(cfg tokenOptions) (*TokenGenerator, error) {
+	pool, err := memory.NewBytePool(requiredBytes(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTokenConfig, err)
+	}
+
+	return &TokenGenerator{opts: cfg, pool: pool}, nil
 }
 
 // NewValidator constructs a token validator with safe defaults.
 func NewValidator(opts ...TokenOption) (*TokenValidator, error) {
 	cfg := defaultTokenOptions()
 
-	for _, opt := range opts {
-		if opt == nil {
-			continue
-		}
-
-		err := opt(&cfg)
-		if err != nil {
-			return nil, err
-		}
+	err := options.Apply(&cfg, opts, func(cfg *tokenOptions) bool { return cfg.collect })
+	if err != nil {
+		return nil, err
 	}
 
-	err := validateTokenOptions(cfg)
+	err = validateTokenOptions(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +111,20 @@ func NewValidator(opts ...TokenOption) (*TokenValidator, error) {
 	return &TokenValidator{opts: cfg}, nil
 }
 
+// WithTokenCollectAllErrors makes NewGenerator and NewValidator run every option and report all
+// failures together as an *errs.MultiError, instead of stopping at the first one.
+func WithTokenCollectAllErrors() TokenOption {
+	return func(cfg *tokenOptions) error {
+		cfg.collect = true
+
+		return nil
+	}
+}
+
 // WithTokenEncoding sets the token encoding.
 func WithTokenEncoding(encoding TokenEncoding) TokenOption {
 	return func(cfg *tokenOptions) error {
-		if encoding != TokenEncodingBase64URL && encoding != TokenEncodingHex {
+		if !isValidTokenEncoding(encoding) {
 			return ErrInvalidTokenConfig
 		}
 
@@ -149,11 +173,29 @@ func WithTokenMaxLength(maxLength int) TokenOption {
 	}
 }
 
+// WithTokenAcceptAnyBase64 makes validators accept URL-safe and standard base64, padded or not,
+// in addition to the configured encoding. Generators keep using the configured encoding.
+func WithTokenAcceptAnyBase64() TokenOption {
+	return func(cfg *tokenOptions) error {
+		cfg.acceptAnyBase64 = true
+
+		return nil
+	}
+}
+
 // Generate produces a new token encoded as a string.
+// The raw random bytes come from a pool and are zeroed once encoded.
 func (g *TokenGenerator) Generate() (string, error) {
-	raw, err := g.GenerateBytes()
+	if g.pool == nil {
+		return "", ErrInvalidTokenConfig
+	}
+
+	raw := g.pool.Get()
+	defer g.pool.Put(raw)
+
+	_, err := rand.Read(raw)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("generate token: %w", err)
 	}
 
 	token, err := encodeToken(raw, g.opts.encoding)
@@ -196,6 +238,10 @@ func (v *TokenValidator) Validate(token string) ([]byte, error) {
 	}
 
 	decoded, err := decodeToken(token, v.opts.encoding)
+	if err != nil && v.opts.acceptAnyBase64 {
+		decoded, err = decodeAnyBase64(token)
+	}
+
 	if err != nil {
 		return nil, ErrTokenInvalid
 	}
@@ -224,7 +270,7 @@ func validateTokenOptions(cfg tokenOptions) error {
 		return ErrInvalidTokenConfig
 	}
 
-	if cfg.encoding != TokenEncodingBase64URL && cfg.encoding != TokenEncodingHex {
+	if !isValidTokenEncoding(cfg.encoding) {
 		return ErrInvalidTokenConfig
 	}
 
@@ -237,9 +283,23 @@ func validateTokenOptions(cfg tokenOptions) error {
 		return ErrInvalidTokenConfig
 	}
 
+	// Padded standard base64 is the longest accepted form.
+	if cfg.acceptAnyBase64 && encodedLength(TokenEncodingBase64Std, required) > cfg.maxLength {
+		return ErrInvalidTokenConfig
+	}
+
 	return nil
 }
 
+func isValidTokenEncoding(encoding TokenEncoding) bool {
+	switch encoding {
+	case TokenEncodingBase64URL, TokenEncodingHex, TokenEncodingBase64Std, TokenEncodingCrockfordBase32:
+		return true
+	default:
+		return false
+	}
+}
+
 func requiredBytes(cfg tokenOptions) int {
 	required := cfg.minEntropyBits / bitsPerByte
 	if cfg.minEntropyBits%bitsPerByte != 0 {
@@ -259,6 +319,10 @@ func encodedLength(encoding TokenEncoding, bytes int) int {
 		return base64.RawURLEncoding.EncodedLen(bytes)
 	case TokenEncodingHex:
 		return hex.EncodedLen(bytes)
+	case TokenEncodingBase64Std:
+		return base64.StdEncoding.EncodedLen(bytes)
+	case TokenEncodingCrockfordBase32:
+		return crockfordEncoding.EncodedLen(bytes)
 	default:
 		return 0
 	}
@@ -270,6 +334,10 @@ func encodeToken(raw []byte, encoding TokenEncoding) (string, error) {
 		return base64.RawURLEncoding.EncodeToString(raw), nil
 	case TokenEncodingHex:
 		return hex.EncodeToString(raw), nil
+	case TokenEncodingBase64Std:
+		return base64.StdEncoding.EncodeToString(raw), nil
+	case TokenEncodingCrockfordBase32:
+		return crockfordEncoding.EncodeToString(raw), nil
 	default:
 		return "", ErrInvalidTokenConfig
 	}
@@ -290,12 +358,65 @@ func decodeToken(token string, encoding TokenEncoding) ([]byte, error) {
 			return nil, ewrap.Wrap(err, "failed to decode hex token")
 		}
 
+		return data, nil
+	case TokenEncodingBase64Std:
+		data, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "failed to decode base64 token")
+		}
+
+		return data, nil
+	case TokenEncodingCrockfordBase32:
+		data, err := crockfordEncoding.DecodeString(normalizeCrockford(token))
+		if err != nil {
+			return nil, ewrap.Wrap(err, "failed to decode crockford base32 token")
+		}
+
 		return data, nil
 	default:
 		return nil, ErrInvalidTokenConfig
 	}
 }
 
+// normalizeCrockford uppercases token, maps the ambiguous symbols O, I and L to their digits,
+// and drops hyphens used as visual separators.
+func normalizeCrockford(token string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-':
+			return -1
+		case 'O', 'o':
+			return '0'
+		case 'I', 'i', 'L', 'l':
+			return '1'
+		default:
+			return unicode.ToUpper(r)
+		}
+	}, token)
+}
+
+// decodeAnyBase64 tries the URL-safe and standard alphabets, unpadded and padded, in that fixed
+// order and returns the first success. Many tokens decode under several variants, but they then
+// yield the same bytes: a token valid in both alphabets uses none of +/-_, and one valid both
+// padded and unpadded has no '=' and needs none, so the order never changes the decoded value.
+func decodeAnyBase64(token string) ([]byte, error) {
+	encodings := []*base64.Encoding{
+		base64.RawURLEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.StdEncoding,
+	}
+
+	for _, encoding := range encodings {
+		data, err := encoding.DecodeString(token)
+		if err == nil {
+			return data, nil
+		}
+	}
+
+	return nil, ErrTokenInvalid
+}
+
 func containsSpace(value string) bool {
 	return strings.IndexFunc(value, unicode.IsSpace) >= 0
 }
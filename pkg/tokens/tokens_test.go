@@ -1,9 +1,14 @@
 package tokens
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/hyp3rd/sectools/pkg/errs"
+	"github.com/hyp3rd/sectools/pkg/memory"
 )
 
 const errMsgValidator = "expected validator, got %v"
@@ -131,3 +136,189 @@ func TestTokenValidateWhitespace(t *testing.T) {
 		t.Fatalf("expected ErrTokenInvalid, got %v", err)
 	}
 }
+
+func TestTokenGenerateBase64Std(t *testing.T) {
+	t.Parallel()
+
+	generator, err := NewGenerator(WithTokenEncoding(TokenEncodingBase64Std))
+	if err != nil {
+		t.Fatalf("expected generator, got %v", err)
+	}
+
+	token, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	if len(token) != base64.StdEncoding.EncodedLen(16) {
+		t.Fatalf("expected padded token, got %q", token)
+	}
+
+	validator, err := NewValidator(WithTokenEncoding(TokenEncodingBase64Std))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(token)
+	if err != nil {
+		t.Fatalf("expected token valid, got %v", err)
+	}
+}
+
+func TestTokenCrockfordBase32(t *testing.T) {
+	t.Parallel()
+
+	generator, err := NewGenerator(WithTokenEncoding(TokenEncodingCrockfordBase32))
+	if err != nil {
+		t.Fatalf("expected generator, got %v", err)
+	}
+
+	token, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	if strings.ContainsAny(token, "ILOU=") {
+		t.Fatalf("expected crockford alphabet, got %q", token)
+	}
+
+	validator, err := NewValidator(WithTokenEncoding(TokenEncodingCrockfordBase32))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	raw := bytes.Repeat([]byte{0x00, 0x42, 0x10, 0x84}, 4)
+
+	token, err = encodeToken(raw, TokenEncodingCrockfordBase32)
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	printed := strings.NewReplacer("0", "o", "1", "L").Replace(strings.ToLower(token))
+	printed = printed[:8] + "-" + printed[8:]
+
+	decoded, err := validator.Validate(printed)
+	if err != nil {
+		t.Fatalf("expected token valid, got %v", err)
+	}
+
+	if !bytes.Equal(decoded, raw) {
+		t.Fatalf("expected %x, got %x", raw, decoded)
+	}
+
+	_, err = validator.Validate("U" + token[1:])
+	if !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid, got %v", err)
+	}
+}
+
+func TestTokenValidateAcceptAnyBase64(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte{0xfb, 0xff, 0xbf, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d}
+
+	strict, err := NewValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = strict.Validate(base64.StdEncoding.EncodeToString(raw))
+	if !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid, got %v", err)
+	}
+
+	validator, err := NewValidator(WithTokenAcceptAnyBase64())
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	for _, encoding := range []*base64.Encoding{
+		base64.RawURLEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.StdEncoding,
+	} {
+		token := encoding.EncodeToString(raw)
+
+		decoded, err := validator.Validate(token)
+		if err != nil {
+			t.Fatalf("expected %q valid, got %v", token, err)
+		}
+
+		if string(decoded) != string(raw) {
+			t.Fatalf("expected decoded bytes for %q", token)
+		}
+	}
+
+	_, err = NewValidator(WithTokenAcceptAnyBase64(), WithTokenMaxLength(base64.RawURLEncoding.EncodedLen(16)))
+	if !errors.Is(err, ErrInvalidTokenConfig) {
+		t.Fatalf("expected ErrInvalidTokenConfig, got %v", err)
+	}
+}
+
+func TestTokenNewDefault(t *testing.T) {
+	t.Parallel()
+
+	generator, validator := NewDefault()
+
+	err := validateTokenOptions(generator.opts)
+	if err != nil {
+		t.Fatalf("expected valid default options, got %v", err)
+	}
+
+	token, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+
+	_, err = validator.Validate(token)
+	if err != nil {
+		t.Fatalf("expected token valid, got %v", err)
+	}
+
+	again, _ := NewDefault()
+	if again != generator {
+		t.Fatal("expected cached default generator")
+	}
+}
+
+func TestTokenGeneratorPoolError(t *testing.T) {
+	t.Parallel()
+
+	_, err := newTokenGenerator(tokenOptions{})
+	if !errors.Is(err, ErrInvalidTokenConfig) || !errors.Is(err, memory.ErrPoolSizeInvalid) {
+		t.Fatalf("expected pool error surfaced as ErrInvalidTokenConfig, got %v", err)
+	}
+}
+
+func TestTokenCollectAllErrors(t *testing.T) {
+	t.Parallel()
+
+	opts := []TokenOption{
+		WithTokenEncoding(TokenEncoding(99)),
+		nil,
+		WithTokenMaxLength(0),
+		WithTokenCollectAllErrors(),
+	}
+
+	_, err := NewGenerator(opts...)
+
+	var multi *errs.MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("expected two collected errors, got %v", err)
+	}
+
+	if !errors.Is(err, ErrInvalidTokenConfig) || !strings.Contains(err.Error(), "option 2") {
+		t.Fatalf("expected positioned ErrInvalidTokenConfig, got %v", err)
+	}
+
+	_, err = NewValidator(opts[:3]...)
+	if !errors.Is(err, ErrInvalidTokenConfig) || errors.As(err, &multi) {
+		t.Fatalf("expected first error only without collection, got %v", err)
+	}
+
+	_, err = NewSigner(testSignerKey, WithSignerTTL(0), WithSignerMaxLength(0), WithSignerCollectAllErrors())
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("expected two collected signer errors, got %v", err)
+	}
+}
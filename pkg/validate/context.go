@@ -0,0 +1,12 @@
+package validate
+
+import "context"
+
+// contextErr reports cancellation for CPU-bound checks; a nil context never cancels.
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Err()
+}
@@ -7,8 +7,11 @@ import (
 	"net"
 	"net/mail"
 	"strings"
+	"time"
+	"unicode"
 	"unicode/utf8"
 
+	"github.com/hyp3rd/hyperlogger"
 	"golang.org/x/net/idna"
 )
 
@@ -25,6 +28,8 @@ const (
 
 	emailDot = '.'
 	emailAt  = '@'
+
+	emailUnlimitedDots = -1
 )
 
 // DNSResolver abstracts DNS lookups for email validation.
@@ -41,22 +46,38 @@ type emailOptions struct {
 	allowQuotedLocal     bool
 	allowIPLiteral       bool
 	allowIDN             bool
+	idnaProfile          *idna.Profile
+	rejectMixedScript    bool
 	requireTLD           bool
 	verifyDomain         bool
 	requireMX            bool
 	allowARecordFallback bool
+	allowConsecutiveDots bool
+	allowUTF8Local       bool
+	maxLocalDots         int
+	extractPlusTag       bool
+	freeProviders        map[string]struct{}
 	resolver             DNSResolver
+	dnsRetries           int
+	dnsBackoff           time.Duration
+	logger               hyperlogger.Logger
 }
 
 // EmailResult contains normalized email details.
+// Raw is the input exactly as passed to Validate, before trimming or display-name removal.
+// BaseLocalPart and Tag are set only when WithEmailPlusTagExtraction is enabled.
 type EmailResult struct {
+	Raw            string
 	Address        string
 	LocalPart      string
+	BaseLocalPart  string
+	Tag            string
 	Domain         string
 	DomainASCII    string
 	DomainVerified bool
 	VerifiedByMX   bool
 	VerifiedByA    bool
+	FreeProvider   bool
 }
 
 // EmailValidator validates email addresses with optional DNS checks.
@@ -68,7 +89,9 @@ type EmailValidator struct {
 func NewEmailValidator(opts ...EmailOption) (*EmailValidator, error) {
 	cfg := emailOptions{
 		requireTLD:           true,
+		idnaProfile:          idna.Lookup,
 		allowARecordFallback: true,
+		maxLocalDots:         emailUnlimitedDots,
 	}
 
 	for _, opt := range opts {
@@ -86,6 +109,24 @@ func NewEmailValidator(opts ...EmailOption) (*EmailValidator, error) {
 		cfg.resolver = net.DefaultResolver
 	}
 
+	if cfg.resolver != nil && cfg.dnsRetries > 0 {
+		cfg.resolver = &retryingResolver{
+			base:    cfg.resolver,
+			retries: cfg.dnsRetries,
+			backoff: cfg.dnsBackoff,
+			retryIf: isTemporaryDNSError,
+		}
+	}
+
+	if cfg.freeProviders == nil {
+		providers, err := normalizeProviderDomains(defaultFreeProviders())
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.freeProviders = providers
+	}
+
 	return &EmailValidator{opts: cfg}, nil
 }
 
@@ -107,6 +148,42 @@ func WithEmailAllowQuotedLocal(allow bool) EmailOption {
 	}
 }
 
+// WithEmailAllowConsecutiveDots permits empty dot-atom segments such as "first..last" in the
+// local part. Leading and trailing dots are still rejected. Such addresses violate RFC 5322 and
+// many mail servers refuse them, so enable this only for systems known to accept them.
+func WithEmailAllowConsecutiveDots(allow bool) EmailOption {
+	return func(cfg *emailOptions) error {
+		cfg.allowConsecutiveDots = allow
+
+		return nil
+	}
+}
+
+// WithEmailMaxLocalDots limits the number of dots in an unquoted local part; 0 forbids dots.
+// The local-part and address length limits still apply.
+func WithEmailMaxLocalDots(maxDots int) EmailOption {
+	return func(cfg *emailOptions) error {
+		if maxDots < 0 {
+			return ErrInvalidEmailConfig
+		}
+
+		cfg.maxLocalDots = maxDots
+
+		return nil
+	}
+}
+
+// WithEmailPlusTagExtraction splits unquoted local parts such as "user+newsletter" into
+// EmailResult.BaseLocalPart ("user") and EmailResult.Tag ("newsletter") at the first "+".
+// Quoted local parts are never split; BaseLocalPart is the full local part and Tag is empty.
+func WithEmailPlusTagExtraction(enable bool) EmailOption {
+	return func(cfg *emailOptions) error {
+		cfg.extractPlusTag = enable
+
+		return nil
+	}
+}
+
 // WithEmailAllowIPLiteral permits [ip] literal domains.
 func WithEmailAllowIPLiteral(allow bool) EmailOption {
 	return func(cfg *emailOptions) error {
@@ -125,6 +202,46 @@ func WithEmailAllowIDN(allow bool) EmailOption {
 	}
 }
 
+// WithEmailIDNAProfile sets the IDNA profile used to convert IDN domains to ASCII (default idna.Lookup).
+// It only applies when WithEmailAllowIDN is enabled. Looser profiles accept labels that idna.Lookup
+// rejects, which makes look-alike domains easier to register and pass; the ASCII form in
+// EmailResult.DomainASCII is what should be compared and stored.
+func WithEmailIDNAProfile(profile *idna.Profile) EmailOption {
+	return func(cfg *emailOptions) error {
+		if profile == nil {
+			return ErrInvalidEmailConfig
+		}
+
+		cfg.idnaProfile = profile
+
+		return nil
+	}
+}
+
+// WithEmailRejectMixedScripts rejects domains with a label that mixes Unicode scripts, such as
+// Latin and Cyrillic, with ErrEmailDomainInvalid. Labels are checked after IDN normalization,
+// including domains given in punycode form.
+func WithEmailRejectMixedScripts() EmailOption {
+	return func(cfg *emailOptions) error {
+		cfg.rejectMixedScript = true
+
+		return nil
+	}
+}
+
+// WithEmailAllowUTF8LocalPart accepts internationalized local parts (EAI, RFC 6531), such as
+// "用户@例え.jp" together with WithEmailAllowIDN. Non-ASCII characters must be letters, marks,
+// or digits; controls, invisible format characters, and non-ASCII punctuation or symbols
+// (for example a fullwidth "＠") are rejected, and ASCII characters follow the usual atext rules.
+// Mail to such addresses only works if every system that handles it supports SMTPUTF8.
+func WithEmailAllowUTF8LocalPart() EmailOption {
+	return func(cfg *emailOptions) error {
+		cfg.allowUTF8Local = true
+
+		return nil
+	}
+}
+
 // WithEmailRequireTLD requires a dot in the domain part.
 func WithEmailRequireTLD(require bool) EmailOption {
 	return func(cfg *emailOptions) error {
@@ -161,6 +278,22 @@ func WithEmailAllowARecordFallback(allow bool) EmailOption {
 	}
 }
 
+// WithEmailDNSRetries retries MX and A/AAAA lookups up to attempts extra times (1-5) when they
+// fail with a temporary DNS error or timeout, waiting backoff before the first retry and doubling
+// it afterwards. Not-found answers are never retried, and no retry starts past the ctx deadline.
+func WithEmailDNSRetries(attempts int, backoff time.Duration) EmailOption {
+	return func(cfg *emailOptions) error {
+		if attempts <= 0 || attempts > resolverMaxRetries || backoff <= 0 {
+			return ErrInvalidEmailConfig
+		}
+
+		cfg.dnsRetries = attempts
+		cfg.dnsBackoff = backoff
+
+		return nil
+	}
+}
+
 // WithEmailDNSResolver sets a custom DNS resolver.
 func WithEmailDNSResolver(resolver DNSResolver) EmailOption {
 	return func(cfg *emailOptions) error {
@@ -176,6 +309,20 @@ func WithEmailDNSResolver(resolver DNSResolver) EmailOption {
 
 // Validate validates an email address and optionally verifies its domain.
 func (v *EmailValidator) Validate(ctx context.Context, input string) (EmailResult, error) {
+	started := time.Now()
+
+	result, err := v.validate(ctx, input)
+	v.logResult(logEmailDomain(input), err, started)
+
+	return result, err
+}
+
+func (v *EmailValidator) validate(ctx context.Context, input string) (EmailResult, error) {
+	err := contextErr(ctx)
+	if err != nil {
+		return EmailResult{}, err
+	}
+
 	trimmed, err := normalizeEmailInput(input)
 	if err != nil {
 		return EmailResult{}, err
@@ -196,16 +343,24 @@ func (v *EmailValidator) Validate(ctx context.Context, input string) (EmailResul
 		return EmailResult{}, err
 	}
 
-	domainInfo, err := v.validateDomain(domain)
+	domainInfo, err := v.validateDomain(ctx, domain)
 	if err != nil {
 		return EmailResult{}, err
 	}
 
+	_, free := v.opts.freeProviders[domainInfo.ascii]
+
 	result := EmailResult{
-		Address:     address,
-		LocalPart:   localPart,
-		Domain:      domainInfo.normalized,
-		DomainASCII: domainInfo.ascii,
+		Raw:          input,
+		Address:      address,
+		LocalPart:    localPart,
+		Domain:       domainInfo.normalized,
+		DomainASCII:  domainInfo.ascii,
+		FreeProvider: free,
+	}
+
+	if v.opts.extractPlusTag {
+		result.BaseLocalPart, result.Tag = splitPlusTag(localPart)
 	}
 
 	err = v.applyDomainVerification(ctx, domainInfo, &result)
@@ -252,7 +407,7 @@ func (v *EmailValidator) validateLocalPart(local string) error {
 		return ErrEmailLocalPartTooLong
 	}
 
-	return validateLocalPartSyntax(local, v.opts.allowQuotedLocal)
+	return validateLocalPartSyntax(local, v.opts)
 }
 
 type emailDomainInfo struct {
@@ -261,33 +416,37 @@ type emailDomainInfo struct {
 	isIPLiteral bool
 }
 
-func (v *EmailValidator) validateDomain(domain string) (emailDomainInfo, error) {
-	domainInfo, err := normalizeDomain(domain, v.opts.allowIDN)
+func (v *EmailValidator) validateDomain(ctx context.Context, domain string) (emailDomainInfo, error) {
+	domainInfo, err := normalizeDomain(domain, v.opts.allowIDN, v.opts.idnaProfile)
 	if err != nil {
-		return emailDomainInfo{}, err
+		return emailDomainInfo{}, newValidationError(err, FieldDomain, domain)
 	}
 
 	if domainInfo.isIPLiteral {
 		if !v.opts.allowIPLiteral {
-			return emailDomainInfo{}, ErrEmailIPLiteralNotAllowed
+			return emailDomainInfo{}, newValidationError(ErrEmailIPLiteralNotAllowed, FieldDomain, domain)
 		}
 
 		return domainInfo, nil
 	}
 
 	if len(domainInfo.ascii) > emailMaxDomainLength {
-		return emailDomainInfo{}, ErrEmailDomainTooLong
+		return emailDomainInfo{}, newValidationError(ErrEmailDomainTooLong, FieldDomain, domain)
 	}
 
 	if v.opts.requireTLD && !strings.ContainsRune(domainInfo.ascii, emailDot) {
-		return emailDomainInfo{}, ErrEmailDomainInvalid
+		return emailDomainInfo{}, newValidationError(ErrEmailDomainInvalid, FieldDomain, domain)
 	}
 
-	err = validateDomainLabels(domainInfo.ascii)
+	err = validateDomainLabels(ctx, domainInfo.ascii)
 	if err != nil {
 		return emailDomainInfo{}, err
 	}
 
+	if v.opts.rejectMixedScript && hasMixedScriptLabel(domainInfo.ascii) {
+		return emailDomainInfo{}, newValidationError(ErrEmailDomainInvalid, FieldDomain, domain)
+	}
+
 	return domainInfo, nil
 }
 
@@ -300,7 +459,17 @@ func (v *EmailValidator) applyDomainVerification(ctx context.Context, domainInfo
 		return ErrEmailInvalid
 	}
 
+	started := time.Now()
+
 	verification, err := v.verifyDomain(ctx, domainInfo.ascii)
+	v.logDebug("email dns verification",
+		hyperlogger.Field{Key: "domain", Value: domainInfo.ascii},
+		hyperlogger.Field{Key: "verified", Value: verification.verified},
+		hyperlogger.Field{Key: "mx", Value: verification.byMX},
+		hyperlogger.Field{Key: "a", Value: verification.byA},
+		hyperlogger.Field{Key: "duration", Value: time.Since(started)},
+	)
+
 	if err != nil {
 		return err
 	}
@@ -334,9 +503,9 @@ func splitEmail(address string) (local, domain string, err error) {
 	return local, domain, nil
 }
 
-func validateLocalPartSyntax(local string, allowQuoted bool) error {
+func validateLocalPartSyntax(local string, opts emailOptions) error {
 	if isQuoted(local) {
-		if !allowQuoted {
+		if !opts.allowQuotedLocal {
 			return ErrEmailQuotedLocalPart
 		}
 
@@ -347,13 +516,28 @@ func validateLocalPartSyntax(local string, allowQuoted bool) error {
 		return nil
 	}
 
-	if !isDotAtom(local) {
+	if !isDotAtom(local, opts.allowConsecutiveDots, opts.allowUTF8Local) {
+		return ErrEmailLocalPartInvalid
+	}
+
+	if opts.maxLocalDots != emailUnlimitedDots && strings.Count(local, string(emailDot)) > opts.maxLocalDots {
 		return ErrEmailLocalPartInvalid
 	}
 
 	return nil
 }
 
+// splitPlusTag returns the local part before the first "+" and the tag after it.
+func splitPlusTag(local string) (base, tag string) {
+	if isQuoted(local) {
+		return local, ""
+	}
+
+	base, tag, _ = strings.Cut(local, "+")
+
+	return base, tag
+}
+
 func isQuoted(local string) bool {
 	return len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"'
 }
@@ -372,7 +556,7 @@ func isValidQuotedLocal(local string) bool {
 	return true
 }
 
-func isDotAtom(local string) bool {
+func isDotAtom(local string, allowConsecutiveDots, allowUTF8 bool) bool {
 	if len(local) == 0 {
 		return false
 	}
@@ -384,12 +568,20 @@ func isDotAtom(local string) bool {
 	parts := strings.SplitSeq(local, ".")
 	for part := range parts {
 		if part == "" {
+			if allowConsecutiveDots {
+				continue
+			}
+
 			return false
 		}
 
 		for _, r := range part {
-			if r > utf8.RuneSelf {
-				return false
+			if r >= utf8.RuneSelf {
+				if !allowUTF8 || !isUTF8Atext(r) {
+					return false
+				}
+
+				continue
 			}
 
 			if !isAtext(r) {
@@ -422,7 +614,18 @@ func isAtext(char rune) bool {
 	}
 }
 
-func normalizeDomain(domain string, allowIDN bool) (emailDomainInfo, error) {
+// isUTF8Atext reports whether a non-ASCII rune may appear in an RFC 6531 local part.
+// RFC 6531 allows any non-ASCII character; this is narrowed to letters, marks, and digits.
+// utf8.RuneError covers invalid encodings as well as a literal U+FFFD.
+func isUTF8Atext(char rune) bool {
+	if char == utf8.RuneError {
+		return false
+	}
+
+	return unicode.In(char, unicode.L, unicode.M, unicode.N)
+}
+
+func normalizeDomain(domain string, allowIDN bool, profile *idna.Profile) (emailDomainInfo, error) {
 	normalized := strings.TrimSuffix(domain, string(emailDot))
 	if normalized == "" {
 		return emailDomainInfo{}, ErrEmailDomainInvalid
@@ -447,7 +650,7 @@ func normalizeDomain(domain string, allowIDN bool) (emailDomainInfo, error) {
 			return emailDomainInfo{}, ErrEmailIDNNotAllowed
 		}
 
-		converted, err := idna.Lookup.ToASCII(normalized)
+		converted, err := profile.ToASCII(normalized)
 		if err != nil {
 			return emailDomainInfo{}, ErrEmailDomainInvalid
 		}
@@ -466,12 +669,14 @@ func normalizeDomain(domain string, allowIDN bool) (emailDomainInfo, error) {
 func parseIPLiteral(literal string) net.IP {
 	lower := strings.ToLower(literal)
 	if strings.HasPrefix(lower, emailIPv6LiteralPrefix) {
-		value := literal[len(emailIPv6LiteralPrefix):]
+		ip, _ := parseIPHost(literal[len(emailIPv6LiteralPrefix):])
 
-		return net.ParseIP(value)
+		return ip
 	}
 
-	return net.ParseIP(literal)
+	ip, _ := parseIPHost(literal)
+
+	return ip
 }
 
 func isASCII(value string) bool {
@@ -484,13 +689,18 @@ func isASCII(value string) bool {
 	return true
 }
 
-func validateDomainLabels(domain string) error {
+func validateDomainLabels(ctx context.Context, domain string) error {
 	labels := strings.SplitSeq(domain, ".")
 	for label := range labels {
-		err := validateDomainLabel(label)
+		err := contextErr(ctx)
 		if err != nil {
 			return err
 		}
+
+		err = validateDomainLabel(label)
+		if err != nil {
+			return newValidationError(err, FieldLabel, label)
+		}
 	}
 
 	return nil
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// WithEmailFreeProviderList replaces the built-in list of free webmail domains used to set
+// EmailResult.FreeProvider. Domains are matched exactly, case-insensitively, in ASCII form.
+func WithEmailFreeProviderList(domains ...string) EmailOption {
+	return func(cfg *emailOptions) error {
+		providers, err := normalizeProviderDomains(domains)
+		if err != nil {
+			return err
+		}
+
+		cfg.freeProviders = providers
+
+		return nil
+	}
+}
+
+func normalizeProviderDomains(domains []string) (map[string]struct{}, error) {
+	providers := make(map[string]struct{}, len(domains))
+
+	for _, domain := range domains {
+		normalized := strings.TrimSuffix(strings.TrimSpace(domain), string(emailDot))
+		if normalized == "" {
+			return nil, ErrInvalidEmailConfig
+		}
+
+		ascii, err := idna.Lookup.ToASCII(normalized)
+		if err != nil {
+			return nil, ErrInvalidEmailConfig
+		}
+
+		providers[strings.ToLower(ascii)] = struct{}{}
+	}
+
+	return providers, nil
+}
+
+func defaultFreeProviders() []string {
+	return []string{
+		"gmail.com",
+		"googlemail.com",
+		"yahoo.com",
+		"yahoo.co.uk",
+		"ymail.com",
+		"outlook.com",
+		"hotmail.com",
+		"live.com",
+		"msn.com",
+		"aol.com",
+		"icloud.com",
+		"me.com",
+		"mac.com",
+		"proton.me",
+		"protonmail.com",
+		"gmx.com",
+		"gmx.de",
+		"mail.com",
+		"zoho.com",
+		"yandex.com",
+		"yandex.ru",
+		"mail.ru",
+		"qq.com",
+		"163.com",
+	}
+}
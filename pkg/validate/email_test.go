@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/net/idna"
 )
@@ -88,6 +90,30 @@ func TestEmailAllowDisplayName(t *testing.T) {
 	}
 }
 
+func TestEmailResultRaw(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator(WithEmailAllowDisplayName(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	input := "  Name <user@example.com>\n"
+
+	result, err := validator.Validate(context.Background(), input)
+	if err != nil {
+		t.Fatalf(errMsgValidEmail, err)
+	}
+
+	if result.Address != testEmail {
+		t.Fatalf("expected normalized address, got %s", result.Address)
+	}
+
+	if result.Raw != input {
+		t.Fatalf("expected raw input preserved, got %q", result.Raw)
+	}
+}
+
 func TestEmailInvalidLocalPart(t *testing.T) {
 	t.Parallel()
 
@@ -189,6 +215,38 @@ func TestEmailDomainVerificationUnverified(t *testing.T) {
 	}
 }
 
+func TestEmailIDNAProfile(t *testing.T) {
+	t.Parallel()
+
+	strict, err := NewEmailValidator(WithEmailAllowIDN(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	transitional, err := NewEmailValidator(
+		WithEmailAllowIDN(true),
+		WithEmailIDNAProfile(idna.New(idna.MapForLookup(), idna.Transitional(true))),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err := strict.Validate(context.Background(), "user@faß.de")
+	if err != nil || result.DomainASCII != "xn--fa-hia.de" {
+		t.Fatalf("expected non-transitional ASCII domain, got %q %v", result.DomainASCII, err)
+	}
+
+	result, err = transitional.Validate(context.Background(), "user@faß.de")
+	if err != nil || result.DomainASCII != "fass.de" {
+		t.Fatalf("expected transitional ASCII domain, got %q %v", result.DomainASCII, err)
+	}
+
+	_, err = NewEmailValidator(WithEmailIDNAProfile(nil))
+	if !errors.Is(err, ErrInvalidEmailConfig) {
+		t.Fatalf("expected ErrInvalidEmailConfig, got %v", err)
+	}
+}
+
 func TestEmailAllowIDN(t *testing.T) {
 	t.Parallel()
 
@@ -212,6 +270,73 @@ func TestEmailAllowIDN(t *testing.T) {
 	}
 }
 
+func TestEmailRejectMixedScripts(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator(WithEmailAllowIDN(true), WithEmailRejectMixedScripts())
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	// "pаypal" with a Cyrillic U+0430 in place of the Latin "a".
+	_, err = validator.Validate(context.Background(), "user@p\u0430ypal.example")
+	if !errors.Is(err, ErrEmailDomainInvalid) {
+		t.Fatalf("expected ErrEmailDomainInvalid, got %v", err)
+	}
+
+	_, err = validator.Validate(context.Background(), "user@bücher.example")
+	if err != nil {
+		t.Fatalf(errMsgValidEmail, err)
+	}
+}
+
+func TestEmailAllowUTF8LocalPart(t *testing.T) {
+	t.Parallel()
+
+	strict, err := NewEmailValidator(WithEmailAllowIDN(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = strict.Validate(context.Background(), "用户@例え.jp")
+	if !errors.Is(err, ErrEmailLocalPartInvalid) {
+		t.Fatalf("expected ErrEmailLocalPartInvalid without UTF-8 local parts, got %v", err)
+	}
+
+	validator, err := NewEmailValidator(WithEmailAllowIDN(true), WithEmailAllowUTF8LocalPart())
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	for _, address := range []string{"用户@例え.jp", "josé.niño@example.com", "दीपक+tag@example.com"} {
+		result, err := validator.Validate(context.Background(), address)
+		if err != nil {
+			t.Fatalf("expected %q valid, got %v", address, err)
+		}
+
+		if !strings.HasPrefix(address, result.LocalPart+"@") {
+			t.Fatalf("expected local part preserved for %q, got %q", address, result.LocalPart)
+		}
+	}
+
+	rejected := []string{
+		"us\u200Ber@example.com",     // zero-width space
+		"user\u202E@example.com",     // right-to-left override
+		"user\uFF20evil@example.com", // fullwidth commercial at
+		"user\u3002x@example.com",    // ideographic full stop
+		"us\u0085er@example.com",     // C1 control
+		"us er@example.com",
+		"user\xff@example.com", // invalid UTF-8
+	}
+
+	for _, address := range rejected {
+		_, err = validator.Validate(context.Background(), address)
+		if err == nil {
+			t.Fatalf("expected %q rejected", address)
+		}
+	}
+}
+
 func TestEmailIPLiteralDisallowed(t *testing.T) {
 	t.Parallel()
 
@@ -239,3 +364,206 @@ func TestEmailIPLiteralAllowed(t *testing.T) {
 		t.Fatalf("expected valid ip-literal, got %v", err)
 	}
 }
+
+func TestEmailIPv6LiteralCanonicalized(t *testing.T) {
+	t.Parallel()
+
+	mapped := parseIPLiteral("IPv6:::ffff:127.0.0.1")
+	if len(mapped) != net.IPv4len || !mapped.Equal(net.IPv4(127, 0, 0, 1)) {
+		t.Fatalf("expected IPv4-mapped literal to canonicalize, got %v", mapped)
+	}
+
+	if parseIPLiteral("IPv6:fe80::1%eth0") == nil {
+		t.Fatal("expected zoned IPv6 literal to parse")
+	}
+
+	if parseIPLiteral("127.0.0.1%eth0") != nil {
+		t.Fatal("expected zone on IPv4 literal to be rejected")
+	}
+}
+
+func TestEmailValidateHonorsExpiredDeadline(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err = validator.Validate(ctx, "user@example.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	err = validateDomainLabels(ctx, "mail.example.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded from label checks, got %v", err)
+	}
+}
+
+func TestIsEmail(t *testing.T) {
+	t.Parallel()
+
+	if !IsEmail(testEmail) {
+		t.Fatalf("expected %q to be valid", testEmail)
+	}
+
+	for _, input := range []string{"", "user@", "Display <user@example.com>"} {
+		if IsEmail(input) {
+			t.Fatalf("expected %q to be invalid", input)
+		}
+	}
+}
+
+func TestEmailLocalDotOptions(t *testing.T) {
+	t.Parallel()
+
+	strict, err := NewEmailValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = strict.Validate(context.Background(), "first..last@example.com")
+	if !errors.Is(err, ErrEmailLocalPartInvalid) {
+		t.Fatalf("expected ErrEmailLocalPartInvalid, got %v", err)
+	}
+
+	relaxed, err := NewEmailValidator(WithEmailAllowConsecutiveDots(true), WithEmailMaxLocalDots(2))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = relaxed.Validate(context.Background(), "first..last@example.com")
+	if err != nil {
+		t.Fatalf("expected consecutive dots to be allowed, got %v", err)
+	}
+
+	for _, input := range []string{".first@example.com", "first.@example.com", "a.b.c.d@example.com"} {
+		_, err = relaxed.Validate(context.Background(), input)
+		if !errors.Is(err, ErrEmailLocalPartInvalid) {
+			t.Fatalf("%s: expected ErrEmailLocalPartInvalid, got %v", input, err)
+		}
+	}
+
+	noDots, err := NewEmailValidator(WithEmailMaxLocalDots(0))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = noDots.Validate(context.Background(), "first.last@example.com")
+	if !errors.Is(err, ErrEmailLocalPartInvalid) {
+		t.Fatalf("expected dots to be rejected, got %v", err)
+	}
+
+	_, err = NewEmailValidator(WithEmailMaxLocalDots(-1))
+	if !errors.Is(err, ErrInvalidEmailConfig) {
+		t.Fatalf("expected ErrInvalidEmailConfig, got %v", err)
+	}
+}
+
+func TestEmailFreeProvider(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err := validator.Validate(context.Background(), "user@GMail.com")
+	if err != nil {
+		t.Fatalf("expected valid email, got %v", err)
+	}
+
+	if !result.FreeProvider {
+		t.Fatal("expected gmail.com to be a free provider")
+	}
+
+	result, err = validator.Validate(context.Background(), testEmail)
+	if err != nil {
+		t.Fatalf("expected valid email, got %v", err)
+	}
+
+	if result.FreeProvider {
+		t.Fatalf("expected %s not to be a free provider", testEmail)
+	}
+
+	custom, err := NewEmailValidator(WithEmailAllowIDN(true), WithEmailFreeProviderList("Bücher.example"))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err = custom.Validate(context.Background(), "user@bücher.example")
+	if err != nil {
+		t.Fatalf("expected valid email, got %v", err)
+	}
+
+	if !result.FreeProvider {
+		t.Fatal("expected IDN provider match")
+	}
+
+	result, err = custom.Validate(context.Background(), "user@gmail.com")
+	if err != nil {
+		t.Fatalf("expected valid email, got %v", err)
+	}
+
+	if result.FreeProvider {
+		t.Fatal("expected custom list to replace the defaults")
+	}
+
+	_, err = NewEmailValidator(WithEmailFreeProviderList(" "))
+	if !errors.Is(err, ErrInvalidEmailConfig) {
+		t.Fatalf("expected ErrInvalidEmailConfig, got %v", err)
+	}
+}
+
+func TestEmailPlusTagExtraction(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator(WithEmailPlusTagExtraction(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	tests := []struct {
+		input string
+		base  string
+		tag   string
+	}{
+		{input: "user+newsletter@example.com", base: "user", tag: "newsletter"},
+		{input: "user+a+b@example.com", base: "user", tag: "a+b"},
+		{input: "user@example.com", base: "user", tag: ""},
+	}
+
+	for _, tt := range tests {
+		result, err := validator.Validate(context.Background(), tt.input)
+		if err != nil {
+			t.Fatalf("expected valid email %q, got %v", tt.input, err)
+		}
+
+		if result.BaseLocalPart != tt.base || result.Tag != tt.tag {
+			t.Fatalf("expected base %q tag %q, got %q %q", tt.base, tt.tag, result.BaseLocalPart, result.Tag)
+		}
+	}
+
+	base, tag := splitPlusTag(`"user+tag"`)
+	if base != `"user+tag"` || tag != "" {
+		t.Fatalf("expected quoted local part to stay intact, got %q %q", base, tag)
+	}
+
+	plain, err := NewEmailValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err := plain.Validate(context.Background(), "user+newsletter@example.com")
+	if err != nil {
+		t.Fatalf("expected valid email, got %v", err)
+	}
+
+	if result.Tag != "" || result.BaseLocalPart != "" || result.LocalPart != "user+newsletter" {
+		t.Fatalf("expected no tag extraction by default, got %+v", result)
+	}
+}
@@ -34,6 +34,19 @@ var (
 	// ErrEmailDomainUnverified indicates that the email domain is unverified.
 	ErrEmailDomainUnverified = ewrap.New("email domain is unverified")
 
+	// ErrInvalidHostnameConfig indicates that the hostname validation configuration is invalid.
+	ErrInvalidHostnameConfig = ewrap.New("invalid hostname validation config")
+	// ErrHostnameEmpty indicates that the hostname is empty.
+	ErrHostnameEmpty = ewrap.New("hostname is empty")
+	// ErrHostnameInvalid indicates that the hostname is invalid.
+	ErrHostnameInvalid = ewrap.New("hostname is invalid")
+	// ErrHostnameTooLong indicates that the hostname is too long.
+	ErrHostnameTooLong = ewrap.New("hostname is too long")
+	// ErrHostnameIDNNotAllowed indicates that internationalized hostnames are not allowed.
+	ErrHostnameIDNNotAllowed = ewrap.New("hostname idn is not allowed")
+	// ErrHostnameIPLiteralNotAllowed indicates that an IP literal was given where a hostname is required.
+	ErrHostnameIPLiteralNotAllowed = ewrap.New("hostname ip literal is not allowed")
+
 	// ErrURLInvalid indicates that the URL is invalid.
 	ErrURLInvalid = ewrap.New("url is invalid")
 	// ErrURLTooLong indicates that the URL is too long.
@@ -42,12 +55,24 @@ var (
 	ErrURLSchemeNotAllowed = ewrap.New("url scheme is not allowed")
 	// ErrURLHostMissing indicates that the URL host is required.
 	ErrURLHostMissing = ewrap.New("url host is required")
+	// ErrURLComponentTooLong indicates that the URL path or query exceeds its configured length limit.
+	ErrURLComponentTooLong = ewrap.New("url component is too long")
+	// ErrURLNotAbsolute indicates that the URL is relative or opaque rather than absolute and hierarchical.
+	ErrURLNotAbsolute = ewrap.New("url is not absolute")
 	// ErrURLUserInfoNotAllowed indicates that the URL userinfo is not allowed.
 	ErrURLUserInfoNotAllowed = ewrap.New("url userinfo is not allowed")
 	// ErrURLHostNotAllowed indicates that the URL host is not allowed.
 	ErrURLHostNotAllowed = ewrap.New("url host is not allowed")
+	// ErrURLSecretInQuery indicates that the URL query carries a secret or credential.
+	ErrURLSecretInQuery = ewrap.New("url query contains a secret")
+	// ErrURLSecretInPath indicates that the URL path or query embeds a high-confidence secret.
+	ErrURLSecretInPath = ewrap.New("url path contains a secret")
 	// ErrURLPrivateIPNotAllowed indicates that the URL private IP is not allowed.
 	ErrURLPrivateIPNotAllowed = ewrap.New("url private ip is not allowed")
+	// ErrURLResolveFailed indicates that the URL host could not be resolved.
+	ErrURLResolveFailed = ewrap.New("url host could not be resolved")
+	// ErrURLMetadataBlocked indicates that the URL targets a cloud metadata endpoint.
+	ErrURLMetadataBlocked = ewrap.New("url cloud metadata endpoint is blocked")
 	// ErrURLRedirectNotAllowed indicates that URL redirects are not allowed.
 	ErrURLRedirectNotAllowed = ewrap.New("url redirect is not allowed")
 	// ErrURLRedirectLoop indicates that a URL redirect loop was detected.
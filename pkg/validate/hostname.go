@@ -0,0 +1,129 @@
+package validate
+
+import (
+	"net"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// HostnameOption configures Hostname.
+type HostnameOption func(*hostnameOptions) error
+
+type hostnameOptions struct {
+	allowIDN        bool
+	rejectIPLiteral bool
+	maxLength       int
+}
+
+// HostnameResult holds a validated hostname.
+type HostnameResult struct {
+	// ASCII is the lowercase ASCII (punycode) form without a trailing dot.
+	ASCII string
+	// Unicode is the display form; it equals ASCII for non-IDN names and IP literals.
+	Unicode string
+	// IsIPLiteral reports whether the input was an IP address.
+	IsIPLiteral bool
+}
+
+// WithHostnameAllowIDN permits internationalized hostnames, which are converted to punycode.
+func WithHostnameAllowIDN(allow bool) HostnameOption {
+	return func(cfg *hostnameOptions) error {
+		cfg.allowIDN = allow
+
+		return nil
+	}
+}
+
+// WithHostnameRejectIPLiteral rejects IPv4 and IPv6 literals with ErrHostnameIPLiteralNotAllowed.
+func WithHostnameRejectIPLiteral() HostnameOption {
+	return func(cfg *hostnameOptions) error {
+		cfg.rejectIPLiteral = true
+
+		return nil
+	}
+}
+
+// WithHostnameMaxLength sets the maximum length of the ASCII form (default 253).
+func WithHostnameMaxLength(maxLen int) HostnameOption {
+	return func(cfg *hostnameOptions) error {
+		if maxLen <= 0 || maxLen > urlDefaultMaxHostLen {
+			return ErrInvalidHostnameConfig
+		}
+
+		cfg.maxLength = maxLen
+
+		return nil
+	}
+}
+
+// Hostname validates a bare hostname, such as a TLS server name or a configured host,
+// without building a URL. Labels must be 1-63 letters, digits, or hyphens and may not start
+// or end with a hyphen. One trailing dot is accepted and dropped. IP literals are accepted
+// unless WithHostnameRejectIPLiteral is set; zoned IPv6 literals are always rejected.
+func Hostname(host string, opts ...HostnameOption) (HostnameResult, error) {
+	cfg := hostnameOptions{
+		maxLength: urlDefaultMaxHostLen,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return HostnameResult{}, err
+		}
+	}
+
+	if host == "" {
+		return HostnameResult{}, ErrHostnameEmpty
+	}
+
+	if ip, zoned := parseIPHost(host); ip != nil {
+		return hostnameIPLiteral(ip, zoned, cfg)
+	}
+
+	if !cfg.allowIDN && !isASCII(host) {
+		return HostnameResult{}, ErrHostnameIDNNotAllowed
+	}
+
+	ascii, err := normalizeHost(host, cfg.allowIDN, idna.Lookup)
+	if err != nil {
+		return HostnameResult{}, ErrHostnameInvalid
+	}
+
+	if len(ascii) > cfg.maxLength {
+		return HostnameResult{}, ErrHostnameTooLong
+	}
+
+	for label := range strings.SplitSeq(ascii, ".") {
+		err = validateDomainLabel(label)
+		if err != nil {
+			return HostnameResult{}, newValidationError(ErrHostnameInvalid, FieldLabel, label)
+		}
+	}
+
+	unicode, err := idna.Lookup.ToUnicode(ascii)
+	if err != nil {
+		unicode = ascii
+	}
+
+	return HostnameResult{ASCII: ascii, Unicode: unicode}, nil
+}
+
+func hostnameIPLiteral(ip net.IP, zoned bool, cfg hostnameOptions) (HostnameResult, error) {
+	if cfg.rejectIPLiteral {
+		return HostnameResult{}, ErrHostnameIPLiteralNotAllowed
+	}
+
+	// A zone only scopes an address to one interface and is never a valid server name.
+	if zoned {
+		return HostnameResult{}, ErrHostnameInvalid
+	}
+
+	normalized := ip.String()
+
+	return HostnameResult{ASCII: normalized, Unicode: normalized, IsIPLiteral: true}, nil
+}
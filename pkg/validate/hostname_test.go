@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHostname(t *testing.T) {
+	t.Parallel()
+
+	result, err := Hostname("API.Example.com.")
+	if err != nil {
+		t.Fatalf("expected valid hostname, got %v", err)
+	}
+
+	if result.ASCII != "api.example.com" || result.Unicode != "api.example.com" || result.IsIPLiteral {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	_, err = Hostname("bücher.example")
+	if !errors.Is(err, ErrHostnameIDNNotAllowed) {
+		t.Fatalf("expected ErrHostnameIDNNotAllowed, got %v", err)
+	}
+
+	result, err = Hostname("Bücher.example", WithHostnameAllowIDN(true))
+	if err != nil {
+		t.Fatalf("expected IDN hostname, got %v", err)
+	}
+
+	if result.ASCII != "xn--bcher-kva.example" || result.Unicode != "bücher.example" {
+		t.Fatalf("unexpected IDN result %+v", result)
+	}
+
+	invalid := []string{
+		"-bad.example",
+		"bad-.example",
+		"a..example",
+		"under_score.example",
+		"host name",
+		strings.Repeat("a", 64) + ".example",
+		".",
+	}
+
+	for _, host := range invalid {
+		_, err = Hostname(host)
+		if !errors.Is(err, ErrHostnameInvalid) {
+			t.Fatalf("expected ErrHostnameInvalid for %q, got %v", host, err)
+		}
+	}
+
+	_, err = Hostname("")
+	if !errors.Is(err, ErrHostnameEmpty) {
+		t.Fatalf("expected ErrHostnameEmpty, got %v", err)
+	}
+
+	_, err = Hostname("a.example", WithHostnameMaxLength(5))
+	if !errors.Is(err, ErrHostnameTooLong) {
+		t.Fatalf("expected ErrHostnameTooLong, got %v", err)
+	}
+
+	_, err = Hostname("a.example", WithHostnameMaxLength(0))
+	if !errors.Is(err, ErrInvalidHostnameConfig) {
+		t.Fatalf("expected ErrInvalidHostnameConfig, got %v", err)
+	}
+}
+
+func TestHostnameIPLiteral(t *testing.T) {
+	t.Parallel()
+
+	result, err := Hostname("::ffff:10.0.0.1")
+	if err != nil {
+		t.Fatalf("expected IP literal, got %v", err)
+	}
+
+	if !result.IsIPLiteral || result.ASCII != "10.0.0.1" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	_, err = Hostname("fe80::1%eth0")
+	if !errors.Is(err, ErrHostnameInvalid) {
+		t.Fatalf("expected zoned literal to be invalid, got %v", err)
+	}
+
+	_, err = Hostname("192.0.2.1", WithHostnameRejectIPLiteral())
+	if !errors.Is(err, ErrHostnameIPLiteralNotAllowed) {
+		t.Fatalf("expected ErrHostnameIPLiteralNotAllowed, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"net"
+	"strings"
+)
+
+const (
+	ipZoneSeparator = "%"
+	bitsPerByte     = 8
+)
+
+var (
+	// nat64WellKnownPrefix embeds an IPv4 address in its last 32 bits (RFC 6052).
+	nat64WellKnownPrefix = mustParseCIDR("64:ff9b::/96")
+	// nat64LocalPrefix is reserved for local-use IPv4/IPv6 translation (RFC 8215).
+	nat64LocalPrefix = mustParseCIDR("64:ff9b:1::/48")
+)
+
+// IPClass describes the address range an IP address belongs to.
+type IPClass int
+
+const (
+	// IPClassInvalid is returned for nil or malformed addresses.
+	IPClassInvalid IPClass = iota
+	// IPClassPublic is a globally routable unicast address.
+	IPClassPublic
+	// IPClassPrivate is an RFC 1918, RFC 4193, or local-use NAT64 address.
+	IPClassPrivate
+	// IPClassLoopback is 127.0.0.0/8 or ::1.
+	IPClassLoopback
+	// IPClassLinkLocal is a link-local unicast or multicast address.
+	IPClassLinkLocal
+	// IPClassMulticast is a multicast address outside the link-local scope.
+	IPClassMulticast
+	// IPClassUnspecified is 0.0.0.0 or ::.
+	IPClassUnspecified
+)
+
+// ClassifyIP reports the range ip belongs to. IPv4-mapped IPv6 addresses are classified as
+// IPv4, and addresses under the well-known NAT64 prefix take the class of the IPv4 address
+// they translate to.
+func ClassifyIP(ip net.IP) IPClass {
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return IPClassInvalid
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	if embedded := nat64EmbeddedIPv4(ip); embedded != nil {
+		return ClassifyIP(embedded)
+	}
+
+	switch {
+	case ip.IsUnspecified():
+		return IPClassUnspecified
+	case ip.IsLoopback():
+		return IPClassLoopback
+	case ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast():
+		return IPClassLinkLocal
+	case ip.IsPrivate() || nat64LocalPrefix.Contains(ip):
+		return IPClassPrivate
+	case ip.IsMulticast():
+		return IPClassMulticast
+	default:
+		return IPClassPublic
+	}
+}
+
+// IsPublicIP reports whether s is an IP literal in the public class.
+// Zoned IPv6 literals such as "fe80::1%eth0" are never public.
+func IsPublicIP(s string) bool {
+	ip, zoned := parseIPHost(strings.TrimSpace(s))
+	if ip == nil || zoned {
+		return false
+	}
+
+	return ClassifyIP(ip) == IPClassPublic
+}
+
+// parseIPHost parses an IP literal, stripping any IPv6 zone and canonicalizing
+// IPv4-mapped addresses to their 4-byte form. It reports whether a zone was present.
+func parseIPHost(host string) (net.IP, bool) {
+	value, zone, zoned := strings.Cut(host, ipZoneSeparator)
+	if zoned && (zone == "" || !strings.Contains(value, ":")) {
+		return nil, false
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, false
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return v4, zoned
+	}
+
+	return ip, zoned
+}
+
+// nat64EmbeddedIPv4 returns the IPv4 address translated by a well-known NAT64 prefix.
+func nat64EmbeddedIPv4(ip net.IP) net.IP {
+	if len(ip) != net.IPv6len || !nat64WellKnownPrefix.Contains(ip) {
+		return nil
+	}
+
+	return net.IPv4(ip[12], ip[13], ip[14], ip[15]).To4()
+}
+
+// parseCIDRs parses CIDR ranges, treating bare addresses as single-host ranges.
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		value := strings.TrimSpace(cidr)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip, _ := parseIPHost(value)
+			if ip == nil {
+				return nil, ErrInvalidURLConfig
+			}
+
+			bits := len(ip) * bitsPerByte
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, ErrInvalidURLConfig
+		}
+
+		networks = append(networks, network)
+	}
+
+	if len(networks) == 0 {
+		return nil, ErrInvalidURLConfig
+	}
+
+	return networks, nil
+}
+
+// ipInNetworks reports whether ip, or the IPv4 address it translates via NAT64, is in any network.
+func ipInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	embedded := nat64EmbeddedIPv4(ip)
+
+	for _, network := range networks {
+		if network.Contains(ip) || (embedded != nil && network.Contains(embedded)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"net"
+	"testing"
+)
+
+func TestClassifyIP(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]IPClass{
+		"8.8.8.8":              IPClassPublic,
+		"2001:4860:4860::8888": IPClassPublic,
+		"10.1.2.3":             IPClassPrivate,
+		"fd00::1":              IPClassPrivate,
+		"64:ff9b:1::1":         IPClassPrivate,
+		"127.0.0.1":            IPClassLoopback,
+		"::1":                  IPClassLoopback,
+		"::ffff:127.0.0.1":     IPClassLoopback,
+		"64:ff9b::7f00:1":      IPClassLoopback,
+		"169.254.169.254":      IPClassLinkLocal,
+		"fe80::1":              IPClassLinkLocal,
+		"ff02::1":              IPClassLinkLocal,
+		"239.1.1.1":            IPClassMulticast,
+		"ff0e::1":              IPClassMulticast,
+		"0.0.0.0":              IPClassUnspecified,
+		"::":                   IPClassUnspecified,
+		"::ffff:10.0.0.1":      IPClassPrivate,
+		"64:ff9b::808:808":     IPClassPublic,
+	}
+
+	for input, want := range cases {
+		if got := ClassifyIP(net.ParseIP(input)); got != want {
+			t.Fatalf("ClassifyIP(%s) = %d, want %d", input, got, want)
+		}
+	}
+
+	if ClassifyIP(nil) != IPClassInvalid || ClassifyIP(net.IP{1, 2, 3}) != IPClassInvalid {
+		t.Fatal("expected malformed addresses to be invalid")
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"8.8.8.8", " 1.1.1.1 ", "2606:4700::1111"} {
+		if !IsPublicIP(input) {
+			t.Fatalf("expected %q to be public", input)
+		}
+	}
+
+	for _, input := range []string{"", "example.com", "192.168.1.1", "::ffff:192.168.1.1", "2606:4700::1111%eth0"} {
+		if IsPublicIP(input) {
+			t.Fatalf("expected %q not to be public", input)
+		}
+	}
+}
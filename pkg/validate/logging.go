@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyp3rd/hyperlogger"
+
+	"github.com/hyp3rd/sectools/pkg/secrets"
+)
+
+const (
+	logVerdictAllowed  = "allowed"
+	logVerdictRejected = "rejected"
+	logRedactedQuery   = "?[REDACTED]"
+)
+
+// WithURLLogger logs validation decisions at debug level.
+// Only scheme, host, and a redacted path are logged; query strings and userinfo never are.
+func WithURLLogger(log hyperlogger.Logger) URLOption {
+	return func(cfg *urlOptions) error {
+		if log == nil {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.logger = log
+
+		return nil
+	}
+}
+
+// WithEmailLogger logs validation decisions and DNS results at debug level.
+// Only the domain is logged; local parts are never included.
+func WithEmailLogger(log hyperlogger.Logger) EmailOption {
+	return func(cfg *emailOptions) error {
+		if log == nil {
+			return ErrInvalidEmailConfig
+		}
+
+		cfg.logger = log
+
+		return nil
+	}
+}
+
+// prepareURLLogging selects the detector used to redact logged paths.
+func prepareURLLogging(cfg *urlOptions) error {
+	if cfg.logger == nil {
+		return nil
+	}
+
+	if cfg.secretDetector != nil {
+		cfg.logDetector = cfg.secretDetector
+
+		return nil
+	}
+
+	detector, err := secrets.NewSecretDetector()
+	if err != nil {
+		return err
+	}
+
+	cfg.logDetector = detector
+
+	return nil
+}
+
+func (v *URLValidator) logDebug(msg string, fields ...hyperlogger.Field) {
+	if v.opts.logger == nil {
+		return
+	}
+
+	v.opts.logger.WithFields(fields...).Debug(msg)
+}
+
+func (v *URLValidator) logResult(target *url.URL, result URLResult, err error, started time.Time) {
+	if v.opts.logger == nil {
+		return
+	}
+
+	fields := []hyperlogger.Field{
+		{Key: "url", Value: v.logSafeURL(target)},
+		{Key: "redirects", Value: len(result.Redirects)},
+		{Key: "duration", Value: time.Since(started)},
+	}
+
+	if err != nil {
+		v.logDebug("url validation", append(fields,
+			hyperlogger.Field{Key: "verdict", Value: logVerdictRejected},
+			hyperlogger.Field{Key: "reason", Value: err.Error()},
+		)...)
+
+		return
+	}
+
+	v.logDebug("url validation", append(fields, hyperlogger.Field{Key: "verdict", Value: logVerdictAllowed})...)
+}
+
+// logSafeURL renders target without userinfo, fragment, or query values, redacting secrets in the path.
+func (v *URLValidator) logSafeURL(target *url.URL) string {
+	if target == nil {
+		return ""
+	}
+
+	safe := url.URL{Scheme: target.Scheme, Host: target.Host, Path: target.Path}
+
+	rendered := safe.String()
+	if v.opts.logDetector != nil {
+		redacted, _, err := v.opts.logDetector.Redact(rendered)
+		if err == nil {
+			rendered = redacted
+		}
+	}
+
+	if target.RawQuery != "" {
+		rendered += logRedactedQuery
+	}
+
+	return rendered
+}
+
+func (v *EmailValidator) logDebug(msg string, fields ...hyperlogger.Field) {
+	if v.opts.logger == nil {
+		return
+	}
+
+	v.opts.logger.WithFields(fields...).Debug(msg)
+}
+
+func (v *EmailValidator) logResult(domain string, err error, started time.Time) {
+	if v.opts.logger == nil {
+		return
+	}
+
+	fields := []hyperlogger.Field{
+		{Key: "domain", Value: domain},
+		{Key: "duration", Value: time.Since(started)},
+	}
+
+	if err != nil {
+		v.logDebug("email validation", append(fields,
+			hyperlogger.Field{Key: "verdict", Value: logVerdictRejected},
+			hyperlogger.Field{Key: "reason", Value: err.Error()},
+		)...)
+
+		return
+	}
+
+	v.logDebug("email validation", append(fields, hyperlogger.Field{Key: "verdict", Value: logVerdictAllowed})...)
+}
+
+// logEmailDomain returns the text after the last '@' so local parts are never logged.
+func logEmailDomain(input string) string {
+	at := strings.LastIndexByte(input, emailAt)
+	if at < 0 {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.TrimSpace(input[at+1:]), ">")
+}
@@ -0,0 +1,56 @@
+package validate
+
+import "net"
+
+// cloudMetadataIPs lists well-known instance metadata service addresses.
+var cloudMetadataIPs = []net.IP{
+	net.ParseIP("169.254.169.254").To4(), // AWS, GCP, Azure, OpenStack
+	net.ParseIP("169.254.170.2").To4(),   // AWS ECS task metadata
+	net.ParseIP("168.63.129.16").To4(),   // Azure WireServer
+	net.ParseIP("100.100.100.200").To4(), // Alibaba Cloud
+	net.ParseIP("fd00:ec2::254"),         // AWS IPv6
+}
+
+// cloudMetadataHosts lists well-known instance metadata service hostnames.
+var cloudMetadataHosts = map[string]struct{}{
+	"metadata":                   {},
+	"metadata.google.internal":   {},
+	"metadata.goog":              {},
+	"instance-data":              {},
+	"instance-data.ec2.internal": {},
+}
+
+// WithURLBlockCloudMetadata rejects well-known cloud metadata endpoints (AWS, GCP, Azure)
+// by IP and hostname, regardless of the private-IP and allowed-CIDR settings.
+func WithURLBlockCloudMetadata() URLOption {
+	return func(cfg *urlOptions) error {
+		cfg.blockMetadata = true
+
+		return nil
+	}
+}
+
+func (v *URLValidator) checkCloudMetadata(host string) error {
+	if !v.opts.blockMetadata {
+		return nil
+	}
+
+	if _, ok := cloudMetadataHosts[host]; ok {
+		return ErrURLMetadataBlocked
+	}
+
+	ip, _ := parseIPHost(host)
+	if ip == nil {
+		return nil
+	}
+
+	embedded := nat64EmbeddedIPv4(ip)
+
+	for _, metadata := range cloudMetadataIPs {
+		if ip.Equal(metadata) || (embedded != nil && embedded.Equal(metadata)) {
+			return ErrURLMetadataBlocked
+		}
+	}
+
+	return nil
+}
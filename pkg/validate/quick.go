@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"context"
+	"sync"
+)
+
+//nolint:gochecknoglobals // default validators are immutable and built once on first use.
+var (
+	defaultEmailValidator = sync.OnceValues(func() (*EmailValidator, error) {
+		return NewEmailValidator()
+	})
+	defaultURLValidator = sync.OnceValues(func() (*URLValidator, error) {
+		return NewURLValidator()
+	})
+)
+
+// IsEmail reports whether input is a valid email address under the default EmailValidator.
+// It never performs DNS lookups.
+func IsEmail(input string) bool {
+	validator, err := defaultEmailValidator()
+	if err != nil {
+		return false
+	}
+
+	_, err = validator.Validate(context.Background(), input)
+
+	return err == nil
+}
+
+// IsHTTPSURL reports whether raw is a valid https URL under the default URLValidator.
+// It never performs network I/O.
+func IsHTTPSURL(raw string) bool {
+	validator, err := defaultURLValidator()
+	if err != nil {
+		return false
+	}
+
+	_, err = validator.Validate(context.Background(), raw)
+
+	return err == nil
+}
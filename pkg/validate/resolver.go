@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+const (
+	resolverDefaultTimeout = 2 * time.Second
+	resolverDefaultBackoff = 100 * time.Millisecond
+	resolverMaxRetries     = 5
+)
+
+// ResolverOptions configures NewResolver. Zero values select the defaults.
+type ResolverOptions struct {
+	// Timeout bounds each lookup attempt (default 2s).
+	Timeout time.Duration
+	// Retries is the number of extra attempts after a temporary failure (capped at 5).
+	Retries int
+	// Backoff is the delay before the first retry, doubled for each further retry (default 100ms).
+	Backoff time.Duration
+	// Server forces queries to a DNS server given as host:port. It is ignored when Resolver is set.
+	Server string
+	// Resolver performs the lookups (default net.DefaultResolver).
+	Resolver DNSResolver
+}
+
+type retryingResolver struct {
+	base    DNSResolver
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+	retryIf func(error) bool
+}
+
+// NewResolver returns a DNSResolver with per-lookup timeouts and bounded retries.
+// Not-found answers are returned immediately without retrying.
+func NewResolver(opts ResolverOptions) DNSResolver {
+	resolver := &retryingResolver{
+		base:    opts.Resolver,
+		timeout: opts.Timeout,
+		retries: min(max(opts.Retries, 0), resolverMaxRetries),
+		backoff: opts.Backoff,
+		retryIf: isRetryableDNSError,
+	}
+
+	if resolver.timeout <= 0 {
+		resolver.timeout = resolverDefaultTimeout
+	}
+
+	if resolver.backoff <= 0 {
+		resolver.backoff = resolverDefaultBackoff
+	}
+
+	if resolver.base == nil {
+		resolver.base = serverResolver(opts.Server)
+	}
+
+	return resolver
+}
+
+// LookupMX implements DNSResolver.
+func (r *retryingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return withRetries(ctx, r, func(ctx context.Context) ([]*net.MX, error) {
+		return r.base.LookupMX(ctx, name)
+	})
+}
+
+// LookupHost implements DNSResolver.
+func (r *retryingResolver) LookupHost(ctx context.Context, name string) ([]string, error) {
+	return withRetries(ctx, r, func(ctx context.Context) ([]string, error) {
+		return r.base.LookupHost(ctx, name)
+	})
+}
+
+func withRetries[T any](ctx context.Context, r *retryingResolver, lookup func(context.Context) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+
+	delay := r.backoff
+
+	for attempt := 0; ; attempt++ {
+		result, err = lookupWithTimeout(ctx, r.timeout, lookup)
+		if err == nil || attempt == r.retries || !r.retryIf(err) {
+			return result, err
+		}
+
+		// Return the lookup error rather than sleeping into a deadline that ends before the retry.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return result, err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+	}
+}
+
+func lookupWithTimeout[T any](ctx context.Context, timeout time.Duration, lookup func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return lookup(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return lookup(ctx)
+}
+
+func isRetryableDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+
+	return !errors.Is(err, context.Canceled)
+}
+
+// isTemporaryDNSError reports whether err is a DNS failure marked temporary or a timeout;
+// not-found answers are never temporary.
+func isTemporaryDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		return false
+	}
+
+	return !dnsErr.IsNotFound && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+}
+
+func serverResolver(server string) DNSResolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type flakyResolver struct {
+	failures int32
+	calls    atomic.Int32
+	err      error
+}
+
+func (f *flakyResolver) LookupMX(_ context.Context, _ string) ([]*net.MX, error) {
+	if f.calls.Add(1) <= f.failures {
+		return nil, f.err
+	}
+
+	return []*net.MX{{Host: "mx.example.com.", Pref: 10}}, nil
+}
+
+func (f *flakyResolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	if f.calls.Add(1) <= f.failures {
+		return nil, f.err
+	}
+
+	return []string{"192.0.2.1"}, nil
+}
+
+func TestResolverRetriesTemporaryFailures(t *testing.T) {
+	t.Parallel()
+
+	stub := &flakyResolver{failures: 2, err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}
+	resolver := NewResolver(ResolverOptions{Retries: 2, Backoff: time.Millisecond, Resolver: stub})
+
+	hosts, err := resolver.LookupHost(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expected lookup after retries, got %v", err)
+	}
+
+	if len(hosts) != 1 || stub.calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts and one host, got %d attempts and %v", stub.calls.Load(), hosts)
+	}
+
+	exhausted := &flakyResolver{failures: 5, err: &net.DNSError{Err: "timeout", IsTimeout: true}}
+	resolver = NewResolver(ResolverOptions{Retries: 1, Backoff: time.Millisecond, Resolver: exhausted})
+
+	_, err = resolver.LookupMX(context.Background(), "example.com")
+	if err == nil || exhausted.calls.Load() != 2 {
+		t.Fatalf("expected failure after 2 attempts, got %d attempts and %v", exhausted.calls.Load(), err)
+	}
+}
+
+func TestResolverDoesNotRetryNotFound(t *testing.T) {
+	t.Parallel()
+
+	stub := &flakyResolver{failures: 1, err: &net.DNSError{Err: "no such host", IsNotFound: true}}
+	resolver := NewResolver(ResolverOptions{Retries: 3, Backoff: time.Millisecond, Resolver: stub})
+
+	_, err := resolver.LookupMX(context.Background(), "missing.example")
+
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", stub.calls.Load())
+	}
+}
+
+func TestEmailDNSRetries(t *testing.T) {
+	t.Parallel()
+
+	stub := &flakyResolver{failures: 2, err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}
+
+	validator, err := NewEmailValidator(
+		WithEmailVerifyDomain(true),
+		WithEmailDNSResolver(stub),
+		WithEmailDNSRetries(2, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err := validator.Validate(context.Background(), "user@example.com")
+	if err != nil || !result.VerifiedByMX {
+		t.Fatalf("expected MX verification after retries, got %+v %v", result, err)
+	}
+
+	if stub.calls.Load() != 3 {
+		t.Fatalf("expected 3 MX attempts, got %d", stub.calls.Load())
+	}
+
+	permanent := &flakyResolver{failures: 5, err: errors.New("refused")}
+
+	validator, err = NewEmailValidator(
+		WithEmailVerifyDomain(true),
+		WithEmailRequireMX(true),
+		WithEmailDNSResolver(permanent),
+		WithEmailDNSRetries(3, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "user@example.com")
+	if !errors.Is(err, ErrEmailDomainLookupFailed) || permanent.calls.Load() != 1 {
+		t.Fatalf("expected one attempt for a non-temporary error, got %d and %v", permanent.calls.Load(), err)
+	}
+
+	for _, opt := range []EmailOption{WithEmailDNSRetries(0, time.Millisecond), WithEmailDNSRetries(1, 0)} {
+		_, err = NewEmailValidator(opt)
+		if !errors.Is(err, ErrInvalidEmailConfig) {
+			t.Fatalf("expected ErrInvalidEmailConfig, got %v", err)
+		}
+	}
+}
+
+func TestEmailDNSRetriesRespectDeadline(t *testing.T) {
+	t.Parallel()
+
+	stub := &flakyResolver{failures: 5, err: &net.DNSError{Err: "timeout", IsTimeout: true}}
+
+	validator, err := NewEmailValidator(
+		WithEmailVerifyDomain(true),
+		WithEmailRequireMX(true),
+		WithEmailDNSResolver(stub),
+		WithEmailDNSRetries(5, time.Hour),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	started := time.Now()
+
+	_, err = validator.Validate(ctx, "user@example.com")
+	if !errors.Is(err, ErrEmailDomainLookupFailed) {
+		t.Fatalf("expected ErrEmailDomainLookupFailed, got %v", err)
+	}
+
+	if stub.calls.Load() != 1 || time.Since(started) > time.Second {
+		t.Fatalf("expected no retry past the deadline, got %d attempts in %v", stub.calls.Load(), time.Since(started))
+	}
+}
+
+func TestResolverForcesServer(t *testing.T) {
+	t.Parallel()
+
+	server := startStubDNS(t)
+	resolver := NewResolver(ResolverOptions{Server: server, Timeout: time.Second})
+
+	records, err := resolver.LookupMX(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("expected MX records, got %v", err)
+	}
+
+	if len(records) != 1 || records[0].Host != "mx.example.com." {
+		t.Fatalf("unexpected MX records %v", records)
+	}
+
+	validator, err := NewEmailValidator(WithEmailVerifyDomain(true), WithEmailDNSResolver(resolver))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, err := validator.Validate(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("expected verified email, got %v", err)
+	}
+
+	if !result.VerifiedByMX {
+		t.Fatal("expected domain verified by MX")
+	}
+}
+
+// startStubDNS serves one MX record for any name and empty answers for other types over UDP.
+func startStubDNS(t *testing.T) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected udp listener, got %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, readErr := conn.ReadFrom(buf)
+			if readErr != nil {
+				return
+			}
+
+			reply, replyErr := stubDNSReply(buf[:n])
+			if replyErr != nil {
+				continue
+			}
+
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func stubDNSReply(query []byte) ([]byte, error) {
+	var msg dnsmessage.Message
+
+	err := msg.Unpack(query)
+	if err != nil || len(msg.Questions) == 0 {
+		return nil, errors.New("bad query")
+	}
+
+	question := msg.Questions[0]
+	msg.Header.Response = true
+	msg.Header.Authoritative = true
+
+	if question.Type == dnsmessage.TypeMX {
+		msg.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeMX, Class: dnsmessage.ClassINET, TTL: 60},
+			Body:   &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx.example.com.")},
+		}}
+	}
+
+	return msg.Pack()
+}
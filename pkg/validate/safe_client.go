@@ -0,0 +1,156 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hyp3rd/sectools/pkg/tlsconfig"
+)
+
+const (
+	safeClientDefaultTimeout = 30 * time.Second
+	safeClientDialTimeout    = 10 * time.Second
+	safeClientKeepAlive      = 30 * time.Second
+)
+
+// SafeClientOption configures NewSafeHTTPClient.
+type SafeClientOption func(*safeClientOptions) error
+
+type safeClientOptions struct {
+	timeout time.Duration
+	tlsOpts []tlsconfig.Option
+}
+
+// NewSafeHTTPClient returns an *http.Client that applies urlValidator to every request and redirect,
+// resolves each target host with the validator's resolver at dial time, rejects the host if any
+// address violates the private-IP, CIDR, or cloud metadata policy, and dials the checked address
+// directly so a DNS rebind between the check and the connection cannot redirect the request.
+// The transport is built by tlsconfig.NewHTTPTransport and forced to TLS 1.3. Proxy environment
+// variables are ignored, since a proxy would make its own, unchecked, connection to the target.
+// Redirects are limited by the validator's WithURLCheckRedirects hop count (default 10).
+func NewSafeHTTPClient(urlValidator *URLValidator, opts ...SafeClientOption) (*http.Client, error) {
+	if urlValidator == nil {
+		return nil, ErrInvalidURLConfig
+	}
+
+	cfg := safeClientOptions{timeout: safeClientDefaultTimeout}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// TLS 1.3 is applied last so caller options cannot lower it.
+	transport, err := tlsconfig.NewHTTPTransport(append(cfg.tlsOpts, tlsconfig.WithTLS13Only())...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURLConfig, err)
+	}
+
+	transport.Proxy = nil
+	transport.DialContext = urlValidator.safeDialContext(&net.Dialer{
+		Timeout:   safeClientDialTimeout,
+		KeepAlive: safeClientKeepAlive,
+	})
+
+	return &http.Client{
+		Timeout:       cfg.timeout,
+		Transport:     &safeTransport{validator: urlValidator, base: transport},
+		CheckRedirect: urlValidator.checkSafeRedirect,
+	}, nil
+}
+
+// WithSafeClientTimeout sets the overall request timeout (default 30s).
+func WithSafeClientTimeout(timeout time.Duration) SafeClientOption {
+	return func(cfg *safeClientOptions) error {
+		if timeout <= 0 {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.timeout = timeout
+
+		return nil
+	}
+}
+
+// WithSafeClientTLSOptions adds tlsconfig options, such as tlsconfig.WithRootCAs, to the client
+// TLS config. The minimum and maximum versions are always TLS 1.3.
+func WithSafeClientTLSOptions(opts ...tlsconfig.Option) SafeClientOption {
+	return func(cfg *safeClientOptions) error {
+		cfg.tlsOpts = append(cfg.tlsOpts, opts...)
+
+		return nil
+	}
+}
+
+// safeTransport validates each outgoing request URL before handing it to the pinned transport.
+type safeTransport struct {
+	validator *URLValidator
+	base      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *safeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.validator.validateParsed(req.Context(), req.URL)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+func (v *URLValidator) checkSafeRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= v.opts.maxRedirects {
+		return ErrURLRedirectLimit
+	}
+
+	if v.opts.denyRedirectUser && req.URL.User != nil {
+		return ErrURLUserInfoNotAllowed
+	}
+
+	return v.validateParsed(req.Context(), req.URL)
+}
+
+func (v *URLValidator) safeDialContext(dialer *net.Dialer) func(context.Context, string, string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, ErrURLInvalid
+		}
+
+		host, err = normalizeHost(host, v.opts.allowIDN, v.opts.idnaProfile)
+		if err != nil {
+			return nil, newValidationError(err, FieldHost, host)
+		}
+
+		ips, err := v.resolveHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var dialErr error
+
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+
+			dialErr = err
+		}
+
+		return nil, dialErr
+	}
+}
@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hyp3rd/sectools/pkg/tlsconfig"
+)
+
+func newSafeClientServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || r.TLS.Version != tls.VersionTLS13 {
+			w.WriteHeader(http.StatusUpgradeRequired)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/insecure", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://example.com/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://internal.example/ok", http.StatusFound)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("expected server url, got %v", err)
+	}
+
+	// The httptest certificate is valid for example.com, which the fake resolver pins to the server.
+	return server, "https://example.com:" + serverURL.Port()
+}
+
+func TestSafeHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server, base := newSafeClientServer(t)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"example.com":      {"127.0.0.1"},
+			"internal.example": {"10.0.0.1"},
+		},
+	}
+
+	validator, err := NewURLValidator(WithURLDNSResolver(resolver), WithURLAllowedCIDRs("127.0.0.0/8"))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	client, err := NewSafeHTTPClient(validator, WithSafeClientTLSOptions(tlsconfig.WithRootCAs(pool)))
+	if err != nil {
+		t.Fatalf("expected safe client, got %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{path: "/ok"},
+		{path: "/insecure", want: ErrURLSchemeNotAllowed},
+		{path: "/internal", want: ErrURLPrivateIPNotAllowed},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, base+tt.path, http.NoBody)
+		if err != nil {
+			t.Fatalf("expected request, got %v", err)
+		}
+
+		resp, err := client.Do(req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		if tt.want == nil {
+			if err != nil || resp.StatusCode != http.StatusNoContent {
+				t.Fatalf("%s: expected pinned TLS 1.3 request, got %v %v", tt.path, resp, err)
+			}
+
+			continue
+		}
+
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.path, tt.want, err)
+		}
+	}
+}
+
+func TestSafeHTTPClientRejectsPrivateTargets(t *testing.T) {
+	t.Parallel()
+
+	_, base := newSafeClientServer(t)
+
+	resolver := &fakeResolver{
+		hosts: map[string][]string{"example.com": {"127.0.0.1"}},
+	}
+
+	validator, err := NewURLValidator(WithURLDNSResolver(resolver))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	client, err := NewSafeHTTPClient(validator)
+	if err != nil {
+		t.Fatalf("expected safe client, got %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, base+"/ok", http.NoBody)
+	if err != nil {
+		t.Fatalf("expected request, got %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	if !errors.Is(err, ErrURLPrivateIPNotAllowed) {
+		t.Fatalf("expected ErrURLPrivateIPNotAllowed, got %v", err)
+	}
+
+	_, err = NewSafeHTTPClient(nil)
+	if !errors.Is(err, ErrInvalidURLConfig) {
+		t.Fatalf("expected ErrInvalidURLConfig, got %v", err)
+	}
+
+	_, err = NewSafeHTTPClient(validator, WithSafeClientTimeout(0))
+	if !errors.Is(err, ErrInvalidURLConfig) {
+		t.Fatalf("expected ErrInvalidURLConfig, got %v", err)
+	}
+}
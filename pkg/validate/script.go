@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+const punycodeLabelPrefix = "xn--"
+
+// hasMixedScriptLabel reports whether any label of an ASCII host mixes Unicode scripts.
+// Punycode labels are decoded first, so pre-encoded input is checked the same way as Unicode input.
+// Labels that cannot be decoded are reported as mixed.
+func hasMixedScriptLabel(asciiHost string) bool {
+	for label := range strings.SplitSeq(asciiHost, ".") {
+		if !strings.HasPrefix(label, punycodeLabelPrefix) {
+			continue
+		}
+
+		decoded, err := idna.Punycode.ToUnicode(label)
+		if err != nil || isMixedScript(decoded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMixedScript applies the UTS #39 "highly restrictive" rule to one label: every letter must come
+// from a single script, except that Han may be combined with Hiragana and Katakana, with Hangul,
+// or with Bopomofo, and Latin may be added to any of those combinations. Common and inherited
+// characters such as digits and hyphens are ignored.
+func isMixedScript(label string) bool {
+	scripts := make(map[string]struct{})
+
+	for _, r := range label {
+		name := runeScript(r)
+		if name == "" {
+			continue
+		}
+
+		scripts[name] = struct{}{}
+	}
+
+	if len(scripts) <= 1 {
+		return false
+	}
+
+	delete(scripts, "Latin")
+
+	for _, allowed := range cjkScriptSets {
+		if scriptsWithin(scripts, allowed) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cjkScriptSets lists the script combinations that UTS #39 allows within one label.
+var cjkScriptSets = [][]string{
+	{"Han", "Hiragana", "Katakana"},
+	{"Han", "Hangul"},
+	{"Han", "Bopomofo"},
+}
+
+func scriptsWithin(scripts map[string]struct{}, allowed []string) bool {
+	for name := range scripts {
+		if !slices.Contains(allowed, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// runeScript returns the script of r, or "" for common and inherited characters.
+func runeScript(r rune) string {
+	if r < unicode.MaxASCII {
+		if unicode.IsLetter(r) {
+			return "Latin"
+		}
+
+		return ""
+	}
+
+	if unicode.Is(unicode.Common, r) || unicode.Is(unicode.Inherited, r) {
+		return ""
+	}
+
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+
+	return ""
+}
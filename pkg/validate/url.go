@@ -2,6 +2,8 @@ package validate
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -9,11 +11,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hyp3rd/hyperlogger"
 	"golang.org/x/net/idna"
+
+	"github.com/hyp3rd/sectools/pkg/secrets"
+	"github.com/hyp3rd/sectools/pkg/tlsconfig"
 )
 
 const (
 	urlDefaultMaxLength    = 2048
+	urlDefaultMaxHostLen   = 253
+	urlMaxLabelLength      = 63
 	urlDefaultMaxRedirects = 10
 	urlDefaultTimeout      = 5 * time.Second
 
@@ -30,6 +38,23 @@ const (
 	redirectStatusPermanentRedirect = 308
 )
 
+// defaultSecretQueryParams lists query parameter names that commonly carry credentials.
+var defaultSecretQueryParams = []string{
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"auth_token",
+	"token",
+	"api_key",
+	"apikey",
+	"api-key",
+	"client_secret",
+	"secret",
+	"password",
+	"passwd",
+	"pwd",
+}
+
 // URLReputationChecker evaluates a URL's reputation.
 type URLReputationChecker interface {
 	Check(ctx context.Context, target *url.URL) (ReputationResult, error)
@@ -107,18 +132,37 @@ type URLOption func(*urlOptions) error
 type urlOptions struct {
 	allowedSchemes    map[string]struct{}
 	allowUserInfo     bool
+	denyRedirectUser  bool
 	allowIDN          bool
+	idnaProfile       *idna.Profile
+	rejectMixedScript bool
 	allowIPLiteral    bool
 	allowPrivateIP    bool
 	allowLocalhost    bool
 	maxLength         int
+	maxHostLength     int
+	maxPathLength     int
+	maxQueryLength    int
 	checkRedirects    bool
 	maxRedirects      int
 	redirectMethod    string
+	requestHeaders    http.Header
+	userAgent         string
+	noForwardCreds    bool
 	httpClient        *http.Client
+	redirectTLS       *tls.Config
 	reputationChecker URLReputationChecker
 	allowedHosts      map[string]struct{}
 	blockedHosts      map[string]struct{}
+	allowedCIDRs      []*net.IPNet
+	blockedCIDRs      []*net.IPNet
+	blockMetadata     bool
+	secretQueryParams map[string]struct{}
+	secretDetector    *secrets.SecretDetector
+	pathDetector      *secrets.SecretDetector
+	logger            hyperlogger.Logger
+	logDetector       *secrets.SecretDetector
+	resolver          DNSResolver
 }
 
 // URLResult describes URL validation output.
@@ -130,10 +174,14 @@ type URLResult struct {
 }
 
 // URLRedirect captures a single redirect hop.
+// RawLocation is the Location header as received; Resolved reports whether it was
+// relative and resolved against From to produce To.
 type URLRedirect struct {
-	From       string
-	To         string
-	StatusCode int
+	From        string
+	To          string
+	StatusCode  int
+	RawLocation string
+	Resolved    bool
 }
 
 // URLValidator validates URLs with optional redirect and reputation checks.
@@ -147,7 +195,9 @@ func NewURLValidator(opts ...URLOption) (*URLValidator, error) {
 		allowedSchemes: map[string]struct{}{
 			schemeHTTPS: {},
 		},
+		idnaProfile:    idna.Lookup,
 		maxLength:      urlDefaultMaxLength,
+		maxHostLength:  urlDefaultMaxHostLen,
 		maxRedirects:   urlDefaultMaxRedirects,
 		redirectMethod: httpMethodHead,
 	}
@@ -168,6 +218,16 @@ func NewURLValidator(opts ...URLOption) (*URLValidator, error) {
 		return nil, err
 	}
 
+	err = prepareURLLogging(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = prepareURLHTTPClient(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &URLValidator{opts: cfg}, nil
 }
 
@@ -208,6 +268,16 @@ func WithURLAllowUserInfo(allow bool) URLOption {
 	}
 }
 
+// WithURLForbidUserInfoOnRedirect rejects redirect Locations carrying userinfo, even when
+// WithURLAllowUserInfo permits it on the initial URL.
+func WithURLForbidUserInfoOnRedirect() URLOption {
+	return func(cfg *urlOptions) error {
+		cfg.denyRedirectUser = true
+
+		return nil
+	}
+}
+
 // WithURLAllowIDN allows IDN hostnames.
 func WithURLAllowIDN(allow bool) URLOption {
 	return func(cfg *urlOptions) error {
@@ -217,6 +287,34 @@ func WithURLAllowIDN(allow bool) URLOption {
 	}
 }
 
+// WithURLIDNAProfile sets the IDNA profile used to convert IDN hostnames to ASCII (default idna.Lookup).
+// It only applies when WithURLAllowIDN is enabled. Looser profiles such as idna.Display or
+// idna.Punycode accept labels that idna.Lookup rejects, including mixed-script and disallowed
+// characters, which widens the room for homograph hosts; pair them with WithURLAllowedHosts.
+func WithURLIDNAProfile(profile *idna.Profile) URLOption {
+	return func(cfg *urlOptions) error {
+		if profile == nil {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.idnaProfile = profile
+
+		return nil
+	}
+}
+
+// WithURLRejectMixedScripts rejects hosts with a label that mixes Unicode scripts, such as Latin
+// and Cyrillic, with ErrURLHostNotAllowed. Labels are checked after IDN normalization, including
+// hosts given in punycode form. Han combined with Hiragana/Katakana, Hangul, or Bopomofo, plus
+// Latin, is still accepted.
+func WithURLRejectMixedScripts() URLOption {
+	return func(cfg *urlOptions) error {
+		cfg.rejectMixedScript = true
+
+		return nil
+	}
+}
+
 // WithURLAllowIPLiteral allows IP literal hosts.
 func WithURLAllowIPLiteral(allow bool) URLOption {
 	return func(cfg *urlOptions) error {
@@ -257,6 +355,47 @@ func WithURLMaxLength(maxLen int) URLOption {
 	}
 }
 
+// WithURLMaxHostLength sets the maximum host length in ASCII (punycode) form.
+func WithURLMaxHostLength(maxLen int) URLOption {
+	return func(cfg *urlOptions) error {
+		if maxLen <= 0 {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.maxHostLength = maxLen
+
+		return nil
+	}
+}
+
+// WithURLMaxPathLength sets the maximum length of the escaped URL path. It is unlimited by default,
+// apart from the overall WithURLMaxLength cap.
+func WithURLMaxPathLength(maxLen int) URLOption {
+	return func(cfg *urlOptions) error {
+		if maxLen <= 0 {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.maxPathLength = maxLen
+
+		return nil
+	}
+}
+
+// WithURLMaxQueryLength sets the maximum length of the raw URL query, excluding the leading "?".
+// It is unlimited by default, apart from the overall WithURLMaxLength cap.
+func WithURLMaxQueryLength(maxLen int) URLOption {
+	return func(cfg *urlOptions) error {
+		if maxLen <= 0 {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.maxQueryLength = maxLen
+
+		return nil
+	}
+}
+
 // WithURLCheckRedirects enables redirect checks with a max hop count.
 func WithURLCheckRedirects(maxRedirects int) URLOption {
 	return func(cfg *urlOptions) error {
@@ -298,6 +437,35 @@ func WithURLHTTPClient(client *http.Client) URLOption {
 	}
 }
 
+// WithURLRedirectTLSConfig sets the TLS config used by the built-in redirect client.
+// The config must set MinVersion to TLS 1.2 or higher and keep certificate verification on.
+// It cannot be combined with WithURLHTTPClient; configure a custom client's transport directly.
+func WithURLRedirectTLSConfig(tlsCfg *tls.Config) URLOption {
+	return func(cfg *urlOptions) error {
+		if tlsCfg == nil || tlsCfg.MinVersion < tls.VersionTLS12 || tlsCfg.InsecureSkipVerify {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.redirectTLS = tlsCfg.Clone()
+
+		return nil
+	}
+}
+
+// WithURLRequireTLS13 restricts the built-in redirect client to TLS 1.3.
+func WithURLRequireTLS13() URLOption {
+	return func(cfg *urlOptions) error {
+		tlsCfg, err := tlsconfig.NewClientConfig(tlsconfig.WithTLS13Only())
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidURLConfig, err)
+		}
+
+		cfg.redirectTLS = tlsCfg
+
+		return nil
+	}
+}
+
 // WithURLReputationChecker sets a reputation checker.
 func WithURLReputationChecker(checker URLReputationChecker) URLOption {
 	return func(cfg *urlOptions) error {
@@ -329,6 +497,80 @@ func WithURLBlockedHosts(hosts ...string) URLOption {
 	}
 }
 
+// WithURLBlockedCIDRs rejects IP literal hosts inside the given ranges, even when private IPs are allowed.
+// Entries may be CIDRs or single addresses, such as the 169.254.169.254 metadata endpoint.
+func WithURLBlockedCIDRs(cidrs ...string) URLOption {
+	return func(cfg *urlOptions) error {
+		networks, err := parseCIDRs(cidrs)
+		if err != nil {
+			return err
+		}
+
+		cfg.blockedCIDRs = networks
+
+		return nil
+	}
+}
+
+// WithURLAllowedCIDRs permits IP literal hosts inside the given ranges even when they are private.
+// Blocked CIDRs take precedence over allowed ones.
+func WithURLAllowedCIDRs(cidrs ...string) URLOption {
+	return func(cfg *urlOptions) error {
+		networks, err := parseCIDRs(cidrs)
+		if err != nil {
+			return err
+		}
+
+		cfg.allowedCIDRs = networks
+
+		return nil
+	}
+}
+
+// WithURLRejectSecretQueryParams rejects URLs carrying credential-like query parameters.
+// The provided names are matched case-insensitively in addition to a default list.
+func WithURLRejectSecretQueryParams(names ...string) URLOption {
+	return func(cfg *urlOptions) error {
+		params := normalizeHostSet(defaultSecretQueryParams)
+		for name := range normalizeHostSet(names) {
+			params[name] = struct{}{}
+		}
+
+		cfg.secretQueryParams = params
+
+		return nil
+	}
+}
+
+// WithURLSecretQueryDetector scans query parameter values with a secret detector.
+func WithURLSecretQueryDetector(detector *secrets.SecretDetector) URLOption {
+	return func(cfg *urlOptions) error {
+		if detector == nil {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.secretDetector = detector
+
+		return nil
+	}
+}
+
+// WithURLScanPathForSecrets scans the decoded path, query parameter names and values with a secret
+// detector and rejects URLs carrying a high or critical severity match with ErrURLSecretInPath, for
+// APIs that embed tokens in the path (/v1/keys/sk_live_...). Components longer than the detector's
+// max length fail with ErrURLInvalid rather than going unscanned.
+func WithURLScanPathForSecrets(detector *secrets.SecretDetector) URLOption {
+	return func(cfg *urlOptions) error {
+		if detector == nil {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.pathDetector = detector
+
+		return nil
+	}
+}
+
 // Validate validates the URL, optionally checking redirects and reputation.
 func (v *URLValidator) Validate(ctx context.Context, raw string) (URLResult, error) {
 	trimmed := strings.TrimSpace(raw)
@@ -345,7 +587,29 @@ func (v *URLValidator) Validate(ctx context.Context, raw string) (URLResult, err
 		return URLResult{}, ErrURLInvalid
 	}
 
-	err = v.validateParsed(parsed)
+	return v.ValidateURL(ctx, parsed)
+}
+
+// ValidateURL validates an already-parsed URL, optionally checking redirects and reputation.
+func (v *URLValidator) ValidateURL(ctx context.Context, parsed *url.URL) (URLResult, error) {
+	started := time.Now()
+
+	result, err := v.validateURL(ctx, parsed)
+	v.logResult(parsed, result, err, started)
+
+	return result, err
+}
+
+func (v *URLValidator) validateURL(ctx context.Context, parsed *url.URL) (URLResult, error) {
+	if parsed == nil {
+		return URLResult{}, ErrURLInvalid
+	}
+
+	if len(parsed.String()) > v.opts.maxLength {
+		return URLResult{}, ErrURLTooLong
+	}
+
+	err := v.validateParsed(ctx, parsed)
 	if err != nil {
 		return URLResult{}, err
 	}
@@ -407,6 +671,33 @@ func validateURLOptions(cfg *urlOptions) error {
 		return ErrInvalidURLConfig
 	}
 
+	if cfg.httpClient != nil && cfg.redirectTLS != nil {
+		return ErrInvalidURLConfig
+	}
+
+	return nil
+}
+
+// prepareURLHTTPClient builds the redirect client when no custom client was provided.
+// Its transport comes from tlsconfig.NewHTTPTransport, so redirect checks require TLS 1.3 by
+// default and fail against hosts that only offer TLS 1.2. WithURLRedirectTLSConfig with a
+// TLS 1.2 MinVersion restores the older behavior; WithURLHTTPClient bypasses this entirely.
+func prepareURLHTTPClient(cfg *urlOptions) error {
+	if cfg.httpClient != nil {
+		return nil
+	}
+
+	transport, err := tlsconfig.NewHTTPTransport()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidURLConfig, err)
+	}
+
+	if cfg.redirectTLS != nil {
+		transport.TLSClientConfig = cfg.redirectTLS
+	}
+
+	cfg.httpClient = &http.Client{Timeout: urlDefaultTimeout, Transport: transport}
+
 	return nil
 }
 
@@ -425,12 +716,22 @@ func normalizeHostSet(hosts []string) map[string]struct{} {
 	return clean
 }
 
-func (v *URLValidator) validateParsed(parsed *url.URL) error {
+func (v *URLValidator) validateParsed(ctx context.Context, parsed *url.URL) error {
 	if parsed == nil {
 		return ErrURLInvalid
 	}
 
-	err := v.validateScheme(parsed)
+	err := contextErr(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = validateAbsolute(parsed)
+	if err != nil {
+		return err
+	}
+
+	err = v.validateScheme(parsed)
 	if err != nil {
 		return err
 	}
@@ -440,17 +741,56 @@ func (v *URLValidator) validateParsed(parsed *url.URL) error {
 		return err
 	}
 
+	err = v.validateComponentLengths(parsed)
+	if err != nil {
+		return err
+	}
+
+	err = v.validateQuerySecrets(ctx, parsed)
+	if err != nil {
+		return err
+	}
+
+	err = v.validatePathSecrets(ctx, parsed)
+	if err != nil {
+		return err
+	}
+
 	host, err := v.normalizedHost(parsed)
+	if errors.Is(err, ErrURLHostNotAllowed) {
+		return newValidationError(err, FieldHost, parsed.Hostname())
+	}
+
 	if err != nil {
 		return err
 	}
 
 	err = v.validateHost(host)
 	if err != nil {
-		return err
+		return newValidationError(err, FieldHost, host)
 	}
 
-	return v.validateIPHost(host)
+	err = v.validateIPHost(host)
+	if err != nil {
+		return newValidationError(err, FieldHost, host)
+	}
+
+	return nil
+}
+
+// validateAbsolute rejects relative references ("/path", "//host/path"), opaque URLs ("https:foo"),
+// and path-only forms without an authority ("https:/path"), so ErrURLHostMissing is left for URLs
+// that have an authority with an empty host, such as "https:///path".
+func validateAbsolute(parsed *url.URL) error {
+	if parsed.Scheme == "" || parsed.Opaque != "" {
+		return ErrURLNotAbsolute
+	}
+
+	if parsed.Host == "" && parsed.OmitHost {
+		return ErrURLNotAbsolute
+	}
+
+	return nil
 }
 
 func (v *URLValidator) validateScheme(parsed *url.URL) error {
@@ -460,7 +800,7 @@ func (v *URLValidator) validateScheme(parsed *url.URL) error {
 	}
 
 	if _, ok := v.opts.allowedSchemes[scheme]; !ok {
-		return ErrURLSchemeNotAllowed
+		return newValidationError(ErrURLSchemeNotAllowed, FieldScheme, scheme)
 	}
 
 	return nil
@@ -474,25 +814,165 @@ func (v *URLValidator) validateUserInfo(parsed *url.URL) error {
 	return nil
 }
 
+func (v *URLValidator) validateComponentLengths(parsed *url.URL) error {
+	if v.opts.maxPathLength > 0 && len(parsed.EscapedPath()) > v.opts.maxPathLength {
+		return ErrURLComponentTooLong
+	}
+
+	if v.opts.maxQueryLength > 0 && len(parsed.RawQuery) > v.opts.maxQueryLength {
+		return ErrURLComponentTooLong
+	}
+
+	return nil
+}
+
+func (v *URLValidator) validateQuerySecrets(ctx context.Context, parsed *url.URL) error {
+	if len(v.opts.secretQueryParams) == 0 && v.opts.secretDetector == nil {
+		return nil
+	}
+
+	if parsed.RawQuery == "" {
+		return nil
+	}
+
+	for name, values := range parsed.Query() {
+		err := contextErr(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := v.opts.secretQueryParams[strings.ToLower(name)]; ok {
+			return newValidationError(ErrURLSecretInQuery, FieldQueryParam, name)
+		}
+
+		if v.opts.secretDetector == nil {
+			continue
+		}
+
+		for _, value := range values {
+			err := v.opts.secretDetector.DetectAny(value)
+			if errors.Is(err, secrets.ErrSecretDetected) {
+				return newValidationError(ErrURLSecretInQuery, FieldQueryParam, name)
+			}
+
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrURLInvalid, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *URLValidator) validatePathSecrets(ctx context.Context, parsed *url.URL) error {
+	if v.opts.pathDetector == nil {
+		return nil
+	}
+
+	err := v.detectPathSecret(parsed.Path)
+	if err != nil {
+		return err
+	}
+
+	if parsed.RawQuery == "" {
+		return nil
+	}
+
+	for name, values := range parsed.Query() {
+		err := contextErr(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = v.detectPathSecret(name)
+		if err != nil {
+			return err
+		}
+
+		for _, value := range values {
+			err := v.detectPathSecret(value)
+			if errors.Is(err, ErrURLSecretInPath) {
+				return newValidationError(err, FieldQueryParam, name)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *URLValidator) detectPathSecret(input string) error {
+	matches, err := v.opts.pathDetector.DetectAbove(input, secrets.SeverityHigh)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrURLInvalid, err)
+	}
+
+	if len(matches) > 0 {
+		return ErrURLSecretInPath
+	}
+
+	return nil
+}
+
 func (v *URLValidator) normalizedHost(parsed *url.URL) (string, error) {
 	host := parsed.Hostname()
 	if host == "" {
 		return "", ErrURLHostMissing
 	}
 
-	return normalizeHost(host, v.opts.allowIDN)
+	return normalizeHost(host, v.opts.allowIDN, v.opts.idnaProfile)
 }
 
 func (v *URLValidator) validateHost(host string) error {
+	err := v.checkCloudMetadata(host)
+	if err != nil {
+		return err
+	}
+
+	if v.opts.rejectMixedScript && hasMixedScriptLabel(host) {
+		return ErrURLHostNotAllowed
+	}
+
 	if !v.opts.allowLocalhost && isLocalhost(host) {
 		return ErrURLHostNotAllowed
 	}
 
+	err = v.validateHostLabels(host)
+	if err != nil {
+		return err
+	}
+
 	return v.checkHostRestrictions(host)
 }
 
+// validateHostLabels enforces DNS length limits on the ASCII host; IP literals are checked separately.
+func (v *URLValidator) validateHostLabels(host string) error {
+	if ip, _ := parseIPHost(host); ip != nil {
+		return nil
+	}
+
+	if len(host) > v.opts.maxHostLength {
+		return ErrURLHostNotAllowed
+	}
+
+	for label := range strings.SplitSeq(host, ".") {
+		if label == "" || len(label) > urlMaxLabelLength {
+			return ErrURLHostNotAllowed
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return ErrURLHostNotAllowed
+		}
+	}
+
+	return nil
+}
+
 func (v *URLValidator) validateIPHost(host string) error {
-	ip := net.ParseIP(host)
+	ip, zoned := parseIPHost(host)
 	if ip == nil {
 		return nil
 	}
@@ -501,14 +981,28 @@ func (v *URLValidator) validateIPHost(host string) error {
 		return ErrURLHostNotAllowed
 	}
 
-	if !v.opts.allowPrivateIP && isPrivateIP(ip) {
+	return v.checkIPPolicy(ip, zoned)
+}
+
+// checkIPPolicy applies the CIDR and private-IP rules to a literal or resolved address.
+func (v *URLValidator) checkIPPolicy(ip net.IP, zoned bool) error {
+	if ipInNetworks(ip, v.opts.blockedCIDRs) {
+		return ErrURLPrivateIPNotAllowed
+	}
+
+	if ipInNetworks(ip, v.opts.allowedCIDRs) {
+		return nil
+	}
+
+	// Zone identifiers only scope non-global addresses, so zoned literals are never public.
+	if !v.opts.allowPrivateIP && (zoned || ClassifyIP(ip) != IPClassPublic) {
 		return ErrURLPrivateIPNotAllowed
 	}
 
 	return nil
 }
 
-func normalizeHost(host string, allowIDN bool) (string, error) {
+func normalizeHost(host string, allowIDN bool, profile *idna.Profile) (string, error) {
 	normalized := strings.TrimSuffix(host, ".")
 	if normalized == "" {
 		return "", ErrURLHostMissing
@@ -519,7 +1013,7 @@ func normalizeHost(host string, allowIDN bool) (string, error) {
 			return "", ErrURLHostNotAllowed
 		}
 
-		converted, err := idna.Lookup.ToASCII(normalized)
+		converted, err := profile.ToASCII(normalized)
 		if err != nil {
 			return "", ErrURLHostNotAllowed
 		}
@@ -548,22 +1042,6 @@ func isLocalhost(host string) bool {
 	return host == "localhost" || strings.HasSuffix(host, ".localhost")
 }
 
-func isPrivateIP(ip net.IP) bool {
-	if ip == nil {
-		return false
-	}
-
-	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
-		return true
-	}
-
-	if ip.IsPrivate() {
-		return true
-	}
-
-	return ip.IsMulticast()
-}
-
 func (v *URLValidator) followRedirects(ctx context.Context, start *url.URL) (*url.URL, []URLRedirect, error) {
 	if ctx == nil {
 		return nil, nil, ErrURLInvalid
@@ -573,8 +1051,15 @@ func (v *URLValidator) followRedirects(ctx context.Context, start *url.URL) (*ur
 	current := start
 	visited := make(map[string]struct{})
 	redirects := make([]URLRedirect, 0)
+	// Once set, credential headers stay stripped for the rest of the chain.
+	stripCredentials := false
 
 	for range v.opts.maxRedirects {
+		err := ctx.Err()
+		if err != nil {
+			return nil, nil, err
+		}
+
 		hopKey := current.String()
 		if _, ok := visited[hopKey]; ok {
 			return nil, nil, ErrURLRedirectLoop
@@ -582,7 +1067,7 @@ func (v *URLValidator) followRedirects(ctx context.Context, start *url.URL) (*ur
 
 		visited[hopKey] = struct{}{}
 
-		nextURL, redirect, err := v.nextRedirect(ctx, client, current)
+		nextURL, redirect, err := v.nextRedirect(ctx, client, current, v.redirectHeaders(stripCredentials))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -592,21 +1077,43 @@ func (v *URLValidator) followRedirects(ctx context.Context, start *url.URL) (*ur
 		}
 
 		redirects = append(redirects, *redirect)
+		v.logDebug("url redirect",
+			hyperlogger.Field{Key: "from", Value: v.logSafeURL(current)},
+			hyperlogger.Field{Key: "to", Value: v.logSafeURL(nextURL)},
+			hyperlogger.Field{Key: "status", Value: redirect.StatusCode},
+		)
+
+		if v.opts.noForwardCreds || !sameOrigin(current, nextURL) {
+			stripCredentials = true
+		}
+
 		current = nextURL
 	}
 
 	return nil, nil, ErrURLRedirectLimit
 }
 
-func (v *URLValidator) nextRedirect(ctx context.Context, client *http.Client, current *url.URL) (*url.URL, *URLRedirect, error) {
+func (v *URLValidator) nextRedirect(
+	ctx context.Context,
+	client *http.Client,
+	current *url.URL,
+	headers http.Header,
+) (*url.URL, *URLRedirect, error) {
 	req, err := http.NewRequestWithContext(ctx, v.opts.redirectMethod, current.String(), nil)
 	if err != nil {
 		return nil, nil, ErrURLInvalid
 	}
 
+	req.Header = headers
+
 	// #nosec G704 -- URL has already passed scheme/host/IP policy validation before this request.
 	resp, err := client.Do(req)
 	if err != nil {
+		ctxErr := ctx.Err()
+		if ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+
 		return nil, nil, ErrURLRedirectNotAllowed
 	}
 
@@ -627,17 +1134,25 @@ func (v *URLValidator) nextRedirect(ctx context.Context, client *http.Client, cu
 		return nil, nil, ErrURLRedirectNotAllowed
 	}
 
+	// Checked before resolution: relative Locations inherit the current URL's userinfo.
+	if v.opts.denyRedirectUser && nextURL.User != nil {
+		return nil, nil, ErrURLUserInfoNotAllowed
+	}
+
+	resolved := !nextURL.IsAbs()
 	nextURL = current.ResolveReference(nextURL)
 
-	err = v.validateParsed(nextURL)
+	err = v.validateParsed(ctx, nextURL)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	redirect := URLRedirect{
-		From:       current.String(),
-		To:         nextURL.String(),
-		StatusCode: resp.StatusCode,
+		From:        current.String(),
+		To:          nextURL.String(),
+		StatusCode:  resp.StatusCode,
+		RawLocation: location,
+		Resolved:    resolved,
 	}
 
 	return nextURL, &redirect, nil
@@ -657,13 +1172,9 @@ func isRedirectStatus(code int) bool {
 	}
 }
 
+// httpClient returns a copy of the client set by prepareURLHTTPClient that does not follow redirects.
 func (v *URLValidator) httpClient() *http.Client {
-	client := v.opts.httpClient
-	if client == nil {
-		client = &http.Client{Timeout: urlDefaultTimeout}
-	}
-
-	clone := *client
+	clone := *v.opts.httpClient
 	clone.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
 		return http.ErrUseLastResponse
 	}
@@ -681,6 +1192,11 @@ func (v *URLValidator) checkReputation(ctx context.Context, target *url.URL) err
 		return fmt.Errorf("%w: %w", ErrURLReputationFailed, err)
 	}
 
+	v.logDebug("url reputation",
+		hyperlogger.Field{Key: "url", Value: v.logSafeURL(target)},
+		hyperlogger.Field{Key: "verdict", Value: result.Verdict},
+	)
+
 	if result.Verdict == ReputationBlocked {
 		return ErrURLReputationBlocked
 	}
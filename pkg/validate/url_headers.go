@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	headerHost       = "Host"
+	headerUserAgent  = "User-Agent"
+	defaultHTTPSPort = "443"
+)
+
+// credentialHeaders are removed from redirect hops once the chain changes origin, as net/http does.
+var credentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// WithURLRequestHeaders sets headers sent on every redirect-check request, replacing earlier calls.
+// Credential headers (Authorization, Cookie, Proxy-Authorization) are dropped once a hop changes
+// origin; see WithURLForwardCredentialsSameOrigin. Invalid header names or values and the Host
+// header return ErrInvalidURLConfig.
+func WithURLRequestHeaders(headers http.Header) URLOption {
+	return func(cfg *urlOptions) error {
+		if len(headers) == 0 {
+			return ErrInvalidURLConfig
+		}
+
+		clean := make(http.Header, len(headers))
+
+		for name, values := range headers {
+			if !httpguts.ValidHeaderFieldName(name) || http.CanonicalHeaderKey(name) == headerHost {
+				return ErrInvalidURLConfig
+			}
+
+			for _, value := range values {
+				if !httpguts.ValidHeaderFieldValue(value) {
+					return ErrInvalidURLConfig
+				}
+
+				clean.Add(name, value)
+			}
+		}
+
+		cfg.requestHeaders = clean
+
+		return nil
+	}
+}
+
+// WithURLForwardCredentialsSameOrigin controls whether credential headers from WithURLRequestHeaders
+// are forwarded to redirect hops. With true (the default) they are sent until a hop changes scheme,
+// host, or port, and never again for the rest of the chain, even if it returns to the first origin.
+// With false they are sent only on the request for the initial URL.
+func WithURLForwardCredentialsSameOrigin(forward bool) URLOption {
+	return func(cfg *urlOptions) error {
+		cfg.noForwardCreds = !forward
+
+		return nil
+	}
+}
+
+// WithURLUserAgent sets the User-Agent of redirect-check requests, overriding one set with
+// WithURLRequestHeaders. Without it, net/http sends its default "Go-http-client/1.1".
+func WithURLUserAgent(userAgent string) URLOption {
+	return func(cfg *urlOptions) error {
+		value := strings.TrimSpace(userAgent)
+		if value == "" || !httpguts.ValidHeaderFieldValue(value) {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.userAgent = value
+
+		return nil
+	}
+}
+
+// redirectHeaders returns the headers for one redirect-check request, without credential
+// headers when stripCredentials is set.
+func (v *URLValidator) redirectHeaders(stripCredentials bool) http.Header {
+	headers := v.opts.requestHeaders.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	if v.opts.userAgent != "" {
+		headers.Set(headerUserAgent, v.opts.userAgent)
+	}
+
+	if stripCredentials {
+		for _, name := range credentialHeaders {
+			headers.Del(name)
+		}
+	}
+
+	return headers
+}
+
+// sameOrigin compares scheme, host, and port, filling in the default port for the scheme.
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Hostname(), b.Hostname()) &&
+		originPort(a) == originPort(b)
+}
+
+func originPort(target *url.URL) string {
+	port := target.Port()
+	if port == "" && strings.EqualFold(target.Scheme, schemeHTTPS) {
+		return defaultHTTPSPort
+	}
+
+	return port
+}
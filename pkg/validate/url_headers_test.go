@@ -0,0 +1,216 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingRoundTripper answers from responses and records the headers sent to each URL.
+type recordingRoundTripper struct {
+	mu        sync.Mutex
+	responses map[string]*http.Response
+	headers   map[string]http.Header
+}
+
+func (r *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.headers == nil {
+		r.headers = make(map[string]http.Header)
+	}
+
+	r.headers[req.URL.String()] = req.Header.Clone()
+
+	if resp, ok := r.responses[req.URL.String()]; ok {
+		return resp, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func (r *recordingRoundTripper) sent(rawURL string) http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.headers[rawURL]
+}
+
+func redirectResponse(location string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusFound,
+		Header:     http.Header{"Location": []string{location}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+}
+
+func TestURLRedirectRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	transport := &recordingRoundTripper{
+		responses: map[string]*http.Response{
+			"https://example.com/start": redirectResponse("/next"),
+			"https://example.com/next":  redirectResponse("https://other.example.com/final"),
+		},
+	}
+
+	validator, err := NewURLValidator(
+		WithURLCheckRedirects(3),
+		WithURLHTTPClient(&http.Client{Transport: transport}),
+		WithURLRequestHeaders(http.Header{
+			"Authorization": []string{"Bearer token"},
+			"X-Trace":       []string{"abc"},
+			"User-Agent":    []string{"ignored"},
+		}),
+		WithURLUserAgent("sectools-link-check/1.0"),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "https://example.com/start")
+	if err != nil {
+		t.Fatalf("expected valid url, got %v", err)
+	}
+
+	for _, hop := range []string{"https://example.com/start", "https://example.com/next"} {
+		sent := transport.sent(hop)
+		if sent.Get("Authorization") != "Bearer token" || sent.Get("X-Trace") != "abc" {
+			t.Fatalf("expected caller headers on same-origin hop %s, got %v", hop, sent)
+		}
+
+		if sent.Get("User-Agent") != "sectools-link-check/1.0" {
+			t.Fatalf("expected custom user agent on %s, got %q", hop, sent.Get("User-Agent"))
+		}
+	}
+
+	final := transport.sent("https://other.example.com/final")
+	if final.Get("Authorization") != "" {
+		t.Fatalf("expected Authorization dropped on cross-origin hop, got %q", final.Get("Authorization"))
+	}
+
+	if final.Get("X-Trace") != "abc" {
+		t.Fatalf("expected non-credential headers kept on cross-origin hop, got %v", final)
+	}
+}
+
+func TestURLRedirectCredentialStripping(t *testing.T) {
+	t.Parallel()
+
+	credentials := http.Header{
+		"Authorization":       []string{"Bearer token"},
+		"Cookie":              []string{"session=1"},
+		"Proxy-Authorization": []string{"Basic cHJveHk="},
+	}
+
+	newTransport := func() *recordingRoundTripper {
+		return &recordingRoundTripper{
+			responses: map[string]*http.Response{
+				"https://example.com/start":     redirectResponse("/same"),
+				"https://example.com/same":      redirectResponse("https://example.com:8443/port"),
+				"https://example.com:8443/port": redirectResponse("https://example.com/back"),
+			},
+		}
+	}
+
+	hasCredentials := func(sent http.Header) bool {
+		for name := range credentials {
+			if sent.Get(name) == "" {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	hasAnyCredential := func(sent http.Header) bool {
+		for name := range credentials {
+			if sent.Get(name) != "" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	transport := newTransport()
+
+	validator, err := NewURLValidator(
+		WithURLCheckRedirects(5),
+		WithURLHTTPClient(&http.Client{Transport: transport}),
+		WithURLRequestHeaders(credentials),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "https://example.com/start")
+	if err != nil {
+		t.Fatalf("expected valid url, got %v", err)
+	}
+
+	if !hasCredentials(transport.sent("https://example.com/start")) || !hasCredentials(transport.sent("https://example.com/same")) {
+		t.Fatal("expected credentials on same-origin hops")
+	}
+
+	// A port change is an origin change, and stripping sticks after the chain returns.
+	for _, hop := range []string{"https://example.com:8443/port", "https://example.com/back"} {
+		if hasAnyCredential(transport.sent(hop)) {
+			t.Fatalf("expected credentials stripped on %s, got %v", hop, transport.sent(hop))
+		}
+	}
+
+	transport = newTransport()
+
+	validator, err = NewURLValidator(
+		WithURLCheckRedirects(5),
+		WithURLHTTPClient(&http.Client{Transport: transport}),
+		WithURLRequestHeaders(credentials),
+		WithURLForwardCredentialsSameOrigin(false),
+	)
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "https://example.com/start")
+	if err != nil {
+		t.Fatalf("expected valid url, got %v", err)
+	}
+
+	if !hasCredentials(transport.sent("https://example.com/start")) {
+		t.Fatal("expected credentials on the initial request")
+	}
+
+	if hasAnyCredential(transport.sent("https://example.com/same")) {
+		t.Fatal("expected credentials stripped on same-origin redirect when forwarding is disabled")
+	}
+}
+
+func TestURLRequestHeadersInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []URLOption{
+		WithURLRequestHeaders(nil),
+		WithURLRequestHeaders(http.Header{"Bad Name": []string{"x"}}),
+		WithURLRequestHeaders(http.Header{"X-Test": []string{"line\nbreak"}}),
+		WithURLRequestHeaders(http.Header{"Host": []string{"evil.example"}}),
+		WithURLUserAgent(" "),
+		WithURLUserAgent("agent\r\nX-Injected: 1"),
+	}
+
+	for i, opt := range invalid {
+		_, err := NewURLValidator(opt)
+		if !errors.Is(err, ErrInvalidURLConfig) {
+			t.Fatalf("case %d: expected ErrInvalidURLConfig, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// WithURLDNSResolver sets the resolver used by ValidateAndResolve. Defaults to net.DefaultResolver.
+func WithURLDNSResolver(resolver DNSResolver) URLOption {
+	return func(cfg *urlOptions) error {
+		if resolver == nil {
+			return ErrInvalidURLConfig
+		}
+
+		cfg.resolver = resolver
+
+		return nil
+	}
+}
+
+// ValidateAndResolve validates the URL like Validate, then resolves the host of the final URL and
+// applies the private-IP, CIDR, and cloud metadata policy to every returned address.
+// Dial one of the returned IPs directly, using the validated hostname for SNI and the Host header,
+// so a DNS rebind between validation and connection cannot redirect the request.
+func (v *URLValidator) ValidateAndResolve(ctx context.Context, raw string) (URLResult, []net.IP, error) {
+	result, err := v.Validate(ctx, raw)
+	if err != nil {
+		return URLResult{}, nil, err
+	}
+
+	final, err := url.Parse(result.FinalURL)
+	if err != nil {
+		return URLResult{}, nil, ErrURLInvalid
+	}
+
+	host, err := v.normalizedHost(final)
+	if err != nil {
+		return URLResult{}, nil, err
+	}
+
+	ips, err := v.resolveHost(ctx, host)
+	if err != nil {
+		return URLResult{}, nil, err
+	}
+
+	return result, ips, nil
+}
+
+// resolveHost returns the addresses for host, rejecting the host if any address violates policy.
+func (v *URLValidator) resolveHost(ctx context.Context, host string) ([]net.IP, error) {
+	if ip, _ := parseIPHost(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	resolver := v.opts.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		ctxErr := contextErr(ctx)
+		if ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrURLResolveFailed, err)
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+
+	for _, addr := range addrs {
+		ip, zoned := parseIPHost(addr)
+		if ip == nil {
+			return nil, ErrURLResolveFailed
+		}
+
+		err := v.checkResolvedIP(host, ip, zoned)
+		if err != nil {
+			return nil, newValidationError(err, FieldHost, host)
+		}
+
+		ips = append(ips, ip)
+	}
+
+	if len(ips) == 0 {
+		return nil, ErrURLResolveFailed
+	}
+
+	return ips, nil
+}
+
+func (v *URLValidator) checkResolvedIP(host string, ip net.IP, zoned bool) error {
+	err := v.checkCloudMetadata(ip.String())
+	if err != nil {
+		return err
+	}
+
+	// An explicitly allowed localhost name is expected to resolve to loopback.
+	if v.opts.allowLocalhost && isLocalhost(host) && ip.IsLoopback() {
+		return nil
+	}
+
+	return v.checkIPPolicy(ip, zoned)
+}
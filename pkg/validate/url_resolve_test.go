@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestURLValidateAndResolve(t *testing.T) {
+	t.Parallel()
+
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"public.example":   {"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"},
+			"rebind.example":   {"93.184.216.34", "10.0.0.1"},
+			"metadata.example": {"169.254.169.254"},
+		},
+		hostErr: map[string]error{
+			"missing.example": &net.DNSError{Err: "no such host", IsNotFound: true},
+		},
+	}
+
+	validator, err := NewURLValidator(WithURLDNSResolver(resolver), WithURLBlockCloudMetadata())
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	result, ips, err := validator.ValidateAndResolve(context.Background(), "https://public.example/path")
+	if err != nil {
+		t.Fatalf("expected resolved url, got %v", err)
+	}
+
+	if result.FinalURL != "https://public.example/path" || len(ips) != 2 || !ips[0].Equal(net.ParseIP("93.184.216.34")) {
+		t.Fatalf("unexpected result %+v with ips %v", result, ips)
+	}
+
+	_, _, err = validator.ValidateAndResolve(context.Background(), "https://rebind.example")
+	if !errors.Is(err, ErrURLPrivateIPNotAllowed) {
+		t.Fatalf("expected ErrURLPrivateIPNotAllowed, got %v", err)
+	}
+
+	_, _, err = validator.ValidateAndResolve(context.Background(), "https://metadata.example")
+	if !errors.Is(err, ErrURLMetadataBlocked) {
+		t.Fatalf("expected ErrURLMetadataBlocked, got %v", err)
+	}
+
+	_, _, err = validator.ValidateAndResolve(context.Background(), "https://missing.example")
+	if !errors.Is(err, ErrURLResolveFailed) {
+		t.Fatalf("expected ErrURLResolveFailed, got %v", err)
+	}
+
+	_, _, err = validator.ValidateAndResolve(context.Background(), "https://empty.example")
+	if !errors.Is(err, ErrURLResolveFailed) {
+		t.Fatalf("expected ErrURLResolveFailed for no addresses, got %v", err)
+	}
+}
+
+func TestURLValidateAndResolvePolicy(t *testing.T) {
+	t.Parallel()
+
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"internal.example": {"10.0.0.5"},
+			"localhost":        {"127.0.0.1", "::1"},
+		},
+	}
+
+	allowed, err := NewURLValidator(WithURLDNSResolver(resolver), WithURLAllowedCIDRs("10.0.0.0/24"))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, ips, err := allowed.ValidateAndResolve(context.Background(), "https://internal.example")
+	if err != nil || len(ips) != 1 {
+		t.Fatalf("expected allowed CIDR to pass, got %v %v", ips, err)
+	}
+
+	local, err := NewURLValidator(WithURLDNSResolver(resolver), WithURLAllowLocalhost(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, ips, err = local.ValidateAndResolve(context.Background(), "https://localhost")
+	if err != nil || len(ips) != 2 {
+		t.Fatalf("expected allowed localhost to pass, got %v %v", ips, err)
+	}
+
+	literal, err := NewURLValidator(WithURLAllowIPLiteral(true))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, ips, err = literal.ValidateAndResolve(context.Background(), "https://93.184.216.34")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.ParseIP("93.184.216.34")) {
+		t.Fatalf("expected literal address without lookup, got %v %v", ips, err)
+	}
+
+	_, err = NewURLValidator(WithURLDNSResolver(nil))
+	if !errors.Is(err, ErrInvalidURLConfig) {
+		t.Fatalf("expected ErrInvalidURLConfig, got %v", err)
+	}
+}
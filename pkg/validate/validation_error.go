@@ -0,0 +1,51 @@
+package validate
+
+import "strconv"
+
+// Fields reported by ValidationError.
+const (
+	// FieldScheme identifies the URL scheme.
+	FieldScheme = "scheme"
+	// FieldHost identifies the URL host.
+	FieldHost = "host"
+	// FieldQueryParam identifies a URL query parameter name; the value is never reported.
+	FieldQueryParam = "query parameter"
+	// FieldDomain identifies the email domain.
+	FieldDomain = "domain"
+	// FieldLabel identifies a single email domain label.
+	FieldLabel = "label"
+)
+
+// ValidationError wraps a validation sentinel with the input component that failed.
+// It unwraps to the sentinel, so errors.Is checks against ErrURLHostNotAllowed and
+// friends keep working; use errors.As to read the offending field and value.
+// Email local parts and query parameter values are never carried.
+type ValidationError struct {
+	err   error
+	field string
+	value string
+}
+
+// Error returns the sentinel message followed by the offending field and value.
+func (e *ValidationError) Error() string {
+	return e.err.Error() + ": " + e.field + " " + strconv.Quote(e.value)
+}
+
+// Unwrap returns the wrapped sentinel.
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// Field returns the name of the input component that failed, such as FieldHost.
+func (e *ValidationError) Field() string {
+	return e.field
+}
+
+// Value returns the offending value of the field.
+func (e *ValidationError) Value() string {
+	return e.value
+}
+
+func newValidationError(err error, field, value string) error {
+	return &ValidationError{err: err, field: field, value: value}
+}
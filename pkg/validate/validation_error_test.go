@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidationErrorURLDetails(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewURLValidator(WithURLBlockedHosts("evil.example.com"))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	cases := []struct {
+		raw      string
+		sentinel error
+		field    string
+		value    string
+	}{
+		{"https://Evil.Example.com/path", ErrURLHostNotAllowed, FieldHost, "evil.example.com"},
+		{"http://example.com", ErrURLSchemeNotAllowed, FieldScheme, "http"},
+		{"https://127.0.0.1/", ErrURLHostNotAllowed, FieldHost, "127.0.0.1"},
+	}
+
+	for _, tc := range cases {
+		_, err = validator.Validate(context.Background(), tc.raw)
+		if !errors.Is(err, tc.sentinel) {
+			t.Fatalf("%s: expected %v, got %v", tc.raw, tc.sentinel, err)
+		}
+
+		var details *ValidationError
+		if !errors.As(err, &details) {
+			t.Fatalf("%s: expected ValidationError, got %T", tc.raw, err)
+		}
+
+		if details.Field() != tc.field || details.Value() != tc.value {
+			t.Fatalf("%s: expected %s %q, got %s %q", tc.raw, tc.field, tc.value, details.Field(), details.Value())
+		}
+	}
+}
+
+func TestValidationErrorOmitsQueryValues(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewURLValidator(WithURLRejectSecretQueryParams("token"))
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "https://example.com/cb?Token=s3cr3t")
+
+	var details *ValidationError
+	if !errors.As(err, &details) || !errors.Is(err, ErrURLSecretInQuery) {
+		t.Fatalf("expected ValidationError wrapping ErrURLSecretInQuery, got %v", err)
+	}
+
+	if details.Field() != FieldQueryParam || details.Value() != "Token" {
+		t.Fatalf("expected query parameter name, got %s %q", details.Field(), details.Value())
+	}
+}
+
+func TestValidationErrorEmailLabel(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewEmailValidator()
+	if err != nil {
+		t.Fatalf(errMsgValidator, err)
+	}
+
+	_, err = validator.Validate(context.Background(), "user@mail.-bad.example.com")
+	if !errors.Is(err, ErrEmailDomainInvalid) {
+		t.Fatalf("expected ErrEmailDomainInvalid, got %v", err)
+	}
+
+	var details *ValidationError
+	if !errors.As(err, &details) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+
+	if details.Field() != FieldLabel || details.Value() != "-bad" {
+		t.Fatalf("expected label %q, got %s %q", "-bad", details.Field(), details.Value())
+	}
+
+	if err.Error() != `email domain is invalid: label "-bad"` {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}